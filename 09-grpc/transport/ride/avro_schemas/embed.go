@@ -6,3 +6,8 @@ import _ "embed"
 //
 //go:embed assignment.avsc
 var Assignment []byte
+
+// AssignmentStatusChanged holds the embedded Avro schema for status changes.
+//
+//go:embed assignment_status_changed.avsc
+var AssignmentStatusChanged []byte
@@ -1,14 +1,32 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
+	_ "time/tzdata" // recurring assignments need time zones even in minimal images
 
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/auth"
+	"github.com/yourname/transport/ride/internal/adapters/grpcclient"
+	"github.com/yourname/transport/ride/internal/adapters/grpcserver"
 	"github.com/yourname/transport/ride/internal/adapters/httpserver"
+	"github.com/yourname/transport/ride/internal/adapters/pulsar_connector"
 	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/service"
 )
 
 func main() {
@@ -19,26 +37,367 @@ func main() {
 	safe := *cfg
 	safe.Database.Password = "<redacted>"
 	log.Printf("Loaded config: %+v", safe)
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-	)
-	db, err := sql.Open("mysql", dsn)
+
+	db, err := openDB(cfg.Database)
 	if err != nil {
 		log.Fatalf("failed to open database: %v", err)
 	}
 	defer db.Close()
 
-	// Pool configuration (database/sql pool lives inside *sql.DB)
-	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	db.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetime) * time.Second)
-	db.SetConnMaxIdleTime(time.Duration(cfg.Database.ConnMaxIdleTime) * time.Second)
+	// "ride migrate ..." manages the schema and exits instead of serving.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), db, cfg.Database.Driver, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	var verifier ports.TokenVerifier
+	if cfg.Auth.Enabled {
+		jwtVerifier, err := auth.LoadJWTVerifier(cfg.Auth)
+		if err != nil {
+			log.Fatalf("failed to load auth keys: %v", err)
+		}
+		verifier = jwtVerifier
+	}
+
+	// Cancelled on SIGINT/SIGTERM; every background loop and the HTTP and
+	// gRPC servers stop when it is.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pulsarClient, err := pulsar_connector.NewPulsarClient(cfg.Pulsar)
+	if err != nil {
+		log.Fatalf("failed to create pulsar client: %v", err)
+	}
+	defer pulsarClient.Close()
+
+	assignmentProducer, err := pulsar_connector.NewAssignmentCreatedProducer(pulsarClient, cfg.Pulsar.AssignmentProducer)
+	if err != nil {
+		log.Fatalf("failed to create assignment producer: %v", err)
+	}
+	defer assignmentProducer.Close()
+
+	statusProducer, err := pulsar_connector.NewAssignmentStatusChangedProducer(pulsarClient, cfg.Pulsar.AssignmentStatusProducer)
+	if err != nil {
+		log.Fatalf("failed to create assignment status producer: %v", err)
+	}
+	defer statusProducer.Close()
+
+	store := newStorage(cfg.Database.Driver, db)
+	assignmentRepo := store.assignments
+
+	// Assignments created without a vehicle get one from the vehicle
+	// service; the client dials on first use. With sagas, assignments also
+	// hold their vehicle there until they end.
+	var (
+		vehicles ports.VehicleFinder
+		reserver ports.VehicleReserver
+	)
+	if cfg.Vehicles.Enabled {
+		vehicleClient := grpcclient.NewVehicleGrpcClient(cfg.Vehicles.Target)
+		defer vehicleClient.Close()
+		vehicles = grpcclient.NewVehicleFinder(vehicleClient, cfg.Vehicles.Timeout)
+		if cfg.Sagas.Enabled {
+			reserver = grpcclient.NewVehicleReserver(vehicleClient, cfg.Vehicles.Timeout)
+		}
+	}
+
+	// Every event goes to Pulsar and is then queued for webhooks; a failure
+	// of either step retries both, which neither side minds. A status change
+	// that ends an assignment releases its vehicle before either.
+	enqueueWebhooks := service.EnqueueWebhooks(store.webhooks, store.webhooks)
+	statusHandlers := []service.OutboxHandler{service.PublishAssignmentStatusChanged(statusProducer), enqueueWebhooks}
+	if reserver != nil {
+		statusHandlers = append([]service.OutboxHandler{service.ReleaseVehicleOnTerminalStatus(reserver)}, statusHandlers...)
+	}
+	relay := service.NewOutboxRelay(store.outbox, map[string]service.OutboxHandler{
+		models.EventAssignmentCreated:       service.ChainOutboxHandlers(service.PublishAssignmentCreated(assignmentProducer), enqueueWebhooks),
+		models.EventAssignmentStatusChanged: service.ChainOutboxHandlers(statusHandlers...),
+	}, service.OutboxRelayOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MinBackoff:   cfg.Outbox.MinBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	})
+
+	var wg sync.WaitGroup
+	wg.Go(func() { relay.Run(ctx) })
+	wg.Go(func() { purgeExpiredIdempotencyKeys(ctx, store.idempotency, time.Hour) })
+
+	if cfg.Webhooks.Enabled {
+		dispatcher := service.NewWebhookDispatcher(store.webhooks, store.webhooks, service.WebhookDispatcherOptions{
+			PollInterval: cfg.Webhooks.PollInterval,
+			BatchSize:    cfg.Webhooks.BatchSize,
+			Concurrency:  cfg.Webhooks.Concurrency,
+			Timeout:      cfg.Webhooks.Timeout,
+			MinBackoff:   cfg.Webhooks.MinBackoff,
+			MaxBackoff:   cfg.Webhooks.MaxBackoff,
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			DisableAfter: cfg.Webhooks.DisableAfter,
+		})
+		wg.Go(func() { dispatcher.Run(ctx) })
+	}
+
+	var cached *repository.CachedAssignmentRepository
+	if cfg.Cache.Enabled {
+		cached, err = repository.NewCachedAssignmentRepository(assignmentRepo, repository.CacheOptions{
+			Size:       cfg.Cache.Size,
+			TTL:        cfg.Cache.TTL,
+			Registerer: prometheus.DefaultRegisterer,
+		})
+		if err != nil {
+			log.Fatalf("failed to create assignment cache: %v", err)
+		}
+		assignmentRepo = cached
+
+		if cfg.Cache.InvalidateFromEvents {
+			consumer, err := pulsar_connector.NewAssignmentEventsBroadcastConsumer(pulsarClient, cfg.Pulsar.CacheInvalidationConsumer)
+			if err != nil {
+				log.Fatalf("failed to create cache invalidation consumer: %v", err)
+			}
+			defer consumer.Close()
+			wg.Go(func() {
+				_ = consumer.Start(ctx, service.CacheInvalidationProcessor{Invalidate: cached.Invalidate})
+			})
+		}
+	}
+
+	if cfg.Scheduler.Enabled {
+		// The scheduler reads the uncached repository: its compare-and-set
+		// transitions need the current versions. It evicts what it changed
+		// from the local cache.
+		var invalidate func(ctx context.Context, assignmentID string)
+		if cached != nil {
+			invalidate = cached.Invalidate
+		}
+		scheduler := service.NewStatusScheduler(store.assignments, newLeaderLock(cfg.Database.Driver, db, cfg.Scheduler.LockName), service.StatusSchedulerOptions{
+			Interval:      cfg.Scheduler.Interval,
+			CompleteAfter: cfg.Scheduler.CompleteAfter,
+			ExpireAfter:   cfg.Scheduler.ExpireAfter,
+			BatchSize:     cfg.Scheduler.BatchSize,
+			Tenants:       cfg.Tenancy.Tenants,
+			Invalidate:    invalidate,
+		})
+		wg.Go(func() { scheduler.Run(ctx) })
+	}
+
+	// The broadcaster reads the audit trail, so it sees the changes of every
+	// replica.
+	broadcaster := service.NewAssignmentBroadcaster(store.assignments, service.AssignmentBroadcasterOptions{
+		PollInterval: cfg.Stream.PollInterval,
+		BatchSize:    cfg.Stream.BatchSize,
+		ReplaySize:   cfg.Stream.ReplaySize,
+		BufferSize:   cfg.Stream.BufferSize,
+		GapTimeout:   cfg.Stream.GapTimeout,
+	})
+	wg.Go(func() { broadcaster.Run(ctx) })
+
+	// New assignments hold their vehicle in the vehicle service. Every
+	// replica resumes interrupted sagas; claiming one is a compare-and-set,
+	// so only one replica settles it.
+	var sagas *service.AssignmentSagaOrchestrator
+	if reserver != nil {
+		sagas = service.NewAssignmentSagaOrchestrator(assignmentRepo, store.sagas, reserver, service.AssignmentSagaOptions{
+			ResumeInterval: cfg.Sagas.ResumeInterval,
+			StaleAfter:     cfg.Sagas.StaleAfter,
+			BatchSize:      cfg.Sagas.BatchSize,
+		})
+		wg.Go(func() { sagas.Run(ctx) })
+	}
+
+	materializer := service.NewRecurrenceMaterializer(store.recurring, assignmentRepo, sagas, service.RecurrenceMaterializerOptions{
+		Horizon:  cfg.Recurrence.Horizon,
+		Interval: cfg.Recurrence.Interval,
+		Tenants:  cfg.Tenancy.Tenants,
+	})
+	wg.Go(func() { materializer.Run(ctx) })
+	recurringService := service.NewRecurringAssignmentService(store.recurring, materializer)
+
+	// REST and gRPC share the rate limiter and the load shedder, so a client
+	// and the instance have one budget across both APIs.
+	limiter, shedder, err := newAdmission(cfg)
+	if err != nil {
+		log.Fatalf("failed to create admission control: %v", err)
+	}
+	// REST and gRPC share one service, so both APIs validate, audit and
+	// publish assignments the same way.
+	assignmentService := service.NewAssignmentService(assignmentRepo, vehicles, sagas)
+	if cfg.GRPC.Enabled {
+		grpcSrv := grpcserver.NewGRPCServer(cfg.GRPC, grpcserver.NewRideServer(assignmentService, broadcaster), grpcserver.Deps{
+			Verifier:           verifier,
+			RequireTenantClaim: cfg.Auth.TenantClaimRequired(),
+			Tenants:            cfg.Tenancy.Tenants,
+			RateLimiter:        limiter,
+			LoadShedder:        shedder,
+		})
+		wg.Go(func() {
+			if err := grpcserver.Run(ctx, cfg.GRPC, grpcSrv); err != nil {
+				log.Printf("grpc server failed: %v", err)
+				stop()
+			}
+		})
+	}
+	serverErr := httpserver.Run(ctx, cfg.Server, httpserver.Deps{
+		Assignments:        assignmentService,
+		Recurring:          recurringService,
+		Webhooks:           service.NewWebhookService(store.webhooks),
+		Feed:               broadcaster,
+		Idempotency:        store.idempotency,
+		Verifier:           verifier,
+		RequireTenantClaim: cfg.Auth.TenantClaimRequired(),
+		IdempotencyConfig:  cfg.Idempotency,
+		Tenancy:            cfg.Tenancy,
+		RateLimiter:        limiter,
+		LoadShedder:        shedder,
+	})
+	stop()
+
+	// Let the relay finish its current batch, the dispatcher its requests,
+	// and the scheduler release its leader lock, before the producers, the
+	// Pulsar client and the database are closed.
+	wg.Wait()
+	if serverErr != nil {
+		log.Fatalf("http server failed: %v", serverErr)
+	}
+	log.Println("ride service stopped")
+}
+
+// purgeExpiredIdempotencyKeys keeps the idempotency_keys table from growing
+// without bound. Expired keys are already ignored on lookup, so a missed run
+// only costs disk space.
+func purgeExpiredIdempotencyKeys(ctx context.Context, store ports.IdempotencyStore, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := store.DeleteExpired(ctx, time.Now().UTC())
+			if err != nil {
+				log.Printf("purge idempotency keys: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("purged %d expired idempotency keys", n)
+			}
+		}
+	}
+}
+
+// storage holds the repository adapters of one database.
+type storage struct {
+	assignments ports.AssignmentRepository
+	recurring   ports.RecurringAssignmentRepository
+	idempotency ports.IdempotencyStore
+	outbox      ports.OutboxStore
+	webhooks    ports.WebhookStore
+	sagas       ports.AssignmentSagaStore
+}
+
+// newStorage picks the repository adapters matching the configured driver.
+func newStorage(driver string, db *sql.DB) storage {
+	if driver == configs.DriverPostgres {
+		return storage{
+			assignments: repository.NewPostgresAssignmentRepository(db),
+			recurring:   repository.NewPostgresRecurringAssignmentRepository(db),
+			idempotency: repository.NewPostgresIdempotencyStore(db),
+			outbox:      repository.NewPostgresOutboxStore(db),
+			webhooks:    repository.NewPostgresWebhookStore(db),
+			sagas:       repository.NewPostgresAssignmentSagaStore(db),
+		}
+	}
+	return storage{
+		assignments: repository.NewSQLAssignmentRepository(db),
+		recurring:   repository.NewSQLRecurringAssignmentRepository(db),
+		idempotency: repository.NewSQLIdempotencyStore(db),
+		outbox:      repository.NewSQLOutboxStore(db),
+		webhooks:    repository.NewSQLWebhookStore(db),
+		sagas:       repository.NewSQLAssignmentSagaStore(db),
+	}
+}
+
+// newAdmission builds the rate limiter and the load shedder that are
+// enabled; the others are nil.
+func newAdmission(cfg *configs.Config) (*admission.RateLimiter, *admission.LoadShedder, error) {
+	var (
+		limiter *admission.RateLimiter
+		shedder *admission.LoadShedder
+		err     error
+	)
+	if limits := cfg.RateLimit; limits.Enabled {
+		operations := make(map[string]admission.Limit, len(limits.Operations))
+		for op, l := range limits.Operations {
+			operations[op] = admission.Limit{Rate: l.Rate, Burst: l.Burst}
+		}
+		limiter, err = admission.NewRateLimiter(admission.RateLimiterOptions{
+			Default:    admission.Limit{Rate: limits.Default.Rate, Burst: limits.Default.Burst},
+			Operations: operations,
+			MaxClients: limits.MaxClients,
+			Registerer: prometheus.DefaultRegisterer,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+	if shedding := cfg.LoadShedding; shedding.Enabled {
+		shedder, err = admission.NewLoadShedder(admission.LoadShedderOptions{
+			MaxInFlight: cfg.MaxInFlight(),
+			Exempt:      shedding.ExemptOperations,
+			Registerer:  prometheus.DefaultRegisterer,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("load shedder: %w", err)
+		}
+	}
+	return limiter, shedder, nil
+}
+
+func newLeaderLock(driver string, db *sql.DB, name string) ports.LeaderLock {
+	if name == "" {
+		name = "ride-status-scheduler"
+	}
+	if driver == configs.DriverPostgres {
+		return repository.NewPostgresLeaderLock(db, name)
+	}
+	return repository.NewMySQLLeaderLock(db, name)
+}
 
-	assignmentRepo := repository.NewSQLAssignmentRepository(db)
+func openDB(cfg configs.DatabaseConfig) (*sql.DB, error) {
+	var (
+		driver = configs.DriverMySQL
+		dsn    = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
+			cfg.User,
+			cfg.Password,
+			cfg.Host,
+			cfg.Port,
+			cfg.Name,
+		)
+	)
+	if cfg.Driver == configs.DriverPostgres {
+		sslMode := cfg.SSLMode
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+		driver = configs.DriverPostgres
+		dsn = (&url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Path:     cfg.Name,
+			RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+		}).String()
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
 
-	httpserver.Run(cfg.Server, assignmentRepo)
+	// Pool configuration (database/sql pool lives inside *sql.DB)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+	return db, nil
 }
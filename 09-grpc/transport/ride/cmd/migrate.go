@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/migrations"
+)
+
+const migrateUsage = `usage: ride migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [N]      revert the last N applied migrations (default 1)
+  status        list migrations and whether they are applied
+  to <version>  migrate up or down to exactly <version> (0 reverts all)`
+
+// runMigrate implements the "migrate" subcommand.
+func runMigrate(ctx context.Context, db *sql.DB, driver string, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", migrateUsage)
+	}
+
+	var (
+		m   *repository.Migrator
+		err error
+	)
+	if driver == configs.DriverPostgres {
+		m, err = repository.NewPostgresMigrator(db, migrations.Postgres)
+	} else {
+		m, err = repository.NewMigrator(db, migrations.MySQL)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		err = m.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("down: N must be a positive number, got %q", args[1])
+			}
+		}
+		err = m.Down(ctx, steps)
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("to: missing version\n%s", migrateUsage)
+		}
+		version, perr := strconv.ParseInt(args[1], 10, 64)
+		if perr != nil || version < 0 {
+			return fmt.Errorf("to: version must be a non-negative number, got %q", args[1])
+		}
+		err = m.To(ctx, version)
+	case "status":
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(ctx, m, out)
+}
+
+func printMigrationStatus(ctx context.Context, m *repository.Migrator, out io.Writer) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, st := range statuses {
+		state, at := "pending", ""
+		if st.Applied {
+			state, at = "applied", st.AppliedAt.Format(time.RFC3339)
+		}
+		if st.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, at)
+	}
+	return w.Flush()
+}
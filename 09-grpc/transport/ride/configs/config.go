@@ -12,12 +12,50 @@ import (
 )
 
 type ServerConfig struct {
-	Port            int `yaml:"port"`
-	ReadTimeoutSec  int `yaml:"read_timeout_sec"`
-	WriteTimeoutSec int `yaml:"write_timeout_sec"`
+	Port               int   `yaml:"port"`
+	ReadTimeoutSec     int   `yaml:"read_timeout_sec"`
+	WriteTimeoutSec    int   `yaml:"write_timeout_sec"`
+	StreamHeartbeatSec int   `yaml:"stream_heartbeat_sec"`  // idle interval before a heartbeat on /assignments/stream; defaults to 15 when zero
+	MaxBodyBytes       int64 `yaml:"max_body_bytes"`        // largest request body; defaults to 1 MiB when zero
+	MaxImportBodyBytes int64 `yaml:"max_import_body_bytes"` // largest batchImportAssignments body; defaults to 16 MiB when zero
 }
 
+// GRPCConfig configures the gRPC API, served next to the HTTP one on its own
+// port.
+type GRPCConfig struct {
+	Enabled              bool `yaml:"enabled"`
+	Port                 int  `yaml:"port"`
+	ConnectionTimeoutSec int  `yaml:"connection_timeout_sec"` // handshake deadline for new connections; defaults to 120 when zero
+	ShutdownTimeoutSec   int  `yaml:"shutdown_timeout_sec"`   // how long open calls may finish on shutdown; defaults to 10 when zero
+}
+
+// VehicleServiceConfig points at the vehicle service, which picks the vehicle
+// of assignments created without one. When disabled vehicleId is required.
+type VehicleServiceConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Target  string        `yaml:"target"`  // host:port or a gRPC target URI
+	Timeout time.Duration `yaml:"timeout"` // per lookup, dialing and retries included; defaults to 2s when zero
+}
+
+// AssignmentSagaConfig makes createAssignment reserve the vehicle in the
+// vehicle service before storing the assignment, releasing it again when
+// the assignment cannot be stored. It needs vehicle_service. Zero values
+// fall back to the orchestrator defaults.
+type AssignmentSagaConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	ResumeInterval time.Duration `yaml:"resume_interval"` // how often interrupted sagas are looked up
+	StaleAfter     time.Duration `yaml:"stale_after"`     // a saga untouched this long counts as interrupted; must exceed the longest request
+	BatchSize      int           `yaml:"batch_size"`      // sagas resumed per run
+}
+
+// Database drivers accepted in DatabaseConfig.Driver.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
 type DatabaseConfig struct {
+	Driver          string `yaml:"driver"` // mysql|postgres; defaults to mysql when empty
 	Host            string `yaml:"host"`
 	Port            int    `yaml:"port"`
 	User            string `yaml:"user"`
@@ -27,6 +65,7 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    `yaml:"max_idle_conns"`
 	ConnMaxLifetime int    `yaml:"conn_max_lifetime_sec"`
 	ConnMaxIdleTime int    `yaml:"conn_max_idle_time_sec"`
+	SSLMode         string `yaml:"ssl_mode"` // postgres only; defaults to "disable" when empty
 }
 
 type PulsarConfig struct {
@@ -41,6 +80,14 @@ type PulsarConfig struct {
 
 	Consumer PulsarConsumerConfig `yaml:"consumer"`
 	Producer PulsarProducerConfig `yaml:"producer"`
+	// AssignmentProducer publishes AssignmentCreated events relayed from the outbox.
+	AssignmentProducer PulsarProducerConfig `yaml:"assignment_producer"`
+	// AssignmentStatusProducer publishes AssignmentStatusChanged events
+	// relayed from the outbox.
+	AssignmentStatusProducer PulsarProducerConfig `yaml:"assignment_status_producer"`
+	// CacheInvalidationConsumer reads the same events back to evict cached
+	// assignments; each instance gets its own subscription.
+	CacheInvalidationConsumer PulsarConsumerConfig `yaml:"cache_invalidation_consumer"`
 }
 
 type PulsarConsumerConfig struct {
@@ -67,10 +114,156 @@ type PulsarProducerConfig struct {
 	BatchingMaxPublishDelay         time.Duration  `yaml:"batching_max_publish_delay"`
 }
 
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl"` // how long Idempotency-Key responses are replayed; defaults to 24h when zero
+}
+
+// OutboxConfig tunes the relay that publishes outbox rows. Zero values fall
+// back to the relay defaults.
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"` // how often pending rows are polled
+	BatchSize    int           `yaml:"batch_size"`    // rows published per poll
+	MinBackoff   time.Duration `yaml:"min_backoff"`   // delay after the first failed attempt; doubles per attempt
+	MaxBackoff   time.Duration `yaml:"max_backoff"`   // upper bound for the retry delay
+}
+
+// CacheConfig enables the read-through assignment cache. Zero size and TTL
+// fall back to the repository defaults.
+type CacheConfig struct {
+	Enabled              bool          `yaml:"enabled"`
+	Size                 int           `yaml:"size"`                   // entries per LRU (by id, listings)
+	TTL                  time.Duration `yaml:"ttl"`                    // upper bound for serving another instance's stale write
+	InvalidateFromEvents bool          `yaml:"invalidate_from_events"` // evict on AssignmentCreated events from Pulsar
+}
+
+// RecurrenceConfig tunes the job that materializes recurring assignments.
+// Zero values fall back to the materializer defaults.
+type RecurrenceConfig struct {
+	Horizon  time.Duration `yaml:"horizon"`  // how far ahead occurrences become assignments
+	Interval time.Duration `yaml:"interval"` // how often the horizon is rolled forward
+}
+
+// SchedulerConfig tunes the job that activates, completes and expires
+// assignments as time passes. Zero durations and sizes fall back to the
+// scheduler defaults. Replicas other than the one running it see its
+// transitions once their cache entries expire, after cache.ttl at most.
+type SchedulerConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Interval      time.Duration `yaml:"interval"`       // how often due assignments are looked up
+	CompleteAfter time.Duration `yaml:"complete_after"` // active assignments complete this long after they end
+	ExpireAfter   time.Duration `yaml:"expire_after"`   // pending assignments are cancelled this long after they end
+	BatchSize     int           `yaml:"batch_size"`     // assignments read per query
+	LockName      string        `yaml:"lock_name"`      // leader lock shared by all replicas; defaults to "ride-status-scheduler"
+}
+
+// WebhookConfig tunes delivery of assignment events to registered webhooks.
+// Zero values fall back to the dispatcher defaults.
+type WebhookConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"` // how often due deliveries are polled
+	BatchSize    int           `yaml:"batch_size"`    // deliveries fetched per poll
+	Concurrency  int           `yaml:"concurrency"`   // requests in flight at once
+	Timeout      time.Duration `yaml:"timeout"`       // per request
+	MinBackoff   time.Duration `yaml:"min_backoff"`   // delay after the first failed attempt; doubles per attempt
+	MaxBackoff   time.Duration `yaml:"max_backoff"`   // upper bound for the retry delay
+	MaxAttempts  int           `yaml:"max_attempts"`  // a delivery fails for good after this many attempts
+	DisableAfter int           `yaml:"disable_after"` // consecutive failed attempts that disable a webhook
+}
+
+// StreamConfig tunes the broadcaster behind /assignments/stream. Zero values
+// fall back to the broadcaster defaults.
+type StreamConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"` // how often new audit entries are read
+	BatchSize    int           `yaml:"batch_size"`    // audit entries read per query
+	ReplaySize   int           `yaml:"replay_size"`   // recent changes kept for clients resuming with Last-Event-ID
+	BufferSize   int           `yaml:"buffer_size"`   // changes queued per client before it is disconnected
+	GapTimeout   time.Duration `yaml:"gap_timeout"`   // how long a missing audit ID is waited for
+}
+
+// TenancyConfig lists the tenants, one per city, that this deployment
+// serves. Authenticated requests act for the tenant of their token;
+// otherwise requests pick theirs with the X-Tenant-ID header. When the list
+// is empty only the "default" tenant is served, and requests may leave the
+// header out whenever "default" is served.
+type TenancyConfig struct {
+	Tenants []string `yaml:"tenants"` // tenant IDs, at most 50 characters; background jobs run for each
+}
+
+// maxTenantIDLength matches the tenant_id columns.
+const maxTenantIDLength = 50
+
+// AuthConfig configures JWT bearer authentication of the API. Tokens are
+// verified with the keys of JWKSFile and Keys, picked by the kid header;
+// every key names the one algorithm it may be used with.
+type AuthConfig struct {
+	Enabled     bool            `yaml:"enabled"`
+	Issuer      string          `yaml:"issuer"`       // expected "iss" claim
+	Audience    string          `yaml:"audience"`     // must be among the "aud" claims
+	JWKSFile    string          `yaml:"jwks_file"`    // JSON Web Key Set; every key needs "kid" and "alg"
+	Keys        []AuthKeyConfig `yaml:"keys"`         // static keys, next to or instead of the JWKS
+	ClockSkew   time.Duration   `yaml:"clock_skew"`   // leeway for exp, nbf and iat; defaults to 30s
+	TenantClaim string          `yaml:"tenant_claim"` // claim pinning a token to a tenant; defaults to "tenant"
+	// RequireTenantClaim refuses tokens without a tenant claim; when it is
+	// set to false they act for the "default" tenant. Defaults to true.
+	RequireTenantClaim *bool `yaml:"require_tenant_claim"`
+}
+
+// TenantClaimRequired reports whether tokens without a tenant claim are
+// refused.
+func (c AuthConfig) TenantClaimRequired() bool {
+	return c.RequireTenantClaim == nil || *c.RequireTenantClaim
+}
+
+// AuthKeyConfig is a static verification key.
+type AuthKeyConfig struct {
+	ID        string `yaml:"id"`        // matched against the kid header
+	Algorithm string `yaml:"algorithm"` // e.g. RS256, ES256, EdDSA or HS256
+	File      string `yaml:"file"`      // PEM public key; the raw secret for HS256/384/512
+}
+
+// RateLimitConfig limits how fast each API client, identified by its token
+// subject or else its IP address, may call each operation. Limits are token
+// buckets kept per instance.
+type RateLimitConfig struct {
+	Enabled    bool                 `yaml:"enabled"`
+	Default    RateLimit            `yaml:"default"`     // operations not listed in Operations
+	Operations map[string]RateLimit `yaml:"operations"`  // by operationId
+	MaxClients int                  `yaml:"max_clients"` // buckets kept; the least recently used are dropped; defaults to 10000
+}
+
+// RateLimit allows Rate requests per second on average and bursts of up to
+// Burst requests.
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`  // zero means unlimited
+	Burst int     `yaml:"burst"` // defaults to the rate rounded up
+}
+
+// LoadSheddingConfig caps the API requests served at once, so that a spike
+// is turned away with 503 before it exhausts the database pool.
+type LoadSheddingConfig struct {
+	Enabled          bool     `yaml:"enabled"`
+	MaxInFlight      int      `yaml:"max_in_flight"`     // defaults to what database.max_open_conns leaves after the background jobs
+	ExemptOperations []string `yaml:"exempt_operations"` // long-lived operations that hold no connection, e.g. streamAssignments
+}
+
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Pulsar   PulsarConfig   `yaml:"pulsar"`
+	Server       ServerConfig         `yaml:"server"`
+	GRPC         GRPCConfig           `yaml:"grpc"`
+	Vehicles     VehicleServiceConfig `yaml:"vehicle_service"`
+	Sagas        AssignmentSagaConfig `yaml:"assignment_saga"`
+	Database     DatabaseConfig       `yaml:"database"`
+	Pulsar       PulsarConfig         `yaml:"pulsar"`
+	Idempotency  IdempotencyConfig    `yaml:"idempotency"`
+	Outbox       OutboxConfig         `yaml:"outbox"`
+	Cache        CacheConfig          `yaml:"cache"`
+	Recurrence   RecurrenceConfig     `yaml:"recurrence"`
+	Scheduler    SchedulerConfig      `yaml:"scheduler"`
+	Webhooks     WebhookConfig        `yaml:"webhooks"`
+	Stream       StreamConfig         `yaml:"stream"`
+	Tenancy      TenancyConfig        `yaml:"tenancy"`
+	Auth         AuthConfig           `yaml:"auth"`
+	RateLimit    RateLimitConfig      `yaml:"rate_limit"`
+	LoadShedding LoadSheddingConfig   `yaml:"load_shedding"`
 }
 
 // LoadConfig reads and parses the configuration file from the given path.
@@ -115,9 +308,57 @@ func (c Config) Validate() error {
 	if err := c.validateServer(); err != nil {
 		errs = append(errs, fmt.Errorf("server: %w", err))
 	}
+	if err := c.validateGRPC(); err != nil {
+		errs = append(errs, fmt.Errorf("grpc: %w", err))
+	}
+	if err := c.validateVehicleService(); err != nil {
+		errs = append(errs, fmt.Errorf("vehicle_service: %w", err))
+	}
+	if err := c.validateAssignmentSaga(); err != nil {
+		errs = append(errs, fmt.Errorf("assignment_saga: %w", err))
+	}
 	if err := c.validateDatabase(); err != nil {
 		errs = append(errs, fmt.Errorf("database: %w", err))
 	}
+	if c.Idempotency.TTL < 0 {
+		errs = append(errs, fmt.Errorf("idempotency: ttl %s must be >= 0", c.Idempotency.TTL))
+	}
+	if err := c.validateOutbox(); err != nil {
+		errs = append(errs, fmt.Errorf("outbox: %w", err))
+	}
+	if c.Cache.Size < 0 {
+		errs = append(errs, fmt.Errorf("cache: size %d must be >= 0", c.Cache.Size))
+	}
+	if c.Cache.TTL < 0 {
+		errs = append(errs, fmt.Errorf("cache: ttl %s must be >= 0", c.Cache.TTL))
+	}
+	if c.Recurrence.Horizon < 0 {
+		errs = append(errs, fmt.Errorf("recurrence: horizon %s must be >= 0", c.Recurrence.Horizon))
+	}
+	if c.Recurrence.Interval < 0 {
+		errs = append(errs, fmt.Errorf("recurrence: interval %s must be >= 0", c.Recurrence.Interval))
+	}
+	if err := c.validateScheduler(); err != nil {
+		errs = append(errs, fmt.Errorf("scheduler: %w", err))
+	}
+	if err := c.validateWebhooks(); err != nil {
+		errs = append(errs, fmt.Errorf("webhooks: %w", err))
+	}
+	if err := c.validateStream(); err != nil {
+		errs = append(errs, fmt.Errorf("stream: %w", err))
+	}
+	if err := c.validateTenancy(); err != nil {
+		errs = append(errs, fmt.Errorf("tenancy: %w", err))
+	}
+	if err := c.validateAuth(); err != nil {
+		errs = append(errs, fmt.Errorf("auth: %w", err))
+	}
+	if err := c.validateRateLimit(); err != nil {
+		errs = append(errs, fmt.Errorf("rate_limit: %w", err))
+	}
+	if err := c.validateLoadShedding(); err != nil {
+		errs = append(errs, fmt.Errorf("load_shedding: %w", err))
+	}
 
 	// If you prefer fail-fast, just return the first error instead of joining.
 	return errors.Join(errs...)
@@ -137,6 +378,15 @@ func (c Config) validateServer() error {
 	if c.Server.WriteTimeoutSec < 0 {
 		errs = append(errs, fmt.Errorf("write_timeout_sec %d must be >= 0", c.Server.WriteTimeoutSec))
 	}
+	if c.Server.StreamHeartbeatSec < 0 {
+		errs = append(errs, fmt.Errorf("stream_heartbeat_sec %d must be >= 0", c.Server.StreamHeartbeatSec))
+	}
+	if c.Server.MaxBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("max_body_bytes %d must be >= 0", c.Server.MaxBodyBytes))
+	}
+	if c.Server.MaxImportBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("max_import_body_bytes %d must be >= 0", c.Server.MaxImportBodyBytes))
+	}
 
 	// Optional enterprise sanity: warn-like validation (still error) for extreme values.
 	// Example: timeouts too large are almost always a misconfig.
@@ -150,9 +400,65 @@ func (c Config) validateServer() error {
 	return errors.Join(errs...)
 }
 
+func (c Config) validateGRPC() error {
+	if !c.GRPC.Enabled {
+		return nil
+	}
+	var errs []error
+
+	switch {
+	case c.GRPC.Port < 1 || c.GRPC.Port > 65535:
+		errs = append(errs, fmt.Errorf("port %d is out of range (1..65535)", c.GRPC.Port))
+	case c.GRPC.Port == c.Server.Port:
+		errs = append(errs, fmt.Errorf("port %d is already used by the HTTP server", c.GRPC.Port))
+	}
+	if c.GRPC.ConnectionTimeoutSec < 0 {
+		errs = append(errs, fmt.Errorf("connection_timeout_sec %d must be >= 0", c.GRPC.ConnectionTimeoutSec))
+	}
+	if c.GRPC.ShutdownTimeoutSec < 0 {
+		errs = append(errs, fmt.Errorf("shutdown_timeout_sec %d must be >= 0", c.GRPC.ShutdownTimeoutSec))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateVehicleService() error {
+	var errs []error
+
+	if c.Vehicles.Enabled && c.Vehicles.Target == "" {
+		errs = append(errs, errors.New("target is required when enabled"))
+	}
+	if c.Vehicles.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout %s must be >= 0", c.Vehicles.Timeout))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateAssignmentSaga() error {
+	var errs []error
+
+	if c.Sagas.Enabled && !c.Vehicles.Enabled {
+		errs = append(errs, errors.New("vehicle_service must be enabled"))
+	}
+	if c.Sagas.ResumeInterval < 0 {
+		errs = append(errs, fmt.Errorf("resume_interval %s must be >= 0", c.Sagas.ResumeInterval))
+	}
+	if c.Sagas.StaleAfter < 0 {
+		errs = append(errs, fmt.Errorf("stale_after %s must be >= 0", c.Sagas.StaleAfter))
+	}
+	if c.Sagas.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("batch_size %d must be >= 0", c.Sagas.BatchSize))
+	}
+	return errors.Join(errs...)
+}
+
 func (c Config) validateDatabase() error {
 	var errs []error
 
+	switch c.Database.Driver {
+	case "", DriverMySQL, DriverPostgres:
+	default:
+		errs = append(errs, fmt.Errorf("driver %q is not supported (mysql|postgres)", c.Database.Driver))
+	}
 	if c.Database.Host == "" {
 		errs = append(errs, errors.New("host is required"))
 	}
@@ -185,3 +491,222 @@ func (c Config) validateDatabase() error {
 	// Password policy remains environment-dependent; keep it out of generic validation.
 	return errors.Join(errs...)
 }
+
+func (c Config) validateOutbox() error {
+	var errs []error
+
+	if c.Outbox.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll_interval %s must be >= 0", c.Outbox.PollInterval))
+	}
+	if c.Outbox.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("batch_size %d must be >= 0", c.Outbox.BatchSize))
+	}
+	if c.Outbox.MinBackoff < 0 {
+		errs = append(errs, fmt.Errorf("min_backoff %s must be >= 0", c.Outbox.MinBackoff))
+	}
+	if c.Outbox.MaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("max_backoff %s must be >= 0", c.Outbox.MaxBackoff))
+	}
+	if c.Outbox.MaxBackoff > 0 && c.Outbox.MinBackoff > c.Outbox.MaxBackoff {
+		errs = append(errs, fmt.Errorf("min_backoff %s must be <= max_backoff %s", c.Outbox.MinBackoff, c.Outbox.MaxBackoff))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c Config) validateScheduler() error {
+	var errs []error
+
+	if c.Scheduler.Interval < 0 {
+		errs = append(errs, fmt.Errorf("interval %s must be >= 0", c.Scheduler.Interval))
+	}
+	if c.Scheduler.CompleteAfter < 0 {
+		errs = append(errs, fmt.Errorf("complete_after %s must be >= 0", c.Scheduler.CompleteAfter))
+	}
+	if c.Scheduler.ExpireAfter < 0 {
+		errs = append(errs, fmt.Errorf("expire_after %s must be >= 0", c.Scheduler.ExpireAfter))
+	}
+	if c.Scheduler.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("batch_size %d must be >= 0", c.Scheduler.BatchSize))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateWebhooks() error {
+	var errs []error
+
+	if c.Webhooks.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll_interval %s must be >= 0", c.Webhooks.PollInterval))
+	}
+	if c.Webhooks.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("batch_size %d must be >= 0", c.Webhooks.BatchSize))
+	}
+	if c.Webhooks.Concurrency < 0 {
+		errs = append(errs, fmt.Errorf("concurrency %d must be >= 0", c.Webhooks.Concurrency))
+	}
+	if c.Webhooks.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout %s must be >= 0", c.Webhooks.Timeout))
+	}
+	if c.Webhooks.MinBackoff < 0 {
+		errs = append(errs, fmt.Errorf("min_backoff %s must be >= 0", c.Webhooks.MinBackoff))
+	}
+	if c.Webhooks.MaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("max_backoff %s must be >= 0", c.Webhooks.MaxBackoff))
+	}
+	if c.Webhooks.MaxBackoff > 0 && c.Webhooks.MinBackoff > c.Webhooks.MaxBackoff {
+		errs = append(errs, fmt.Errorf("min_backoff %s must be <= max_backoff %s", c.Webhooks.MinBackoff, c.Webhooks.MaxBackoff))
+	}
+	if c.Webhooks.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("max_attempts %d must be >= 0", c.Webhooks.MaxAttempts))
+	}
+	if c.Webhooks.DisableAfter < 0 {
+		errs = append(errs, fmt.Errorf("disable_after %d must be >= 0", c.Webhooks.DisableAfter))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateStream() error {
+	var errs []error
+
+	if c.Stream.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll_interval %s must be >= 0", c.Stream.PollInterval))
+	}
+	if c.Stream.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("batch_size %d must be >= 0", c.Stream.BatchSize))
+	}
+	if c.Stream.ReplaySize < 0 {
+		errs = append(errs, fmt.Errorf("replay_size %d must be >= 0", c.Stream.ReplaySize))
+	}
+	if c.Stream.BufferSize < 0 {
+		errs = append(errs, fmt.Errorf("buffer_size %d must be >= 0", c.Stream.BufferSize))
+	}
+	if c.Stream.GapTimeout < 0 {
+		errs = append(errs, fmt.Errorf("gap_timeout %s must be >= 0", c.Stream.GapTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateTenancy() error {
+	var errs []error
+
+	seen := map[string]bool{}
+	for _, id := range c.Tenancy.Tenants {
+		switch {
+		case id == "":
+			errs = append(errs, errors.New("tenants must not contain an empty ID"))
+		case len(id) > maxTenantIDLength:
+			errs = append(errs, fmt.Errorf("tenant %q is longer than %d characters", id, maxTenantIDLength))
+		case seen[id]:
+			errs = append(errs, fmt.Errorf("tenant %q is listed twice", id))
+		}
+		seen[id] = true
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateAuth() error {
+	if !c.Auth.Enabled {
+		return nil
+	}
+	var errs []error
+
+	if c.Auth.Issuer == "" {
+		errs = append(errs, errors.New("issuer is required"))
+	}
+	if c.Auth.Audience == "" {
+		errs = append(errs, errors.New("audience is required"))
+	}
+	if c.Auth.JWKSFile == "" && len(c.Auth.Keys) == 0 {
+		errs = append(errs, errors.New("jwks_file or keys is required"))
+	}
+	if c.Auth.ClockSkew < 0 {
+		errs = append(errs, fmt.Errorf("clock_skew %s must be >= 0", c.Auth.ClockSkew))
+	}
+	seen := map[string]bool{}
+	for i, k := range c.Auth.Keys {
+		switch {
+		case k.ID == "":
+			errs = append(errs, fmt.Errorf("keys[%d]: id is required", i))
+		case seen[k.ID]:
+			errs = append(errs, fmt.Errorf("keys[%d]: id %q is listed twice", i, k.ID))
+		}
+		seen[k.ID] = true
+		if k.Algorithm == "" {
+			errs = append(errs, fmt.Errorf("keys[%d]: algorithm is required", i))
+		}
+		if k.File == "" {
+			errs = append(errs, fmt.Errorf("keys[%d]: file is required", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateRateLimit() error {
+	var errs []error
+
+	check := func(name string, l RateLimit) {
+		if l.Rate < 0 {
+			errs = append(errs, fmt.Errorf("%s: rate %g must be >= 0", name, l.Rate))
+		}
+		if l.Burst < 0 {
+			errs = append(errs, fmt.Errorf("%s: burst %d must be >= 0", name, l.Burst))
+		}
+	}
+	check("default", c.RateLimit.Default)
+	for op, l := range c.RateLimit.Operations {
+		if op == "" {
+			errs = append(errs, errors.New("operations must not contain an empty operationId"))
+		}
+		check("operations."+op, l)
+	}
+	if c.RateLimit.MaxClients < 0 {
+		errs = append(errs, fmt.Errorf("max_clients %d must be >= 0", c.RateLimit.MaxClients))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) validateLoadShedding() error {
+	var errs []error
+
+	if c.LoadShedding.MaxInFlight < 0 {
+		errs = append(errs, fmt.Errorf("max_in_flight %d must be >= 0", c.LoadShedding.MaxInFlight))
+	}
+	if c.LoadShedding.Enabled && c.LoadShedding.MaxInFlight == 0 {
+		switch {
+		case c.Database.MaxOpenConns == 0:
+			errs = append(errs, errors.New("max_in_flight is required when database.max_open_conns is unlimited"))
+		case c.MaxInFlight() <= 1:
+			errs = append(errs, fmt.Errorf("database.max_open_conns %d leaves %d connections to API requests after the %d of background jobs; raise it or set max_in_flight",
+				c.Database.MaxOpenConns, c.MaxInFlight(), c.BackgroundConns()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BackgroundConns is the number of pooled database connections the
+// background jobs may hold at once: one for the leader lock of the
+// scheduler, which holds it while leading, and one per polling loop.
+func (c Config) BackgroundConns() int {
+	n := 3 // outbox relay, change broadcaster and recurrence materializer
+	if c.Scheduler.Enabled {
+		n += 2 // leader lock and scheduler
+	}
+	if c.Webhooks.Enabled {
+		n++
+	}
+	if c.Sagas.Enabled {
+		n++ // resumes interrupted sagas
+	}
+	return n
+}
+
+// MaxInFlight is load_shedding.max_in_flight, or when it is zero the
+// database connections left to API requests after BackgroundConns, so that
+// load is shed before requests queue for connections the background jobs
+// hold.
+func (c Config) MaxInFlight() int {
+	if c.LoadShedding.MaxInFlight > 0 {
+		return c.LoadShedding.MaxInFlight
+	}
+	return c.Database.MaxOpenConns - c.BackgroundConns()
+}
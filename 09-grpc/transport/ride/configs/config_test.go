@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/yourname/transport/ride/configs"
 )
@@ -136,6 +137,552 @@ database
 			},
 			expectErr: false,
 		},
+		{
+			name: "success - idempotency ttl",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"idempotency:\n  ttl: 90m\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Idempotency: configs.IdempotencyConfig{TTL: 90 * time.Minute},
+			},
+			expectErr: false,
+		},
+		{
+			name: "success - outbox",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"outbox:\n  poll_interval: 2s\n  batch_size: 50\n  min_backoff: 1s\n  max_backoff: 1m\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Outbox: configs.OutboxConfig{
+					PollInterval: 2 * time.Second,
+					BatchSize:    50,
+					MinBackoff:   time.Second,
+					MaxBackoff:   time.Minute,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "success - postgres driver",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"  driver: \"postgres\"\n  ssl_mode: \"require\"\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Driver:          configs.DriverPostgres,
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+					SSLMode:         "require",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "error - unknown database driver",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"  driver: \"sqlite\"\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - outbox min_backoff above max_backoff",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"outbox:\n  min_backoff: 2m\n  max_backoff: 1m\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - cache",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"cache:\n  enabled: true\n  size: 500\n  ttl: 10s\n  invalidate_from_events: true\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Cache: configs.CacheConfig{
+					Enabled:              true,
+					Size:                 500,
+					TTL:                  10 * time.Second,
+					InvalidateFromEvents: true,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "error - negative cache ttl",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"cache:\n  ttl: -1s\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - recurrence",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"recurrence:\n  horizon: 168h\n  interval: 30m\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Recurrence: configs.RecurrenceConfig{
+					Horizon:  7 * 24 * time.Hour,
+					Interval: 30 * time.Minute,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "error - negative recurrence horizon",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"recurrence:\n  horizon: -1h\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - scheduler",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"scheduler:\n  enabled: true\n  interval: 10s\n  complete_after: 5m\n  expire_after: 30m\n  batch_size: 50\n  lock_name: \"sched\"\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Scheduler: configs.SchedulerConfig{
+					Enabled:       true,
+					Interval:      10 * time.Second,
+					CompleteAfter: 5 * time.Minute,
+					ExpireAfter:   30 * time.Minute,
+					BatchSize:     50,
+					LockName:      "sched",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "error - negative scheduler expire_after",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"scheduler:\n  expire_after: -1m\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - webhooks",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"webhooks:\n  enabled: true\n  poll_interval: 5s\n  batch_size: 20\n  concurrency: 4\n  timeout: 3s\n  min_backoff: 30s\n  max_backoff: 2h\n  max_attempts: 6\n  disable_after: 12\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Webhooks: configs.WebhookConfig{
+					Enabled:      true,
+					PollInterval: 5 * time.Second,
+					BatchSize:    20,
+					Concurrency:  4,
+					Timeout:      3 * time.Second,
+					MinBackoff:   30 * time.Second,
+					MaxBackoff:   2 * time.Hour,
+					MaxAttempts:  6,
+					DisableAfter: 12,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "success - stream",
+			path: func(t *testing.T) string {
+				yaml := strings.Replace(validYAML, "write_timeout_sec: 30\n", "write_timeout_sec: 30\n  stream_heartbeat_sec: 20\n", 1)
+				return createTempConfigFile(t, yaml+"stream:\n  poll_interval: 250ms\n  batch_size: 100\n  replay_size: 5000\n  buffer_size: 64\n  gap_timeout: 2s\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:               8080,
+					ReadTimeoutSec:     30,
+					WriteTimeoutSec:    30,
+					StreamHeartbeatSec: 20,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Stream: configs.StreamConfig{
+					PollInterval: 250 * time.Millisecond,
+					BatchSize:    100,
+					ReplaySize:   5000,
+					BufferSize:   64,
+					GapTimeout:   2 * time.Second,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "error - negative stream buffer_size",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"stream:\n  buffer_size: -1\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - tenancy",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"tenancy:\n  tenants: [\"berlin\", \"paris\"]\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Tenancy: configs.TenancyConfig{Tenants: []string{"berlin", "paris"}},
+			},
+		},
+		{
+			name: "error - duplicate tenant",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"tenancy:\n  tenants: [\"berlin\", \"berlin\"]\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - auth",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"auth:\n  enabled: true\n  issuer: \"https://issuer\"\n  audience: \"ride-api\"\n  clock_skew: 1m\n  keys:\n    - id: \"k1\"\n      algorithm: \"ES256\"\n      file: \"/etc/ride/k1.pem\"\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				Auth: configs.AuthConfig{
+					Enabled:   true,
+					Issuer:    "https://issuer",
+					Audience:  "ride-api",
+					ClockSkew: time.Minute,
+					Keys:      []configs.AuthKeyConfig{{ID: "k1", Algorithm: "ES256", File: "/etc/ride/k1.pem"}},
+				},
+			},
+		},
+		{
+			name: "error - auth without keys",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"auth:\n  enabled: true\n  issuer: \"https://issuer\"\n  audience: \"ride-api\"\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - rate limit and load shedding",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"rate_limit:\n  enabled: true\n  default: { rate: 20, burst: 40 }\n  operations:\n    createAssignment: { rate: 0.5 }\nload_shedding:\n  enabled: true\n  exempt_operations: [\"streamAssignments\"]\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+				RateLimit: configs.RateLimitConfig{
+					Enabled:    true,
+					Default:    configs.RateLimit{Rate: 20, Burst: 40},
+					Operations: map[string]configs.RateLimit{"createAssignment": {Rate: 0.5}},
+				},
+				LoadShedding: configs.LoadSheddingConfig{
+					Enabled:          true,
+					ExemptOperations: []string{"streamAssignments"},
+				},
+			},
+		},
+		{
+			name: "error - negative rate limit",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"rate_limit:\n  operations:\n    createAssignment: { rate: -1 }\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - load shedding without a bound",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, strings.Replace(validYAML, "max_open_conns: 20", "max_open_conns: 0", 1)+"load_shedding:\n  enabled: true\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - load shedding default leaves no connections",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, strings.Replace(validYAML, "max_open_conns: 20", "max_open_conns: 6", 1)+"scheduler:\n  enabled: true\nload_shedding:\n  enabled: true\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - grpc",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"grpc:\n  enabled: true\n  port: 9090\n  connection_timeout_sec: 5\n  shutdown_timeout_sec: 10\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				GRPC: configs.GRPCConfig{
+					Enabled:              true,
+					Port:                 9090,
+					ConnectionTimeoutSec: 5,
+					ShutdownTimeoutSec:   10,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+			},
+		},
+		{
+			name: "error - grpc on the http port",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"grpc:\n  enabled: true\n  port: 8080\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "success - vehicle service",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"vehicle_service:\n  enabled: true\n  target: \"vehicle:8080\"\n  timeout: 2s\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Vehicles: configs.VehicleServiceConfig{
+					Enabled: true,
+					Target:  "vehicle:8080",
+					Timeout: 2 * time.Second,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+			},
+		},
+		{
+			name: "success - assignment saga",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"vehicle_service:\n  enabled: true\n  target: \"vehicle:8080\"\nassignment_saga:\n  enabled: true\n  stale_after: 2m\n")
+			},
+			expectedCfg: &configs.Config{
+				Server: configs.ServerConfig{
+					Port:            8080,
+					ReadTimeoutSec:  30,
+					WriteTimeoutSec: 30,
+				},
+				Vehicles: configs.VehicleServiceConfig{
+					Enabled: true,
+					Target:  "vehicle:8080",
+				},
+				Sagas: configs.AssignmentSagaConfig{
+					Enabled:    true,
+					StaleAfter: 2 * time.Minute,
+				},
+				Database: configs.DatabaseConfig{
+					Host:            "mysql.internal",
+					Port:            3306,
+					User:            "ride_user",
+					Password:        "from_file",
+					Name:            "transportdb",
+					MaxOpenConns:    20,
+					MaxIdleConns:    5,
+					ConnMaxLifetime: 300,
+					ConnMaxIdleTime: 60,
+				},
+			},
+		},
+		{
+			name: "error - assignment saga without the vehicle service",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"assignment_saga:\n  enabled: true\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - vehicle service without a target",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"vehicle_service:\n  enabled: true\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - webhook min_backoff above max_backoff",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"webhooks:\n  min_backoff: 1h\n  max_backoff: 1m\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - negative idempotency ttl",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, validYAML+"idempotency:\n  ttl: -1s\n")
+			},
+			expectErr: true,
+		},
+		{
+			name: "error - negative max_import_body_bytes",
+			path: func(t *testing.T) string {
+				return createTempConfigFile(t, strings.Replace(validYAML, "write_timeout_sec: 30\n", "write_timeout_sec: 30\n  max_import_body_bytes: -1\n", 1))
+			},
+			expectErr: true,
+		},
 		{
 			name:      "error - empty path",
 			path:      func(t *testing.T) string { return "" },
@@ -182,6 +729,31 @@ database
 	}
 }
 
+func TestAuthConfigTenantClaimRequired(t *testing.T) {
+	optional := false
+	if !(configs.AuthConfig{}).TenantClaimRequired() {
+		t.Error("expected the tenant claim to be required by default")
+	}
+	if (configs.AuthConfig{RequireTenantClaim: &optional}).TenantClaimRequired() {
+		t.Error("expected require_tenant_claim: false to make the tenant claim optional")
+	}
+}
+
+func TestConfigMaxInFlight(t *testing.T) {
+	cfg := configs.Config{Database: configs.DatabaseConfig{MaxOpenConns: 20}}
+	if got := cfg.MaxInFlight(); got != 17 {
+		t.Errorf("expected 17 connections after the relay, broadcaster and materializer, got %d", got)
+	}
+	cfg.Scheduler.Enabled, cfg.Webhooks.Enabled, cfg.Sagas.Enabled = true, true, true
+	if got := cfg.MaxInFlight(); got != 13 {
+		t.Errorf("expected 13 connections with every background job, got %d", got)
+	}
+	cfg.LoadShedding.MaxInFlight = 5
+	if got := cfg.MaxInFlight(); got != 5 {
+		t.Errorf("expected the configured bound, got %d", got)
+	}
+}
+
 func FuzzLoadConfigEnvOverrides(f *testing.F) {
 	const baseConfig = `
 server:
@@ -0,0 +1,80 @@
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type LoadShedderOptions struct {
+	MaxInFlight int      // requests served at once
+	Exempt      []string // operationIds that are neither counted nor shed
+	Registerer  prometheus.Registerer
+}
+
+// LoadShedder admits at most MaxInFlight requests at once and turns the rest
+// away right away, so that a spike fails fast instead of queueing for
+// database connections. Long-lived operations that hold no connection, such
+// as streams, belong in Exempt. The requests in flight and the decisions
+// are reported as ride_http_requests_in_flight and
+// ride_http_load_shedder_decisions_total when Registerer is set.
+type LoadShedder struct {
+	exempt    []string
+	slots     chan struct{}
+	inFlight  prometheus.Gauge
+	decisions *prometheus.CounterVec
+}
+
+func NewLoadShedder(opts LoadShedderOptions) (*LoadShedder, error) {
+	if opts.MaxInFlight <= 0 {
+		return nil, errors.New("max in flight must be positive")
+	}
+
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ride_http_requests_in_flight",
+		Help: "API requests being served and counted by the load shedder.",
+	})
+	decisions := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ride_http_load_shedder_decisions_total",
+		Help: "Load shedder decisions by operation and decision (admitted or shed).",
+	}, []string{"operation", "decision"})
+	if opts.Registerer != nil {
+		for _, c := range []prometheus.Collector{inFlight, decisions} {
+			if err := opts.Registerer.Register(c); err != nil {
+				return nil, fmt.Errorf("register load shedder metrics: %w", err)
+			}
+		}
+	}
+	return &LoadShedder{
+		exempt:    slices.Clone(opts.Exempt),
+		slots:     make(chan struct{}, opts.MaxInFlight),
+		inFlight:  inFlight,
+		decisions: decisions,
+	}, nil
+}
+
+// Exempt returns the operations that are never shed.
+func (s *LoadShedder) Exempt() []string { return slices.Clone(s.exempt) }
+
+// Admit takes a slot for a request to op and returns the func that gives
+// it back, or false when every slot is taken. Exempt operations are
+// admitted without one.
+func (s *LoadShedder) Admit(op string) (func(), bool) {
+	if slices.Contains(s.exempt, op) {
+		return func() {}, true
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.decisions.WithLabelValues(op, "shed").Inc()
+		return nil, false
+	}
+	s.decisions.WithLabelValues(op, "admitted").Inc()
+	s.inFlight.Inc()
+	return func() {
+		s.inFlight.Dec()
+		<-s.slots
+	}, true
+}
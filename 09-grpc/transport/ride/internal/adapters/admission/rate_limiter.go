@@ -0,0 +1,141 @@
+// Package admission decides which API calls are served: a rate limiter per
+// client and operation, and a load shedder bounding the calls in flight.
+// The REST and gRPC servers share one instance of each, so a client and
+// the instance are held to the same budget whichever API they use.
+package admission
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// DefaultRateLimitClients is the number of token buckets kept when
+// RateLimiterOptions.MaxClients is zero.
+const DefaultRateLimitClients = 10_000
+
+// Limit allows Rate requests per second on average and bursts of up to Burst
+// requests. A zero Rate means unlimited; a zero Burst is the Rate rounded up.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimiterOptions struct {
+	Default    Limit            // operations not listed in Operations
+	Operations map[string]Limit // by operationId
+	MaxClients int              // buckets kept; the least recently used are dropped
+	Registerer prometheus.Registerer
+}
+
+// RateLimiter gives every client a token bucket per operation. Operations
+// are named by the operationId of the REST API, which the gRPC methods map
+// to. Buckets live in memory, so each instance enforces the limits on its
+// own. Decisions are counted in ride_http_rate_limit_decisions_total when
+// Registerer is set.
+type RateLimiter struct {
+	opts      RateLimiterOptions
+	buckets   *lru.Cache[string, *tokenBucket]
+	decisions *prometheus.CounterVec
+}
+
+func NewRateLimiter(opts RateLimiterOptions) (*RateLimiter, error) {
+	if opts.MaxClients <= 0 {
+		opts.MaxClients = DefaultRateLimitClients
+	}
+	buckets, err := lru.New[string, *tokenBucket](opts.MaxClients)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ride_http_rate_limit_decisions_total",
+		Help: "Rate limiter decisions by operation and decision (allowed or limited).",
+	}, []string{"operation", "decision"})
+	if opts.Registerer != nil {
+		if err := opts.Registerer.Register(decisions); err != nil {
+			return nil, fmt.Errorf("register rate limit metrics: %w", err)
+		}
+	}
+	return &RateLimiter{opts: opts, buckets: buckets, decisions: decisions}, nil
+}
+
+// Operations returns the operations with a limit of their own.
+func (l *RateLimiter) Operations() []string {
+	ops := make([]string, 0, len(l.opts.Operations))
+	for op := range l.opts.Operations {
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// Allow spends a token of client for op. When none is left it returns an
+// error, describing the limit, and how long until the next token.
+func (l *RateLimiter) Allow(op, client string) (time.Duration, error) {
+	limit, ok := l.opts.Operations[op]
+	if !ok {
+		limit = l.opts.Default
+	}
+	if limit.Rate <= 0 {
+		return 0, nil
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Ceil(limit.Rate))
+	}
+
+	now := time.Now()
+	key := op + " " + client
+	bucket, ok := l.buckets.Get(key)
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		if prev, found, _ := l.buckets.PeekOrAdd(key, bucket); found {
+			bucket = prev // added concurrently
+		}
+	}
+	allowed, wait := bucket.take(limit, now)
+	if !allowed {
+		l.decisions.WithLabelValues(op, "limited").Inc()
+		return wait, fmt.Errorf("rate limit of %g requests per second exceeded for %s", limit.Rate, op)
+	}
+	l.decisions.WithLabelValues(op, "allowed").Inc()
+	return 0, nil
+}
+
+// ClientKey identifies the caller of a request: the token subject when it
+// was authenticated, or else addr, its IP address.
+func ClientKey(ctx context.Context, addr string) string {
+	if claims, ok := requestctx.LookupClaims(ctx); ok {
+		return "sub:" + claims.Subject
+	}
+	return "ip:" + addr
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// take spends a token if one is left and otherwise reports how long until
+// the next one.
+func (b *tokenBucket) take(l Limit, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(l.Burst), b.tokens+elapsed.Seconds()*l.Rate)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.Rate * float64(time.Second))
+}
@@ -0,0 +1,283 @@
+// Package auth verifies the bearer tokens API requests authenticate with.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+const (
+	defaultClockSkew   = 30 * time.Second
+	defaultTenantClaim = "tenant"
+)
+
+// JWTVerifierOptions tunes a JWTVerifier; zero values fall back to defaults.
+type JWTVerifierOptions struct {
+	Issuer      string        // expected "iss" claim
+	Audience    string        // must be among the "aud" claims
+	ClockSkew   time.Duration // leeway for exp, nbf and iat
+	TenantClaim string        // claim pinning a token to a tenant
+}
+
+// JWTVerifier checks signed JWTs against a fixed set of keys. A token names
+// its key in the kid header, which may only be left out when there is a
+// single key, and must be signed with the algorithm configured for that key,
+// so a public key can never be replayed as an HMAC secret.
+type JWTVerifier struct {
+	keys map[string]jose.JSONWebKey
+	algs []jose.SignatureAlgorithm
+	opts JWTVerifierOptions
+	now  func() time.Time
+}
+
+// hmacKeySizes are the minimum secret lengths of the HMAC algorithms.
+var hmacKeySizes = map[jose.SignatureAlgorithm]int{jose.HS256: 32, jose.HS384: 48, jose.HS512: 64}
+
+var asymmetricAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512, jose.EdDSA,
+}
+
+// NewJWTVerifier accepts tokens signed with one of keys. Every key needs a kid
+// and an alg; private keys are reduced to their public part.
+func NewJWTVerifier(keys []jose.JSONWebKey, opts JWTVerifierOptions) (*JWTVerifier, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no verification keys")
+	}
+	if opts.ClockSkew <= 0 {
+		opts.ClockSkew = defaultClockSkew
+	}
+	if opts.TenantClaim == "" {
+		opts.TenantClaim = defaultTenantClaim
+	}
+
+	v := &JWTVerifier{keys: make(map[string]jose.JSONWebKey, len(keys)), opts: opts, now: time.Now}
+	for _, k := range keys {
+		if k.KeyID == "" {
+			return nil, errors.New("key without kid")
+		}
+		if _, dup := v.keys[k.KeyID]; dup {
+			return nil, fmt.Errorf("key %q is listed twice", k.KeyID)
+		}
+		vk, err := verificationKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.KeyID, err)
+		}
+		v.keys[k.KeyID] = vk
+		alg := jose.SignatureAlgorithm(k.Algorithm)
+		if !slices.Contains(v.algs, alg) {
+			v.algs = append(v.algs, alg)
+		}
+	}
+	return v, nil
+}
+
+// verificationKey checks that k fits its algorithm and strips private parts.
+func verificationKey(k jose.JSONWebKey) (jose.JSONWebKey, error) {
+	alg := jose.SignatureAlgorithm(k.Algorithm)
+	if alg == "" {
+		return k, errors.New("alg is required")
+	}
+	if size, ok := hmacKeySizes[alg]; ok {
+		secret, isSecret := k.Key.([]byte)
+		if !isSecret {
+			return k, fmt.Errorf("%s needs a shared secret", alg)
+		}
+		if len(secret) < size {
+			return k, fmt.Errorf("%s needs a secret of at least %d bytes", alg, size)
+		}
+		return k, nil
+	}
+	if !slices.Contains(asymmetricAlgorithms, alg) {
+		return k, fmt.Errorf("unsupported algorithm %q", k.Algorithm)
+	}
+	pub := k.Public()
+	if !pub.Valid() {
+		return k, fmt.Errorf("%s needs a public key", alg)
+	}
+	return pub, nil
+}
+
+// LoadJWTVerifier builds a JWTVerifier from the key files named in cfg.
+func LoadJWTVerifier(cfg configs.AuthConfig) (*JWTVerifier, error) {
+	var keys []jose.JSONWebKey
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks: %w", err)
+		}
+		var set jose.JSONWebKeySet
+		if err := json.Unmarshal(data, &set); err != nil {
+			return nil, fmt.Errorf("parse jwks %s: %w", cfg.JWKSFile, err)
+		}
+		keys = append(keys, set.Keys...)
+	}
+	for _, kc := range cfg.Keys {
+		k, err := loadStaticKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kc.ID, err)
+		}
+		keys = append(keys, k)
+	}
+	return NewJWTVerifier(keys, JWTVerifierOptions{
+		Issuer:      cfg.Issuer,
+		Audience:    cfg.Audience,
+		ClockSkew:   cfg.ClockSkew,
+		TenantClaim: cfg.TenantClaim,
+	})
+}
+
+// loadStaticKey reads a PEM public key or certificate, or for the HMAC
+// algorithms the raw secret, ignoring a trailing newline.
+func loadStaticKey(kc configs.AuthKeyConfig) (jose.JSONWebKey, error) {
+	data, err := os.ReadFile(kc.File)
+	if err != nil {
+		return jose.JSONWebKey{}, err
+	}
+	k := jose.JSONWebKey{KeyID: kc.ID, Algorithm: kc.Algorithm, Use: "sig"}
+	if _, ok := hmacKeySizes[jose.SignatureAlgorithm(kc.Algorithm)]; ok {
+		k.Key = bytes.TrimRight(data, "\r\n")
+		return k, nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return jose.JSONWebKey{}, fmt.Errorf("%s holds no PEM block", kc.File)
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return jose.JSONWebKey{}, err
+		}
+		k.Key = cert.PublicKey
+	default:
+		if k.Key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return jose.JSONWebKey{}, err
+		}
+	}
+	return k, nil
+}
+
+// tokenClaims are the claims beyond the registered ones the verifier reads.
+type tokenClaims struct {
+	Scope scopeList `json:"scope"`
+	Scp   scopeList `json:"scp"`
+}
+
+// scopeList decodes a space-separated string or an array of strings.
+type scopeList []string
+
+func (s *scopeList) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err == nil {
+		*s = strings.Fields(str)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return errors.New("scopes must be a string or an array of strings")
+	}
+	*s = list
+	return nil
+}
+
+// Verify implements ports.TokenVerifier. The errors are safe to show to
+// the client.
+func (v *JWTVerifier) Verify(ctx context.Context, raw string) (requestctx.Claims, error) {
+	tok, err := jwt.ParseSigned(raw, v.algs)
+	if err != nil {
+		return requestctx.Claims{}, errors.New("malformed token or unsupported algorithm")
+	}
+	header := tok.Headers[0]
+	key, err := v.key(header.KeyID)
+	if err != nil {
+		return requestctx.Claims{}, err
+	}
+	if header.Algorithm != key.Algorithm {
+		return requestctx.Claims{}, fmt.Errorf("key %q is not used with %s", key.KeyID, header.Algorithm)
+	}
+
+	var (
+		std   jwt.Claims
+		extra tokenClaims
+		all   map[string]any
+	)
+	if err := tok.Claims(key.Key, &std, &extra, &all); err != nil {
+		return requestctx.Claims{}, errors.New("invalid signature or claims")
+	}
+	switch {
+	case std.Expiry == nil:
+		return requestctx.Claims{}, errors.New("token has no exp claim")
+	case std.Subject == "":
+		return requestctx.Claims{}, errors.New("token has no sub claim")
+	}
+	err = std.ValidateWithLeeway(jwt.Expected{
+		Issuer:      v.opts.Issuer,
+		AnyAudience: jwt.Audience{v.opts.Audience},
+		Time:        v.now(),
+	}, v.opts.ClockSkew)
+	if err != nil {
+		return requestctx.Claims{}, describeValidationError(err)
+	}
+
+	var tenant string
+	if value, ok := all[v.opts.TenantClaim]; ok {
+		if tenant, ok = value.(string); !ok || tenant == "" {
+			return requestctx.Claims{}, fmt.Errorf("%s claim must be a non-empty string", v.opts.TenantClaim)
+		}
+	}
+	return requestctx.Claims{
+		Subject:   std.Subject,
+		Issuer:    std.Issuer,
+		Audience:  std.Audience,
+		Scopes:    append(extra.Scope, extra.Scp...),
+		Tenant:    tenant,
+		ExpiresAt: std.Expiry.Time().UTC(),
+	}, nil
+}
+
+func (v *JWTVerifier) key(kid string) (jose.JSONWebKey, error) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, nil
+		}
+	}
+	k, ok := v.keys[kid]
+	if !ok {
+		return jose.JSONWebKey{}, fmt.Errorf("unknown key %q", kid)
+	}
+	return k, nil
+}
+
+func describeValidationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrExpired):
+		return errors.New("token is expired")
+	case errors.Is(err, jwt.ErrNotValidYet):
+		return errors.New("token is not valid yet")
+	case errors.Is(err, jwt.ErrIssuedInTheFuture):
+		return errors.New("token is issued in the future")
+	case errors.Is(err, jwt.ErrInvalidIssuer):
+		return errors.New("token is from another issuer")
+	case errors.Is(err, jwt.ErrInvalidAudience):
+		return errors.New("token is for another audience")
+	default:
+		return errors.New("invalid claims")
+	}
+}
@@ -0,0 +1,270 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/auth"
+)
+
+const (
+	testIssuer   = "https://issuer.test/"
+	testAudience = "ride-api"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key
+}
+
+func sign(t *testing.T, alg jose.SignatureAlgorithm, key any, kid string, claims map[string]any) string {
+	t.Helper()
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		opts = opts.WithHeader("kid", kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	return token
+}
+
+// validClaims returns claims the verifier accepts, with changes applied.
+func validClaims(changes map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "dispatcher-7",
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"scope": "assignments:read assignments:write",
+	}
+	for k, v := range changes {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestJWTVerifier(t *testing.T) {
+	ecKey, otherKey := newECKey(t), newECKey(t)
+	verifier, err := auth.NewJWTVerifier([]jose.JSONWebKey{
+		{Key: ecKey, KeyID: "ec", Algorithm: string(jose.ES256)}, // the private part is dropped
+		{Key: hmacSecret, KeyID: "hs", Algorithm: string(jose.HS256)},
+	}, auth.JWTVerifierOptions{Issuer: testIssuer, Audience: testAudience, ClockSkew: 30 * time.Second})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		token      string
+		wantErr    string
+		wantScopes []string
+		wantTenant string
+	}{
+		{
+			name:       "es256",
+			token:      sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"tenant": "paris"})),
+			wantScopes: []string{"assignments:read", "assignments:write"},
+			wantTenant: "paris",
+		},
+		{
+			name:       "hs256 with scp array",
+			token:      sign(t, jose.HS256, hmacSecret, "hs", validClaims(map[string]any{"scope": nil, "scp": []string{"webhooks:read"}})),
+			wantScopes: []string{"webhooks:read"},
+		},
+		{
+			name:       "expired within the clock skew",
+			token:      sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"exp": time.Now().Add(-10 * time.Second).Unix()})),
+			wantScopes: []string{"assignments:read", "assignments:write"},
+		},
+		{
+			name:    "expired",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()})),
+			wantErr: "expired",
+		},
+		{
+			name:    "not valid yet",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"nbf": time.Now().Add(time.Minute).Unix()})),
+			wantErr: "not valid yet",
+		},
+		{
+			name:    "other issuer",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"iss": "https://evil.test/"})),
+			wantErr: "issuer",
+		},
+		{
+			name:    "other audience",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"aud": []string{"billing"}})),
+			wantErr: "audience",
+		},
+		{
+			name:    "no expiry",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"exp": nil})),
+			wantErr: "exp",
+		},
+		{
+			name:    "no subject",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"sub": nil})),
+			wantErr: "sub",
+		},
+		{
+			name:    "tenant is not a string",
+			token:   sign(t, jose.ES256, ecKey, "ec", validClaims(map[string]any{"tenant": 7})),
+			wantErr: "tenant",
+		},
+		{
+			name:    "unknown key",
+			token:   sign(t, jose.ES256, ecKey, "rotated", validClaims(nil)),
+			wantErr: "unknown key",
+		},
+		{
+			name:    "kid required with several keys",
+			token:   sign(t, jose.ES256, ecKey, "", validClaims(nil)),
+			wantErr: "unknown key",
+		},
+		{
+			name:    "forged signature",
+			token:   sign(t, jose.ES256, otherKey, "ec", validClaims(nil)),
+			wantErr: "invalid signature",
+		},
+		{
+			name:    "algorithm of another key",
+			token:   sign(t, jose.HS256, hmacSecret, "ec", validClaims(nil)),
+			wantErr: "not used with HS256",
+		},
+		{
+			name:    "garbage",
+			token:   "not.a.jwt",
+			wantErr: "malformed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tc.token)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error mentioning %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != "dispatcher-7" || claims.Issuer != testIssuer || claims.Tenant != tc.wantTenant {
+				t.Fatalf("unexpected claims %+v", claims)
+			}
+			if strings.Join(claims.Scopes, " ") != strings.Join(tc.wantScopes, " ") {
+				t.Fatalf("expected scopes %v, got %v", tc.wantScopes, claims.Scopes)
+			}
+		})
+	}
+}
+
+func TestNewJWTVerifierRejectsKeys(t *testing.T) {
+	ecKey := newECKey(t)
+	testCases := []struct {
+		name string
+		keys []jose.JSONWebKey
+	}{
+		{name: "no keys"},
+		{name: "no kid", keys: []jose.JSONWebKey{{Key: ecKey, Algorithm: string(jose.ES256)}}},
+		{name: "no alg", keys: []jose.JSONWebKey{{Key: ecKey, KeyID: "ec"}}},
+		{name: "short secret", keys: []jose.JSONWebKey{{Key: []byte("short"), KeyID: "hs", Algorithm: string(jose.HS256)}}},
+		{name: "public key as secret", keys: []jose.JSONWebKey{{Key: &ecKey.PublicKey, KeyID: "hs", Algorithm: string(jose.HS256)}}},
+		{name: "unsigned", keys: []jose.JSONWebKey{{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: "none"}}},
+		{name: "duplicate kid", keys: []jose.JSONWebKey{
+			{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: string(jose.ES256)},
+			{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: string(jose.ES256)},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := auth.NewJWTVerifier(tc.keys, auth.JWTVerifierOptions{}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadJWTVerifier(t *testing.T) {
+	dir := t.TempDir()
+	jwksKey, pemKey := newECKey(t), newECKey(t)
+
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &jwksKey.PublicKey, KeyID: "from-jwks", Algorithm: string(jose.ES256), Use: "sig"},
+	}})
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&pemKey.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	files := map[string][]byte{
+		"jwks.json":  jwks,
+		"key.pem":    pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		"secret.txt": append(hmacSecret, '\n'),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	verifier, err := auth.LoadJWTVerifier(configs.AuthConfig{
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		JWKSFile: filepath.Join(dir, "jwks.json"),
+		Keys: []configs.AuthKeyConfig{
+			{ID: "from-pem", Algorithm: string(jose.ES256), File: filepath.Join(dir, "key.pem")},
+			{ID: "shared", Algorithm: string(jose.HS256), File: filepath.Join(dir, "secret.txt")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("LoadJWTVerifier: %v", err)
+	}
+	for kid, key := range map[string]any{"from-jwks": jwksKey, "from-pem": pemKey, "shared": hmacSecret} {
+		alg := jose.ES256
+		if kid == "shared" {
+			alg = jose.HS256
+		}
+		if _, err := verifier.Verify(context.Background(), sign(t, alg, key, kid, validClaims(nil))); err != nil {
+			t.Errorf("key %s: %v", kid, err)
+		}
+	}
+
+	if _, err := auth.LoadJWTVerifier(configs.AuthConfig{JWKSFile: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Fatal("expected an error for a missing JWKS file")
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 
 	"github.com/hamba/avro/v2"
+
+	avroschemas "github.com/yourname/transport/ride/avro_schemas"
 )
 
-var assignmentSchema = avro.MustParse(`{ /* same schema as in /schemas/assignment.avsc */ }`)
+var assignmentSchema = avro.MustParse(string(avroschemas.Assignment))
 
 func EncodeAssignment(a AssignmentCreated) ([]byte, error) {
 	var buf bytes.Buffer
@@ -0,0 +1,71 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/vehiclepb"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+const defaultFindTimeout = 2 * time.Second
+
+// VehicleFinder implements ports.VehicleFinder over a VehicleGrpcClient. It
+// dials on first use, so the ride service starts while the vehicle service
+// is down, and maps gRPC failures onto domain errors.
+type VehicleFinder struct {
+	client  *VehicleGrpcClient
+	timeout time.Duration
+}
+
+// NewVehicleFinder bounds each lookup, dialing and retries included, by
+// timeout, 2s when zero.
+func NewVehicleFinder(client *VehicleGrpcClient, timeout time.Duration) *VehicleFinder {
+	if timeout <= 0 {
+		timeout = defaultFindTimeout
+	}
+	return &VehicleFinder{client: client, timeout: timeout}
+}
+
+func (f *VehicleFinder) FindAvailableVehicle(ctx context.Context, routeID string, startsAt, endsAt time.Time) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	if err := f.client.Dial(ctx); err != nil {
+		return "", models.NewUnavailableError(err, "vehicle service is unreachable; retry later or pass vehicleId")
+	}
+	resp, err := f.client.FindAvailableVehicle(ctx, &vehiclepb.FindRequest{
+		RouteId:  routeID,
+		StartsAt: timestamppb.New(startsAt),
+		EndsAt:   timestamppb.New(endsAt),
+	})
+	if err != nil {
+		return "", findError(routeID, err)
+	}
+	if resp.GetVehicleId() == "" || resp.GetStatus() != "available" {
+		return "", models.NewConflictError("no vehicle is available on route %s", routeID)
+	}
+	return resp.GetVehicleId(), nil
+}
+
+func findError(routeID string, err error) error {
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return models.NewUnavailableError(err, "vehicle service is failing and vehicle selection is paused; retry later or pass vehicleId")
+	}
+	switch st := status.Convert(err); st.Code() {
+	case codes.NotFound, codes.FailedPrecondition:
+		return models.NewConflictError("no vehicle is available on route %s", routeID)
+	case codes.InvalidArgument:
+		return models.NewValidationError("%s", st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return models.NewUnavailableError(err, "vehicle service did not answer; retry later or pass vehicleId")
+	}
+	return fmt.Errorf("find available vehicle on route %s: %w", routeID, err)
+}
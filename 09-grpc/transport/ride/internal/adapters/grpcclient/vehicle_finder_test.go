@@ -0,0 +1,150 @@
+package grpcclient_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/vehiclepb"
+	"github.com/yourname/transport/ride/internal/adapters/grpcclient"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+const testBufSize = 1024 * 1024
+
+// fakeVehicleService answers FindAvailableVehicle with resp, or with a
+// status error when code is set, and keeps the last request.
+type fakeVehicleService struct {
+	vehiclepb.UnimplementedVehicleServiceServer
+	resp  *vehiclepb.FindResponse
+	code  codes.Code
+	calls int32
+	last  atomic.Pointer[vehiclepb.FindRequest]
+}
+
+func (f *fakeVehicleService) FindAvailableVehicle(ctx context.Context, req *vehiclepb.FindRequest) (*vehiclepb.FindResponse, error) {
+	atomic.AddInt32(&f.calls, 1)
+	f.last.Store(req)
+	if f.code != codes.OK {
+		return nil, status.Error(f.code, "route "+req.GetRouteId()+" failed")
+	}
+	return f.resp, nil
+}
+
+// newFinder serves svc on bufconn and returns a finder for it. Retries are
+// off so every lookup is one call the breaker counts.
+func newFinder(t *testing.T, svc vehiclepb.VehicleServiceServer) *grpcclient.VehicleFinder {
+	t.Helper()
+	lis := bufconn.Listen(testBufSize)
+	srv := grpc.NewServer()
+	vehiclepb.RegisterVehicleServiceServer(srv, svc)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("server exited unexpectedly: %v", err)
+		}
+	}()
+
+	client := grpcclient.NewVehicleGrpcClient("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithDisableRetry(),
+	)
+	t.Cleanup(func() {
+		client.Close()
+		srv.Stop()
+		lis.Close()
+	})
+	return grpcclient.NewVehicleFinder(client, time.Second)
+}
+
+// Lookups are for the hour from testStart.
+var testStart = time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+
+func TestVehicleFinderMapsAnswers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		svc         *fakeVehicleService
+		wantVehicle string
+		wantErr     error
+	}{
+		{name: "available", svc: &fakeVehicleService{resp: &vehiclepb.FindResponse{VehicleId: "bus-123", Status: "available"}}, wantVehicle: "bus-123"},
+		{name: "in maintenance", svc: &fakeVehicleService{resp: &vehiclepb.FindResponse{VehicleId: "bus-123", Status: "maintenance"}}, wantErr: models.ErrConflict},
+		{name: "none on the route", svc: &fakeVehicleService{code: codes.NotFound}, wantErr: models.ErrConflict},
+		{name: "bad route", svc: &fakeVehicleService{code: codes.InvalidArgument}, wantErr: models.ErrValidation},
+		{name: "unavailable", svc: &fakeVehicleService{code: codes.Unavailable}, wantErr: models.ErrUnavailable},
+		{name: "internal", svc: &fakeVehicleService{code: codes.Internal}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vehicleID, err := newFinder(t, tc.svc).FindAvailableVehicle(context.Background(), "R1", testStart, testStart.Add(time.Hour))
+			switch {
+			case tc.wantVehicle != "":
+				if err != nil || vehicleID != tc.wantVehicle {
+					t.Fatalf("expected %s, got %q %v", tc.wantVehicle, vehicleID, err)
+				}
+				req := tc.svc.last.Load()
+				if !req.GetStartsAt().AsTime().Equal(testStart) || !req.GetEndsAt().AsTime().Equal(testStart.Add(time.Hour)) {
+					t.Fatalf("expected the lookup for the assignment's window, got %v", req)
+				}
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+			default:
+				// Unclassified failures surface as internal errors.
+				var de *models.DomainError
+				if err == nil || errors.As(err, &de) {
+					t.Fatalf("expected an unclassified error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestVehicleFinderStopsCallingWhenBreakerOpens(t *testing.T) {
+	svc := &fakeVehicleService{code: codes.Unavailable}
+	finder := newFinder(t, svc)
+
+	// The breaker needs 10 calls before it judges the failure rate.
+	for range 10 {
+		if _, err := finder.FindAvailableVehicle(context.Background(), "R1", testStart, testStart.Add(time.Hour)); !errors.Is(err, models.ErrUnavailable) {
+			t.Fatalf("expected ErrUnavailable, got %v", err)
+		}
+	}
+
+	_, err := finder.FindAvailableVehicle(context.Background(), "R1", testStart, testStart.Add(time.Hour))
+	if !errors.Is(err, models.ErrUnavailable) || !errors.Is(err, gobreaker.ErrOpenState) {
+		t.Fatalf("expected an open breaker, got %v", err)
+	}
+	if msg := models.ErrorMessage(err, ""); !strings.Contains(msg, "pass vehicleId") {
+		t.Fatalf("expected a hint to pass vehicleId, got %q", msg)
+	}
+	if calls := atomic.LoadInt32(&svc.calls); calls != 10 {
+		t.Fatalf("expected the open breaker to skip the call, got %d calls", calls)
+	}
+}
+
+func TestVehicleFinderUnreachable(t *testing.T) {
+	client := grpcclient.NewVehicleGrpcClient("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		}),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	_, err := grpcclient.NewVehicleFinder(client, 100*time.Millisecond).FindAvailableVehicle(context.Background(), "R1", testStart, testStart.Add(time.Hour))
+	if !errors.Is(err, models.ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+}
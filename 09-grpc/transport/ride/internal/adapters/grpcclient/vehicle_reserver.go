@@ -0,0 +1,96 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/vehiclepb"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// tenantKey names the tenant of a call in its metadata; the vehicle service
+// keeps the reservations of every tenant apart.
+const tenantKey = "x-tenant-id"
+
+// VehicleReserver implements ports.VehicleReserver over a VehicleGrpcClient.
+// Like VehicleFinder it dials on first use; the two may share a client and
+// so its circuit breaker.
+type VehicleReserver struct {
+	client  *VehicleGrpcClient
+	timeout time.Duration
+}
+
+// NewVehicleReserver bounds each call, dialing and retries included, by
+// timeout, 2s when zero.
+func NewVehicleReserver(client *VehicleGrpcClient, timeout time.Duration) *VehicleReserver {
+	if timeout <= 0 {
+		timeout = defaultFindTimeout
+	}
+	return &VehicleReserver{client: client, timeout: timeout}
+}
+
+func (r *VehicleReserver) Reserve(ctx context.Context, reservationID string, a models.Assignment) error {
+	ctx, cancel := context.WithTimeout(withTenant(ctx), r.timeout)
+	defer cancel()
+
+	if err := r.client.Dial(ctx); err != nil {
+		return models.NewUnavailableError(err, "vehicle service is unreachable; retry later")
+	}
+	_, err := r.client.ReserveVehicle(ctx, &vehiclepb.ReserveRequest{
+		ReservationId: reservationID,
+		VehicleId:     a.VehicleID,
+		RouteId:       a.RouteID,
+		StartsAt:      timestamppb.New(a.StartsAt),
+		EndsAt:        timestamppb.New(a.EndsAt),
+	})
+	if err == nil {
+		return nil
+	}
+	switch st := status.Convert(err); st.Code() {
+	case codes.Aborted, codes.AlreadyExists, codes.FailedPrecondition:
+		return models.NewConflictError("vehicle %s could not be reserved: %s", a.VehicleID, st.Message())
+	case codes.InvalidArgument:
+		return models.NewValidationError("%s", st.Message())
+	}
+	return reservationError("reserve vehicle "+a.VehicleID, err)
+}
+
+func (r *VehicleReserver) Release(ctx context.Context, reservationID string) error {
+	ctx, cancel := context.WithTimeout(withTenant(ctx), r.timeout)
+	defer cancel()
+
+	if err := r.client.Dial(ctx); err != nil {
+		return models.NewUnavailableError(err, "vehicle service is unreachable; retry later")
+	}
+	if _, err := r.client.ReleaseVehicle(ctx, &vehiclepb.ReleaseRequest{ReservationId: reservationID}); err != nil {
+		return reservationError("release reservation "+reservationID, err)
+	}
+	return nil
+}
+
+// withTenant names the tenant of ctx in the metadata of outgoing calls.
+func withTenant(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, tenantKey, requestctx.Tenant(ctx))
+}
+
+// reservationError classifies a failure whose outcome is unknown: the
+// vehicle service may or may not have applied the call.
+func reservationError(op string, err error) error {
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return models.NewUnavailableError(err, "vehicle service is failing and reservations are paused; retry later")
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Canceled:
+		return models.NewUnavailableError(err, "vehicle service did not answer; retry later")
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,136 @@
+package grpcclient_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/vehiclepb"
+	"github.com/yourname/transport/ride/internal/adapters/grpcclient"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// fakeReservationService answers ReserveVehicle and ReleaseVehicle with a
+// status error when code is set, and records the last reservation and the
+// tenant of the last call.
+type fakeReservationService struct {
+	vehiclepb.UnimplementedVehicleServiceServer
+	code     codes.Code
+	reserved *vehiclepb.ReserveRequest
+	released string
+	tenant   string
+}
+
+func (f *fakeReservationService) record(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-tenant-id"); len(values) > 0 {
+		f.tenant = values[0]
+	}
+}
+
+func (f *fakeReservationService) ReserveVehicle(ctx context.Context, req *vehiclepb.ReserveRequest) (*vehiclepb.ReserveResponse, error) {
+	f.record(ctx)
+	if f.code != codes.OK {
+		return nil, status.Error(f.code, "vehicle "+req.GetVehicleId()+" failed")
+	}
+	f.reserved = req
+	return &vehiclepb.ReserveResponse{ReservationId: req.GetReservationId()}, nil
+}
+
+func (f *fakeReservationService) ReleaseVehicle(ctx context.Context, req *vehiclepb.ReleaseRequest) (*vehiclepb.ReleaseResponse, error) {
+	f.record(ctx)
+	if f.code != codes.OK {
+		return nil, status.Error(f.code, "release failed")
+	}
+	f.released = req.GetReservationId()
+	return &vehiclepb.ReleaseResponse{Released: true}, nil
+}
+
+func newReserver(t *testing.T, svc vehiclepb.VehicleServiceServer) *grpcclient.VehicleReserver {
+	t.Helper()
+	lis := bufconn.Listen(testBufSize)
+	srv := grpc.NewServer()
+	vehiclepb.RegisterVehicleServiceServer(srv, svc)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("server exited unexpectedly: %v", err)
+		}
+	}()
+
+	client := grpcclient.NewVehicleGrpcClient("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithDisableRetry(),
+	)
+	t.Cleanup(func() {
+		client.Close()
+		srv.Stop()
+		lis.Close()
+	})
+	return grpcclient.NewVehicleReserver(client, time.Second)
+}
+
+func TestVehicleReserverMapsAnswers(t *testing.T) {
+	start := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	a := models.Assignment{ID: "A1", VehicleID: "bus-123", RouteID: "R1", StartsAt: start, EndsAt: start.Add(time.Hour)}
+
+	testCases := []struct {
+		name    string
+		code    codes.Code
+		wantErr error
+	}{
+		{name: "reserved", code: codes.OK},
+		{name: "overlapping reservation", code: codes.Aborted, wantErr: models.ErrConflict},
+		{name: "bad window", code: codes.InvalidArgument, wantErr: models.ErrValidation},
+		{name: "unavailable", code: codes.Unavailable, wantErr: models.ErrUnavailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeReservationService{code: tc.code}
+			err := newReserver(t, svc).Reserve(requestctx.WithTenant(context.Background(), "acme"), "A1", a)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+			if svc.tenant != "acme" {
+				t.Fatalf("expected the reservation made for acme, got %q", svc.tenant)
+			}
+			if svc.reserved.GetReservationId() != "A1" || svc.reserved.GetVehicleId() != "bus-123" ||
+				!svc.reserved.GetStartsAt().AsTime().Equal(a.StartsAt) || !svc.reserved.GetEndsAt().AsTime().Equal(a.EndsAt) {
+				t.Fatalf("unexpected reservation: %+v", svc.reserved)
+			}
+		})
+	}
+}
+
+func TestVehicleReserverRelease(t *testing.T) {
+	svc := &fakeReservationService{}
+	if err := newReserver(t, svc).Release(context.Background(), "A1"); err != nil || svc.released != "A1" {
+		t.Fatalf("expected A1 released, got %q %v", svc.released, err)
+	}
+	if svc.tenant != requestctx.DefaultTenant {
+		t.Fatalf("expected the release made for the default tenant, got %q", svc.tenant)
+	}
+
+	// A release that went unanswered may or may not have happened; the
+	// saga retries it.
+	err := newReserver(t, &fakeReservationService{code: codes.Unavailable}).Release(context.Background(), "A1")
+	if !errors.Is(err, models.ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+}
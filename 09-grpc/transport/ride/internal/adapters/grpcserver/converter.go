@@ -0,0 +1,126 @@
+package grpcserver
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// changeReset tells a resuming client that it missed changes, like the
+// reset event of the HTTP stream.
+const changeReset = "reset"
+
+// Domain -> proto
+func assignmentFromDomain(a models.Assignment) *ridepb.Assignment {
+	return &ridepb.Assignment{
+		Id:                  a.ID,
+		VehicleId:           a.VehicleID,
+		VehicleAutoSelected: a.VehicleAutoSelected,
+		RouteId:             a.RouteID,
+		StartsAt:            timestamp(a.StartsAt),
+		EndsAt:              timestamp(a.EndsAt),
+		Status:              a.Status,
+		Version:             a.Version,
+		UpdatedAt:           timestamp(a.UpdatedAt),
+	}
+}
+
+// Proto (create request) -> domain. ID and status are assigned by the
+// service, which also defaults a missing end time.
+func newAssignmentToDomain(r *ridepb.CreateAssignmentRequest) models.Assignment {
+	return models.Assignment{
+		VehicleID: r.GetVehicleId(),
+		RouteID:   r.GetRouteId(),
+		StartsAt:  timeOf(r.GetStartsAt()),
+		EndsAt:    timeOf(r.GetEndsAt()),
+	}
+}
+
+// Domain -> proto
+func changeFromDomain(e models.AuditEntry) *ridepb.AssignmentChange {
+	out := &ridepb.AssignmentChange{
+		EventId:      e.ID,
+		Action:       string(e.Action),
+		AssignmentId: e.AssignmentID,
+		Actor:        e.Actor,
+		RequestId:    e.RequestID,
+		After:        assignmentFromDomain(e.After),
+		At:           timestamp(e.At),
+	}
+	if e.Before != nil {
+		out.Before = assignmentFromDomain(*e.Before)
+	}
+	return out
+}
+
+// assignmentQueryFromRequest maps a ListAssignments request onto a domain
+// query. Cursors are the ones of the REST API, so a client may switch
+// between the two while paging. Limits and defaults are left to the service.
+func assignmentQueryFromRequest(r *ridepb.ListAssignmentsRequest) (models.AssignmentQuery, error) {
+	q := models.AssignmentQuery{
+		Status:    r.Status,
+		VehicleID: r.VehicleId,
+		RouteID:   r.RouteId,
+		Order:     models.SortAscending,
+		Limit:     int(r.GetLimit()),
+	}
+	if q.Status != nil && !models.AssignmentStatus(*q.Status).IsValid() {
+		return models.AssignmentQuery{}, models.NewValidationError("unknown status %q", *q.Status)
+	}
+	if r.StartsFrom != nil {
+		from := r.StartsFrom.AsTime()
+		q.StartsFrom = &from
+	}
+	if r.StartsTo != nil {
+		to := r.StartsTo.AsTime()
+		q.StartsTo = &to
+	}
+	if r.GetDescending() {
+		q.Order = models.SortDescending
+	}
+	if r.GetCursor() != "" {
+		after, err := converter.DecodeCursor(r.GetCursor(), q.Order)
+		if err != nil {
+			return models.AssignmentQuery{}, err
+		}
+		q.After = &after
+	}
+	return q, nil
+}
+
+// changeFilterFromRequest maps a WatchAssignments request onto a feed
+// filter for tenant.
+func changeFilterFromRequest(r *ridepb.WatchAssignmentsRequest, tenant string) (models.AssignmentChangeFilter, error) {
+	if r.Status != nil && !models.AssignmentStatus(*r.Status).IsValid() {
+		return models.AssignmentChangeFilter{}, models.NewValidationError("unknown status %q", *r.Status)
+	}
+	if r.GetAfterEventId() < 0 {
+		return models.AssignmentChangeFilter{}, models.NewValidationError("afterEventId must not be negative")
+	}
+	return models.AssignmentChangeFilter{
+		TenantID:  tenant,
+		Status:    r.Status,
+		VehicleID: r.VehicleId,
+		RouteID:   r.RouteId,
+	}, nil
+}
+
+// timestamp leaves zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeOf is the zero time for an unset timestamp.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,54 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpcserver"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+func TestErrorUnaryInterceptorMapsDomainErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		want    codes.Code
+		wantMsg string
+	}{
+		{name: "validation", err: models.NewValidationError("vehicleId is required"), want: codes.InvalidArgument, wantMsg: "vehicleId is required"},
+		{name: "not found", err: models.NewNotFoundError("assignment %s not found", "A1"), want: codes.NotFound, wantMsg: "assignment A1 not found"},
+		{name: "conflict", err: models.NewConflictError("vehicle is booked"), want: codes.Aborted},
+		{name: "precondition", err: models.NewPreconditionFailedError("version mismatch"), want: codes.FailedPrecondition},
+		{name: "unavailable", err: models.NewUnavailableError(errors.New("dial tcp"), "database unavailable"), want: codes.Unavailable, wantMsg: "database unavailable"},
+		{name: "wrapped", err: fmt.Errorf("lookup: %w", models.NewNotFoundError("gone")), want: codes.NotFound},
+		{name: "deadline", err: context.DeadlineExceeded, want: codes.DeadlineExceeded},
+		{name: "unclassified", err: errors.New("boom"), want: codes.Internal, wantMsg: "internal error"},
+		{name: "status passthrough", err: status.Error(codes.PermissionDenied, "token lacks scope"), want: codes.PermissionDenied, wantMsg: "token lacks scope"},
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/ridepb.RideService/GetAssignment"}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := grpcserver.ErrorUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+				return nil, tc.err
+			})
+
+			st, ok := status.FromError(err)
+			if !ok {
+				t.Fatalf("expected gRPC status error, got: %v", err)
+			}
+			if st.Code() != tc.want {
+				t.Fatalf("expected code %v, got %v", tc.want, st.Code())
+			}
+			if tc.wantMsg != "" && st.Message() != tc.wantMsg {
+				t.Fatalf("expected message %q, got %q", tc.wantMsg, st.Message())
+			}
+		})
+	}
+}
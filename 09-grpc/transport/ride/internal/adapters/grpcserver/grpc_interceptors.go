@@ -0,0 +1,258 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/tenancy"
+)
+
+// Metadata keys read from calls; they match the HTTP headers of the REST API.
+const (
+	RequestIDKey = "x-request-id"
+	ActorKey     = "x-actor-id"
+	TenantKey    = "x-tenant-id"
+
+	// retryAfterKey tells refused callers when to retry, in seconds.
+	retryAfterKey = "retry-after"
+)
+
+// maxRequestIDLength bounds caller-supplied request IDs, as over HTTP.
+const maxRequestIDLength = 128
+
+// rpc describes a method as the REST operation it mirrors: the token scope
+// it needs, and the operationId it is rate limited and shed as.
+type rpc struct {
+	scope     string
+	operation string
+}
+
+// methods lists every RPC that is served; authenticated calls to any other
+// method are refused.
+var methods = map[string]rpc{
+	ridepb.RideService_CreateAssignment_FullMethodName:     {scope: "assignments:write", operation: "createAssignment"},
+	ridepb.RideService_TransitionAssignment_FullMethodName: {scope: "assignments:write", operation: "transitionAssignment"},
+	ridepb.RideService_GetAssignment_FullMethodName:        {scope: "assignments:read", operation: "getAssignment"},
+	ridepb.RideService_ListAssignments_FullMethodName:      {scope: "assignments:read", operation: "listAssignments"},
+	ridepb.RideService_WatchAssignments_FullMethodName:     {scope: "assignments:read", operation: "streamAssignments"},
+}
+
+func LoggingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	log.Printf("method=%s duration=%s error=%v", info.FullMethod, duration, err)
+	return res, err
+}
+
+func LoggingStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	log.Printf("stream=%s started", info.FullMethod)
+	err := handler(srv, ss)
+	log.Printf("stream=%s ended with error=%v", info.FullMethod, err)
+	return err
+}
+
+// contextStep derives the context a call runs with from the one it came in
+// with. A status error stops the call.
+type contextStep func(ctx context.Context, method string) (context.Context, error)
+
+// ContextUnaryInterceptor runs step before unary handlers.
+func ContextUnaryInterceptor(step contextStep) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := step(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ContextStreamInterceptor runs step before stream handlers.
+func ContextStreamInterceptor(step contextStep) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := step(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+// RequestMetadata stores the caller's request ID, or a new one, and actor in
+// the context, and echoes the request ID in the response header.
+func RequestMetadata(ctx context.Context, _ string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := first(md, RequestIDKey)
+	if id == "" || len(id) > maxRequestIDLength {
+		id = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	ctx = requestctx.WithRequestID(ctx, id)
+	if actor := first(md, ActorKey); actor != "" {
+		ctx = requestctx.WithActor(ctx, actor)
+	}
+	return ctx, nil
+}
+
+// Authenticate verifies the bearer token in the "authorization" metadata and
+// checks that it grants the scope of the method; methods missing from
+// methods are refused. Like the HTTP Auth middleware it stores the claims,
+// makes the subject the actor and pins the call to the tenant of the token
+// (see tenancy.OfClaims).
+func Authenticate(verifier ports.TokenVerifier, requireTenantClaim bool) contextStep {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		scheme, token, ok := strings.Cut(first(md, "authorization"), " ")
+		token = strings.TrimSpace(token)
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, status.Error(codes.Unauthenticated, "a bearer token is required")
+		}
+		claims, err := verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		m, ok := methods[method]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s is not served", method)
+		}
+		if !claims.HasScope(m.scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "token lacks scope %s", m.scope)
+		}
+		tenant, err := tenancy.OfClaims(claims, requireTenantClaim)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		ctx = requestctx.WithClaims(ctx, claims)
+		ctx = requestctx.WithActor(ctx, claims.Subject)
+		return requestctx.WithTenant(ctx, tenant), nil
+	}
+}
+
+// ResolveTenant puts the tenant of the call into its context under the
+// rules of tenancy.Resolver, as the HTTP Tenant middleware does: the tenant
+// of the token wins and a contradicting x-tenant-id is rejected; without
+// credentials x-tenant-id names it, or it is requestctx.DefaultTenant.
+func ResolveTenant(tenants []string) contextStep {
+	resolver := tenancy.NewResolver(tenants, TenantKey)
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		tenant, err := resolver.Resolve(ctx, first(md, TenantKey))
+		switch {
+		case errors.Is(err, tenancy.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case err != nil:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return requestctx.WithTenant(ctx, tenant), nil
+	}
+}
+
+// RateLimit refuses calls that find the token bucket of their client and
+// method empty with ResourceExhausted and a retry-after header. Methods
+// draw on the buckets of the REST operations they mirror, so a client has
+// one budget across both APIs.
+func RateLimit(limiter *admission.RateLimiter) contextStep {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		m, ok := methods[method]
+		if !ok {
+			return ctx, nil
+		}
+		wait, err := limiter.Allow(m.operation, admission.ClientKey(ctx, peerIP(ctx)))
+		if err != nil {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterKey, strconv.Itoa(int(math.Ceil(wait.Seconds())))))
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return ctx, nil
+	}
+}
+
+// LoadShedUnaryInterceptor refuses calls that shedder turns away with
+// Unavailable and a retry-after header, counting them with the REST
+// requests in flight.
+func LoadShedUnaryInterceptor(shedder *admission.LoadShedder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		release, err := admit(ctx, shedder, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// LoadShedStreamInterceptor is the streaming counterpart of
+// LoadShedUnaryInterceptor.
+func LoadShedStreamInterceptor(shedder *admission.LoadShedder) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := admit(ss.Context(), shedder, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
+
+func admit(ctx context.Context, shedder *admission.LoadShedder, method string) (func(), error) {
+	m, ok := methods[method]
+	if !ok {
+		return func() {}, nil
+	}
+	release, ok := shedder.Admit(m.operation)
+	if !ok {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterKey, "1"))
+		return nil, status.Error(codes.Unavailable, "too many requests in flight; retry later")
+	}
+	return release, nil
+}
+
+// peerIP returns the IP address of the caller, or "" when it is unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+)
+
+// TestMethodsCoverTheService walks the service descriptor, so that a new RPC
+// cannot be served without a scope and a rate limited operation.
+func TestMethodsCoverTheService(t *testing.T) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		t.Fatalf("GetSwagger: %v", err)
+	}
+	operations := map[string]bool{}
+	for _, item := range swagger.Paths.Map() {
+		for _, op := range item.Operations() {
+			// The embedded spec has operationIds capitalized by oapi-codegen.
+			r, size := utf8.DecodeRuneInString(op.OperationID)
+			operations[string(unicode.ToLower(r))+op.OperationID[size:]] = true
+		}
+	}
+
+	desc := ridepb.RideService_ServiceDesc
+	var served []string
+	for _, m := range desc.Methods {
+		served = append(served, "/"+desc.ServiceName+"/"+m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		served = append(served, "/"+desc.ServiceName+"/"+s.StreamName)
+	}
+	for _, name := range served {
+		m, ok := methods[name]
+		switch {
+		case !ok:
+			t.Errorf("%s has no entry in methods", name)
+		case m.scope == "":
+			t.Errorf("%s needs no scope", name)
+		case !operations[m.operation]:
+			t.Errorf("%s maps to %q, which is not an operation of the REST API", name, m.operation)
+		}
+	}
+	if len(methods) != len(served) {
+		t.Errorf("methods lists %d RPCs, the service has %d", len(methods), len(served))
+	}
+}
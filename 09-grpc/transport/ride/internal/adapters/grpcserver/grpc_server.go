@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+const (
+	defaultConnectionTimeout = 120 * time.Second
+	defaultShutdownTimeout   = 10 * time.Second
+)
+
+// Deps holds what the gRPC server is built from besides the service.
+// Verifier, RateLimiter and LoadShedder may be nil, which turns
+// authentication, rate limiting and load shedding off.
+type Deps struct {
+	Verifier ports.TokenVerifier
+	// RequireTenantClaim refuses tokens without a tenant claim.
+	RequireTenantClaim bool
+	// Tenants are served as by the HTTP API.
+	Tenants []string
+	// RateLimiter and LoadShedder are shared with the HTTP server.
+	RateLimiter *admission.RateLimiter
+	LoadShedder *admission.LoadShedder
+}
+
+// NewGRPCServer builds the gRPC server of the ride service.
+func NewGRPCServer(cfg configs.GRPCConfig, service ridepb.RideServiceServer, deps Deps) *grpc.Server {
+	// Logging runs outermost so it records the mapped status, not the raw
+	// error; the rest runs inside it in the order of the HTTP middleware.
+	unary := []grpc.UnaryServerInterceptor{LoggingUnaryInterceptor, ErrorUnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{LoggingStreamInterceptor, ErrorStreamInterceptor}
+	use := func(step contextStep) {
+		unary = append(unary, ContextUnaryInterceptor(step))
+		stream = append(stream, ContextStreamInterceptor(step))
+	}
+	use(RequestMetadata)
+	if deps.Verifier != nil {
+		use(Authenticate(deps.Verifier, deps.RequireTenantClaim))
+	} else {
+		log.Println("WARNING: gRPC authentication is disabled; calls are not checked")
+	}
+	// Limits apply per authenticated client, and only admitted calls take
+	// a load shedder slot.
+	if deps.RateLimiter != nil {
+		use(RateLimit(deps.RateLimiter))
+	}
+	if deps.LoadShedder != nil {
+		unary = append(unary, LoadShedUnaryInterceptor(deps.LoadShedder))
+		stream = append(stream, LoadShedStreamInterceptor(deps.LoadShedder))
+	}
+	use(ResolveTenant(deps.Tenants))
+
+	connectionTimeout := time.Duration(cfg.ConnectionTimeoutSec) * time.Second
+	if connectionTimeout == 0 {
+		connectionTimeout = defaultConnectionTimeout
+	}
+	grpcSrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		grpc.ConnectionTimeout(connectionTimeout),
+	)
+	ridepb.RegisterRideServiceServer(grpcSrv, service)
+	return grpcSrv
+}
+
+// Run serves grpcServer on cfg.Port until ctx is cancelled. Open calls get
+// cfg.ShutdownTimeoutSec to finish; streams still open after that are cut.
+func Run(ctx context.Context, cfg configs.GRPCConfig, grpcServer *grpc.Server) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port %d: %w", cfg.Port, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			errCh <- fmt.Errorf("failed to serve gRPC: %w", err)
+		}
+	}()
+	log.Printf("gRPC server listening on :%d", cfg.Port)
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		return err
+	}
+
+	shutdownTimeout := time.Duration(cfg.ShutdownTimeoutSec) * time.Second
+	if shutdownTimeout == 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop() // waits for in-flight RPCs to finish
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		grpcServer.Stop()
+		<-stopped
+	}
+	return nil
+}
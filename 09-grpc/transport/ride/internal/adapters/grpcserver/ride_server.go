@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// RideServer is the gRPC adapter for assignments. It calls the same
+// AssignmentService as the REST handlers, so both APIs share validation,
+// lifecycle and auditing; domain errors become status codes in
+// ErrorUnaryInterceptor.
+type RideServer struct {
+	ridepb.UnimplementedRideServiceServer
+	assignments ports.AssignmentService
+	feed        ports.AssignmentFeed
+}
+
+func NewRideServer(assignments ports.AssignmentService, feed ports.AssignmentFeed) *RideServer {
+	return &RideServer{assignments: assignments, feed: feed}
+}
+
+func (s *RideServer) CreateAssignment(ctx context.Context, req *ridepb.CreateAssignmentRequest) (*ridepb.Assignment, error) {
+	saved, err := s.assignments.Save(ctx, newAssignmentToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return assignmentFromDomain(saved), nil
+}
+
+func (s *RideServer) GetAssignment(ctx context.Context, req *ridepb.GetAssignmentRequest) (*ridepb.Assignment, error) {
+	a, err := s.assignments.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return assignmentFromDomain(a), nil
+}
+
+func (s *RideServer) ListAssignments(ctx context.Context, req *ridepb.ListAssignmentsRequest) (*ridepb.ListAssignmentsResponse, error) {
+	q, err := assignmentQueryFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.assignments.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ridepb.ListAssignmentsResponse{Assignments: make([]*ridepb.Assignment, 0, len(page.Items))}
+	for _, a := range page.Items {
+		out.Assignments = append(out.Assignments, assignmentFromDomain(a))
+	}
+	if page.Next != nil {
+		out.NextCursor = converter.EncodeCursor(*page.Next, q.Order)
+	}
+	return out, nil
+}
+
+// TransitionAssignment checks expectedVersion like If-Match over HTTP; 0
+// skips the check.
+func (s *RideServer) TransitionAssignment(ctx context.Context, req *ridepb.TransitionAssignmentRequest) (*ridepb.Assignment, error) {
+	if req.GetExpectedVersion() < 0 {
+		return nil, models.NewValidationError("expectedVersion must not be negative")
+	}
+	a, err := s.assignments.Transition(ctx, req.GetId(), models.AssignmentStatus(req.GetTo()), req.GetReason(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	return assignmentFromDomain(a), nil
+}
+
+// WatchAssignments sends the changes of the caller's tenant: a reset when
+// the changes after afterEventId are gone, the buffered ones, then live ones
+// until the client cancels or the feed drops the subscription.
+func (s *RideServer) WatchAssignments(req *ridepb.WatchAssignmentsRequest, stream ridepb.RideService_WatchAssignmentsServer) error {
+	ctx := stream.Context()
+	filter, err := changeFilterFromRequest(req, requestctx.Tenant(ctx))
+	if err != nil {
+		return err
+	}
+
+	sub := s.feed.Subscribe(filter, req.GetAfterEventId())
+	defer sub.Close()
+
+	if !sub.Resumed() {
+		if err := stream.Send(&ridepb.AssignmentChange{Action: changeReset}); err != nil {
+			return err
+		}
+	}
+	for _, e := range sub.Replay() {
+		if err := stream.Send(changeFromDomain(e)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Evicted or shutting down: the client resumes from its last event.
+				return status.Error(codes.Unavailable, "change feed closed; resume from the last eventId")
+			}
+			if err := stream.Send(changeFromDomain(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/grpc/ridepb"
+	"github.com/yourname/transport/ride/internal/adapters/grpcserver"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// bufSize keeps the in-memory listener large enough for typical test payloads.
+const bufSize = 1024 * 1024
+
+// fakeVerifier accepts the tokens it knows.
+type fakeVerifier map[string]requestctx.Claims
+
+func (f fakeVerifier) Verify(ctx context.Context, token string) (requestctx.Claims, error) {
+	claims, ok := f[token]
+	if !ok {
+		return requestctx.Claims{}, errors.New("token is expired")
+	}
+	return claims, nil
+}
+
+// testServer is a RideServer over the memory repository, served on bufconn.
+// Its broadcaster publishes only when the test calls PollOnce.
+type testServer struct {
+	client      ridepb.RideServiceClient
+	repo        ports.AssignmentRepository
+	broadcaster *service.AssignmentBroadcaster
+}
+
+func startServer(t *testing.T, deps grpcserver.Deps) testServer {
+	t.Helper()
+	repo := repository.NewMemoryAssignmentRepository()
+	broadcaster := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{})
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpcserver.NewGRPCServer(configs.GRPCConfig{ConnectionTimeoutSec: 5},
+		grpcserver.NewRideServer(service.NewAssignmentService(repo, nil, nil), broadcaster), deps)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("server exited unexpectedly: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+		lis.Close()
+	})
+	return testServer{client: ridepb.NewRideServiceClient(conn), repo: repo, broadcaster: broadcaster}
+}
+
+func callContext(t *testing.T, kv ...string) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %v, got %v (%v)", want, got, err)
+	}
+}
+
+func TestRideServerAssignments(t *testing.T) {
+	s := startServer(t, grpcserver.Deps{})
+	ctx := callContext(t)
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	var ids []string
+	for i, vehicle := range []string{"V1", "V2", "V3"} {
+		var header metadata.MD
+		a, err := s.client.CreateAssignment(ctx, &ridepb.CreateAssignmentRequest{
+			VehicleId: vehicle,
+			RouteId:   "R1",
+			StartsAt:  timestamppb.New(start.Add(time.Duration(i) * time.Hour)),
+		}, grpc.Header(&header))
+		if err != nil {
+			t.Fatalf("CreateAssignment: %v", err)
+		}
+		if a.GetStatus() != string(models.AssignmentStatusPending) || a.GetVersion() != 1 {
+			t.Fatalf("unexpected assignment %v", a)
+		}
+		if got := a.GetEndsAt().AsTime(); !got.Equal(start.Add(time.Duration(i+1) * time.Hour)) {
+			t.Fatalf("expected endsAt to default to an hour later, got %v", got)
+		}
+		if len(header.Get(grpcserver.RequestIDKey)) != 1 {
+			t.Fatalf("expected a request ID in the response header, got %v", header)
+		}
+		ids = append(ids, a.GetId())
+	}
+
+	_, err := s.client.CreateAssignment(ctx, &ridepb.CreateAssignmentRequest{RouteId: "R1"})
+	wantCode(t, err, codes.InvalidArgument)
+
+	got, err := s.client.GetAssignment(ctx, &ridepb.GetAssignmentRequest{Id: ids[0]})
+	if err != nil || got.GetVehicleId() != "V1" {
+		t.Fatalf("GetAssignment: %v %v", got, err)
+	}
+	_, err = s.client.GetAssignment(ctx, &ridepb.GetAssignmentRequest{Id: "missing"})
+	wantCode(t, err, codes.NotFound)
+
+	// Pages follow each other through the cursor, newest first.
+	var listed []string
+	req := &ridepb.ListAssignmentsRequest{Descending: true, Limit: 2}
+	for {
+		page, err := s.client.ListAssignments(ctx, req)
+		if err != nil {
+			t.Fatalf("ListAssignments: %v", err)
+		}
+		for _, a := range page.GetAssignments() {
+			listed = append(listed, a.GetId())
+		}
+		if page.GetNextCursor() == "" {
+			break
+		}
+		req.Cursor = page.GetNextCursor()
+	}
+	if len(listed) != 3 || listed[0] != ids[2] || listed[2] != ids[0] {
+		t.Fatalf("expected %v newest first, got %v", ids, listed)
+	}
+	_, err = s.client.ListAssignments(ctx, &ridepb.ListAssignmentsRequest{Status: ptr("parked")})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = s.client.ListAssignments(ctx, &ridepb.ListAssignmentsRequest{Cursor: req.Cursor})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = s.client.TransitionAssignment(ctx, &ridepb.TransitionAssignmentRequest{Id: ids[0], To: "active", ExpectedVersion: 7})
+	wantCode(t, err, codes.FailedPrecondition)
+	active, err := s.client.TransitionAssignment(ctx, &ridepb.TransitionAssignmentRequest{Id: ids[0], To: "active", ExpectedVersion: 1})
+	if err != nil || active.GetStatus() != "active" || active.GetVersion() != 2 {
+		t.Fatalf("TransitionAssignment: %v %v", active, err)
+	}
+	_, err = s.client.TransitionAssignment(ctx, &ridepb.TransitionAssignmentRequest{Id: ids[0], To: "pending"})
+	wantCode(t, err, codes.Aborted)
+
+	page, err := s.client.ListAssignments(ctx, &ridepb.ListAssignmentsRequest{Status: ptr("active")})
+	if err != nil || len(page.GetAssignments()) != 1 || page.GetAssignments()[0].GetId() != ids[0] {
+		t.Fatalf("ListAssignments by status: %v %v", page, err)
+	}
+}
+
+func TestRideServerWatchAssignments(t *testing.T) {
+	s := startServer(t, grpcserver.Deps{})
+	ctx := callContext(t)
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	create := func(vehicle string) {
+		t.Helper()
+		start = start.Add(time.Hour)
+		if _, err := s.client.CreateAssignment(ctx, &ridepb.CreateAssignmentRequest{VehicleId: vehicle, RouteId: "R1", StartsAt: timestamppb.New(start)}); err != nil {
+			t.Fatalf("CreateAssignment: %v", err)
+		}
+	}
+	publish := func(want int) {
+		t.Helper()
+		if n, err := s.broadcaster.PollOnce(context.Background()); err != nil || n != want {
+			t.Fatalf("PollOnce: expected %d changes, got %d (err %v)", want, n, err)
+		}
+	}
+
+	publish(0)
+	create("V1")
+	create("V2")
+	publish(2)
+
+	// Resuming after the first change replays the second, which also shows
+	// the subscription is in place before the live change is published.
+	stream, err := s.client.WatchAssignments(ctx, &ridepb.WatchAssignmentsRequest{VehicleId: ptr("V2"), AfterEventId: 1})
+	if err != nil {
+		t.Fatalf("WatchAssignments: %v", err)
+	}
+	change, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if change.GetEventId() != 2 || change.GetAction() != string(models.AuditActionCreated) || change.GetAfter().GetVehicleId() != "V2" || change.GetBefore() != nil {
+		t.Fatalf("expected the creation on V2, got %v", change)
+	}
+
+	create("V1")
+	create("V2")
+	publish(2)
+	if change, err := stream.Recv(); err != nil || change.GetEventId() != 4 {
+		t.Fatalf("expected the live change 4, got %v %v", change, err)
+	}
+
+	// A replica that just started cannot replay anything.
+	fresh := startServer(t, grpcserver.Deps{})
+	stale, err := fresh.client.WatchAssignments(ctx, &ridepb.WatchAssignmentsRequest{AfterEventId: 3})
+	if err != nil {
+		t.Fatalf("WatchAssignments: %v", err)
+	}
+	if change, err := stale.Recv(); err != nil || change.GetAction() != "reset" || change.GetEventId() != 0 {
+		t.Fatalf("expected a reset, got %v %v", change, err)
+	}
+
+	bad, err := s.client.WatchAssignments(ctx, &ridepb.WatchAssignmentsRequest{AfterEventId: -1})
+	if err != nil {
+		t.Fatalf("WatchAssignments: %v", err)
+	}
+	_, err = bad.Recv()
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestRideServerAuthAndTenants(t *testing.T) {
+	verifier := fakeVerifier{
+		"reader":   {Subject: "ops-1", Scopes: []string{"assignments:read"}, Tenant: "berlin"},
+		"writer":   {Subject: "ops-2", Scopes: []string{"assignments:read", "assignments:write"}, Tenant: "berlin"},
+		"parisian": {Subject: "ops-3", Scopes: []string{"assignments:read", "assignments:write"}, Tenant: "paris"},
+		"lyonnais": {Subject: "ops-4", Scopes: []string{"assignments:read"}, Tenant: "lyon"},
+		"unpinned": {Subject: "ops-5", Scopes: []string{"assignments:read"}},
+	}
+	s := startServer(t, grpcserver.Deps{Verifier: verifier, RequireTenantClaim: true, Tenants: []string{"berlin", "paris"}})
+	start := timestamppb.New(time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name     string
+		metadata []string
+		create   bool
+		wantCode codes.Code
+	}{
+		{name: "no token", metadata: []string{grpcserver.TenantKey, "berlin"}, wantCode: codes.Unauthenticated},
+		{name: "not a bearer token", metadata: []string{"authorization", "Basic cmVhZGVy", grpcserver.TenantKey, "berlin"}, wantCode: codes.Unauthenticated},
+		{name: "unknown token", metadata: []string{"authorization", "Bearer stale", grpcserver.TenantKey, "berlin"}, wantCode: codes.Unauthenticated},
+		{name: "missing scope", metadata: []string{"authorization", "Bearer reader"}, create: true, wantCode: codes.PermissionDenied},
+		{name: "read scope", metadata: []string{"authorization", "Bearer reader", grpcserver.TenantKey, "berlin"}, wantCode: codes.OK},
+		{name: "write scope", metadata: []string{"authorization", "Bearer writer"}, create: true, wantCode: codes.OK},
+		{name: "tenant from token", metadata: []string{"authorization", "Bearer parisian"}, create: true, wantCode: codes.OK},
+		{name: "header contradicts token", metadata: []string{"authorization", "Bearer parisian", grpcserver.TenantKey, "berlin"}, wantCode: codes.PermissionDenied},
+		{name: "header cannot switch tenants", metadata: []string{"authorization", "Bearer reader", grpcserver.TenantKey, "paris"}, wantCode: codes.PermissionDenied},
+		{name: "token tenant not served", metadata: []string{"authorization", "Bearer lyonnais"}, wantCode: codes.PermissionDenied},
+		{name: "token without tenant claim", metadata: []string{"authorization", "Bearer unpinned", grpcserver.TenantKey, "berlin"}, wantCode: codes.Unauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := callContext(t, tc.metadata...)
+			var err error
+			if tc.create {
+				_, err = s.client.CreateAssignment(ctx, &ridepb.CreateAssignmentRequest{VehicleId: "V-" + tc.name, RouteId: "R1", StartsAt: start})
+			} else {
+				_, err = s.client.ListAssignments(ctx, &ridepb.ListAssignmentsRequest{})
+			}
+			wantCode(t, err, tc.wantCode)
+		})
+	}
+
+	// Each tenant sees its own assignments, created by the token's subject.
+	page, err := s.client.ListAssignments(callContext(t, "authorization", "Bearer parisian"), &ridepb.ListAssignmentsRequest{})
+	if err != nil || len(page.GetAssignments()) != 1 || page.GetAssignments()[0].GetVehicleId() != "V-tenant from token" {
+		t.Fatalf("expected the paris assignment only, got %v %v", page, err)
+	}
+	entries, err := s.repo.History(requestctx.WithTenant(context.Background(), "paris"), page.GetAssignments()[0].GetId())
+	if err != nil || len(entries) != 1 || entries[0].Actor != "ops-3" {
+		t.Fatalf("expected the creation by ops-3, got %v %v", entries, err)
+	}
+
+	// Streams go through the same checks.
+	stream, err := s.client.WatchAssignments(callContext(t, grpcserver.TenantKey, "berlin"), &ridepb.WatchAssignmentsRequest{})
+	if err != nil {
+		t.Fatalf("WatchAssignments: %v", err)
+	}
+	_, err = stream.Recv()
+	wantCode(t, err, codes.Unauthenticated)
+
+	// Without authentication the metadata names the tenant.
+	anonymous := startServer(t, grpcserver.Deps{Tenants: []string{"berlin"}})
+	_, err = anonymous.client.ListAssignments(callContext(t), &ridepb.ListAssignmentsRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = anonymous.client.ListAssignments(callContext(t, grpcserver.TenantKey, "rome"), &ridepb.ListAssignmentsRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = anonymous.client.ListAssignments(callContext(t, grpcserver.TenantKey, "berlin"), &ridepb.ListAssignmentsRequest{})
+	wantCode(t, err, codes.OK)
+}
+
+func TestRideServerAdmission(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	limiter, err := admission.NewRateLimiter(admission.RateLimiterOptions{
+		Operations: map[string]admission.Limit{"createAssignment": {Rate: 0.01}},
+		Registerer: reg,
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+	shedder, err := admission.NewLoadShedder(admission.LoadShedderOptions{MaxInFlight: 1, Registerer: reg})
+	if err != nil {
+		t.Fatalf("NewLoadShedder: %v", err)
+	}
+	s := startServer(t, grpcserver.Deps{RateLimiter: limiter, LoadShedder: shedder})
+	create := &ridepb.CreateAssignmentRequest{VehicleId: "V1", RouteId: "R1", StartsAt: timestamppb.New(time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC))}
+
+	if _, err := s.client.CreateAssignment(callContext(t), create); err != nil {
+		t.Fatalf("CreateAssignment: %v", err)
+	}
+	var header metadata.MD
+	_, err = s.client.CreateAssignment(callContext(t), create, grpc.Header(&header))
+	wantCode(t, err, codes.ResourceExhausted)
+	if got := header.Get("retry-after"); len(got) != 1 || got[0] != "100" {
+		t.Fatalf("expected retry-after 100, got %v", got)
+	}
+
+	// A request in flight elsewhere, e.g. over REST, takes the only slot.
+	release, ok := shedder.Admit("listAssignments")
+	if !ok {
+		t.Fatal("expected a free slot")
+	}
+	_, err = s.client.ListAssignments(callContext(t), &ridepb.ListAssignmentsRequest{}, grpc.Header(&header))
+	wantCode(t, err, codes.Unavailable)
+	if got := header.Get("retry-after"); len(got) != 1 {
+		t.Fatalf("expected retry-after, got %v", header)
+	}
+	release()
+	if _, err := s.client.ListAssignments(callContext(t), &ridepb.ListAssignmentsRequest{}); err != nil {
+		t.Fatalf("ListAssignments after the slot was freed: %v", err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	counted := map[string]bool{}
+	for _, f := range families {
+		counted[f.GetName()] = len(f.GetMetric()) > 0
+	}
+	for _, name := range []string{"ride_http_rate_limit_decisions_total", "ride_http_load_shedder_decisions_total"} {
+		if !counted[name] {
+			t.Errorf("expected gRPC calls to be counted in %s", name)
+		}
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -20,15 +20,66 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// List all assignments
+	// List assignments
 	// (GET /assignments)
 	ListAssignments(c *gin.Context, params ListAssignmentsParams)
 	// Create a new assignment
 	// (POST /assignments)
-	CreateAssignment(c *gin.Context)
+	CreateAssignment(c *gin.Context, params CreateAssignmentParams)
+	// Stream assignment changes
+	// (GET /assignments/stream)
+	StreamAssignments(c *gin.Context, params StreamAssignmentsParams)
 	// Get a single assignment
 	// (GET /assignments/{id})
-	GetAssignment(c *gin.Context, id string)
+	GetAssignment(c *gin.Context, id string, params GetAssignmentParams)
+	// Update an assignment
+	// (PUT /assignments/{id})
+	UpdateAssignment(c *gin.Context, id string, params UpdateAssignmentParams)
+	// Get the audit trail of an assignment
+	// (GET /assignments/{id}/history)
+	GetAssignmentHistory(c *gin.Context, id string)
+	// Move an assignment to another status
+	// (POST /assignments/{id}/transitions)
+	TransitionAssignment(c *gin.Context, id string, params TransitionAssignmentParams)
+	// Import assignments in bulk
+	// (POST /assignments:batchImport)
+	BatchImportAssignments(c *gin.Context, params BatchImportAssignmentsParams)
+	// Export assignments
+	// (GET /assignments:export)
+	ExportAssignments(c *gin.Context, params ExportAssignmentsParams)
+	// List recurring assignments
+	// (GET /recurring-assignments)
+	ListRecurringAssignments(c *gin.Context)
+	// Create a recurring assignment
+	// (POST /recurring-assignments)
+	CreateRecurringAssignment(c *gin.Context)
+	// Delete a recurring assignment
+	// (DELETE /recurring-assignments/{id})
+	DeleteRecurringAssignment(c *gin.Context, id string)
+	// Get a recurring assignment
+	// (GET /recurring-assignments/{id})
+	GetRecurringAssignment(c *gin.Context, id string)
+	// Replace a recurring assignment
+	// (PUT /recurring-assignments/{id})
+	UpdateRecurringAssignment(c *gin.Context, id string)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(c *gin.Context)
+	// Register a webhook
+	// (POST /webhooks)
+	CreateWebhook(c *gin.Context)
+	// Delete a webhook
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(c *gin.Context, id string)
+	// Get a webhook
+	// (GET /webhooks/{id})
+	GetWebhook(c *gin.Context, id string)
+	// Replace a webhook
+	// (PUT /webhooks/{id})
+	UpdateWebhook(c *gin.Context, id string)
+	// Get the delivery log of a webhook
+	// (GET /webhooks/{id}/deliveries)
+	ListWebhookDeliveries(c *gin.Context, id string, params ListWebhookDeliveriesParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -45,6 +96,8 @@ func (siw *ServerInterfaceWrapper) ListAssignments(c *gin.Context) {
 
 	var err error
 
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListAssignmentsParams
 
@@ -56,6 +109,62 @@ func (siw *ServerInterfaceWrapper) ListAssignments(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "vehicleId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "vehicleId", c.Request.URL.Query(), &params.VehicleId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter vehicleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "routeId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "routeId", c.Request.URL.Query(), &params.RouteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter routeId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startsFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startsFrom", c.Request.URL.Query(), &params.StartsFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startsFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startsTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startsTo", c.Request.URL.Query(), &params.StartsTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startsTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -69,6 +178,34 @@ func (siw *ServerInterfaceWrapper) ListAssignments(c *gin.Context) {
 // CreateAssignment operation middleware
 func (siw *ServerInterfaceWrapper) CreateAssignment(c *gin.Context) {
 
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateAssignmentParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -76,7 +213,80 @@ func (siw *ServerInterfaceWrapper) CreateAssignment(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateAssignment(c)
+	siw.Handler.CreateAssignment(c, params)
+}
+
+// StreamAssignments operation middleware
+func (siw *ServerInterfaceWrapper) StreamAssignments(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamAssignmentsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "vehicleId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "vehicleId", c.Request.URL.Query(), &params.VehicleId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter vehicleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "routeId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "routeId", c.Request.URL.Query(), &params.RouteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter routeId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "lastEventId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastEventId", c.Request.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter lastEventId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Last-Event-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StreamAssignments(c, params)
 }
 
 // GetAssignment operation middleware
@@ -93,6 +303,544 @@ func (siw *ServerInterfaceWrapper) GetAssignment(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAssignmentParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAssignment(c, id, params)
+}
+
+// UpdateAssignment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAssignment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateAssignmentParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateAssignment(c, id, params)
+}
+
+// GetAssignmentHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetAssignmentHistory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAssignmentHistory(c, id)
+}
+
+// TransitionAssignment operation middleware
+func (siw *ServerInterfaceWrapper) TransitionAssignment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransitionAssignmentParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TransitionAssignment(c, id, params)
+}
+
+// BatchImportAssignments operation middleware
+func (siw *ServerInterfaceWrapper) BatchImportAssignments(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchImportAssignmentsParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", c.Request.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.BatchImportAssignments(c, params)
+}
+
+// ExportAssignments operation middleware
+func (siw *ServerInterfaceWrapper) ExportAssignments(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportAssignmentsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "vehicleId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "vehicleId", c.Request.URL.Query(), &params.VehicleId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter vehicleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "routeId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "routeId", c.Request.URL.Query(), &params.RouteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter routeId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startsFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startsFrom", c.Request.URL.Query(), &params.StartsFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startsFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startsTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startsTo", c.Request.URL.Query(), &params.StartsTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startsTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportAssignments(c, params)
+}
+
+// ListRecurringAssignments operation middleware
+func (siw *ServerInterfaceWrapper) ListRecurringAssignments(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRecurringAssignments(c)
+}
+
+// CreateRecurringAssignment operation middleware
+func (siw *ServerInterfaceWrapper) CreateRecurringAssignment(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateRecurringAssignment(c)
+}
+
+// DeleteRecurringAssignment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRecurringAssignment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRecurringAssignment(c, id)
+}
+
+// GetRecurringAssignment operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurringAssignment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:read"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRecurringAssignment(c, id)
+}
+
+// UpdateRecurringAssignment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRecurringAssignment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"assignments:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateRecurringAssignment(c, id)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"webhooks:read"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhooks(c)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"webhooks:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateWebhook(c)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"webhooks:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWebhook(c, id)
+}
+
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"webhooks:read"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhook(c, id)
+}
+
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"webhooks:write"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateWebhook(c, id)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"webhooks:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -100,7 +848,7 @@ func (siw *ServerInterfaceWrapper) GetAssignment(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAssignment(c, id)
+	siw.Handler.ListWebhookDeliveries(c, id, params)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -132,25 +880,138 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/assignments", wrapper.ListAssignments)
 	router.POST(options.BaseURL+"/assignments", wrapper.CreateAssignment)
+	router.GET(options.BaseURL+"/assignments/stream", wrapper.StreamAssignments)
 	router.GET(options.BaseURL+"/assignments/:id", wrapper.GetAssignment)
+	router.PUT(options.BaseURL+"/assignments/:id", wrapper.UpdateAssignment)
+	router.GET(options.BaseURL+"/assignments/:id/history", wrapper.GetAssignmentHistory)
+	router.POST(options.BaseURL+"/assignments/:id/transitions", wrapper.TransitionAssignment)
+	router.POST(options.BaseURL+"/assignments:batchImport", wrapper.BatchImportAssignments)
+	router.GET(options.BaseURL+"/assignments:export", wrapper.ExportAssignments)
+	router.GET(options.BaseURL+"/recurring-assignments", wrapper.ListRecurringAssignments)
+	router.POST(options.BaseURL+"/recurring-assignments", wrapper.CreateRecurringAssignment)
+	router.DELETE(options.BaseURL+"/recurring-assignments/:id", wrapper.DeleteRecurringAssignment)
+	router.GET(options.BaseURL+"/recurring-assignments/:id", wrapper.GetRecurringAssignment)
+	router.PUT(options.BaseURL+"/recurring-assignments/:id", wrapper.UpdateRecurringAssignment)
+	router.GET(options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	router.DELETE(options.BaseURL+"/webhooks/:id", wrapper.DeleteWebhook)
+	router.GET(options.BaseURL+"/webhooks/:id", wrapper.GetWebhook)
+	router.PUT(options.BaseURL+"/webhooks/:id", wrapper.UpdateWebhook)
+	router.GET(options.BaseURL+"/webhooks/:id/deliveries", wrapper.ListWebhookDeliveries)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xWTW/bRhD9K4tpjwwpNznxphRtYMBNAwM9pTqsuSNpAu6HZ4cKBIH/vdilPkibjeFY",
-	"Ny41H2/fvDfiARpvg3foJEJ9AMYYvIuYDx+1ucfHDqOkU+OdoMuPOoSWGi3kXfUtepfexWaLVqenwD4g",
-	"Cw1FDIqmNj/KPiDUEIXJbaAvAJk9z/zSF6c3/uEbNgJ9emUwNkwhdYUabt1Ot2QUHxH2BXz28qfvnHkD",
-	"2rcgusfoO25QOS9qnYGkoKFVrr6MkTbOHnFNO1sUbbRkTL8yrqGGX6rLcKpjmeoPJyT7v07RfQHsO8Fb",
-	"M8twFM0Sl7nf2rPVAjUYLfhOyCIUsxnSDVy4zkL9FQI6k34sQDdCu5SVcLUoaGA1U2KHW2raeUgJLz52",
-	"xGhS7Uvo5R4j1Gc4q2f8F/CEiWeMNoxa0Lzm8jTPYhfM6yr1M3A/4/cfzf+6U7zeCFazyie39oO7xw5Y",
-	"frlVa88qM09uo7RLBhUm3KUjk0GlzyTEUv3tGlShe2gpbtEUak3YmpjzIlrthJqobBdFMVpNTkXRDy2W",
-	"/7p0a5I2AbtPZS/cquWXW0gUcBxg3ZSLcpFY8QGdDgQ1vC8X5XsoIGjZZvqrEax03mBmO00ob47EJNxR",
-	"lOUoLuWztijIEeqvB6DU7rFD3kMBTtuB9qzgYrRzftJZ/aqYLujfFotX7ToStPGlBTMS6UXGmlnv55Ze",
-	"okT59Xiqw9brrNW8P0Xotp2GFBB8nKH492zaEYZBrBjlozf7V932R5ecmrGfekK4w/4Z1TdXa/608xMT",
-	"XYR83GBQwBa1ySI7wJ0fmj633z/3d2kUssVT5ojziQDPS6RjmtleCdSHQVtz9zgTU42+EHLKh5dTzn/S",
-	"U5UMc1daOfw+Rp2ixt6sDmT6/zXoJ5SJdObsmSx/cScZeDr6MVHXduBVZHH8tngb4Z9QlFaR3KbFKeEp",
-	"DHl3Iq3jFmrYioRYV5UOVDYk+3fC2sXgWcrG22p3A/2q/y8AAP//TaQO0k4KAAA=",
+	"H4sIAAAAAAAC/+x9a3MbN9LuX+mac6pWqhpR9EWpRKp8kG35jXZ9O5K8ztbadQTONElEMwADYEQxKf33",
+	"t7oBzIUcSpRjK3biL7ZEzgCNRl8e9AX6Pcl0OdMKlbPJ/u/JFEWOhn88OhMT+j9Hmxk5c1KrZD/5Nxor",
+	"tQI9BjdFENbKiSpRuQOwqHKQDkYiuwCp4Hi881K4bAra0M+vtEL/wSBJE5tNsRQ0vlvMMNlPrDNSTZLr",
+	"6+s0mQkjSnSBkOMxv7VKy2tVLEDMZsWCacmmQk0Q5DJlYJ0sCpgKC24qLdDCiARJY/gFJ2miRElkRKJv",
+	"I9GgnWllkSl8IvIT/LVC6+i3TCuHin8k4mQmiN7dXywR/Xtr2JnRMzRO+kFydEIWtme+NEFjtOmjJI2f",
+	"6NEvmDlPW5dLx+pSFDIHEyi8TpOnWo0Lmd2N2v9rcJzsJ/9nt5GYXf+t3T1i+nomD2yBLMxoYS7d1G9X",
+	"ZYzfHeEwypNBqyuTIVH5XJuRzHNUn5/MsymC0xeooBDZhWVSbKZnNV20UTxjStI8wkKriQWnQSjtpmjA",
+	"oRKKmftKu+e6UvkfEIU/st0ngYOgtIMxE3KdJm/EotAiP9P6hTATvB+OBomDkc4XIC0UNLUBNxWqy1QQ",
+	"WYYzZ5lSg5lWuaTPnwtZYP75iT2sSZ21ZoctHEwGtRnbhlzmzNWpLpipp2guZYZvlbgUshCjAu+D1Bxn",
+	"qHJUGfPUYTnTRhhZLKBqCCEpZSH2JNKT+hINyQDmB2DQmQUUwrHpaxn9E/pi53BMX6zY21PmDYv9XJCh",
+	"x7E26AeTapLCfIpq/bS9Zl8qhxPkpV6nyZnWL4VaBKth70dMs0KicoBXGWKOubdDwiEUspRuxQJ8BMMq",
+	"5WTBoyi8crWwlWIBIwzCvxF/3ipRuak28jfM74c5IxQGTTCO0kIpreW9LkUx1qbEPAW8mkmDOckcqYcz",
+	"lXWYd/n07t27ncPKTVE5ohJXmUWzPfGzZVNRFKgmmIISpVQT5h1bxVuwA/NoZnSG1pIeHCkn3eJ+WHWc",
+	"ky460sydf+EC5sKCKAyKfAGVxdy7PgG5HI+RXV/tkq/jophXhzV06XENKreH/DlxX7hkP8mFwx0nS0zS",
+	"VeRQohO5cLevjBn1Mj5NCEdXDo/zXjxinTDuTnSQl6/CCqoy2f9vQlaMvkwTkTl5SW8RXQV62cmEyrAg",
+	"8/+hZ7hLnMqswMPK6VMsMHNeH5Y2xVTY2KTwSm2bsqm29afHOYwwE5XFZfBIu5gZFC5soK4caIWDZpEj",
+	"rQsUqkVWL9eIpfhrRZpCy28ebVjdYmwad7pm3YcV558mh1Uu3ZFyZrEqKSLzbGg4HpaRpEk1y8NPfvD/",
+	"77FzP69F5nrRSJqIaPZukqyWNNMrd5AZ71/uNr7MO+NL5b57nKQr1tTvBVp33CM3P+8EB7Rz/KwBpvwJ",
+	"gRcy3Dm2jhwHIEYWFcEtw6efiSHcBb/okR2sLmxJEGQeVIBdi2c28ynyt2/jl9R1ZfPDVt9FQ2W/qgdR",
+	"2Xyk6z5yI6D9JEeeNvf8Y30sOiZg5E6Q/l2delxDy+7ev6rKERradaPn1m+3f3bQK0aSZ7l5pMaa1Kak",
+	"f7BS59hWWOF0KbOED8ROiqJXPYlOekk6LO1tyhKYoucnaKuC+RQGFMaIxQp7maLWKtPIuDDvDYyv51h/",
+	"vOky7N3Un+ONnq+yfFlOl864tZ5GU93wvHcQo+ero7zR1gP/qPN6DqLUAX6QotFHNoWx0SU8OAABT0//",
+	"DR7lgLSMfjJdqbUb3HjB7sz2Qs5mmHuZm6NB8Gf2UeX8oGFVHR/FwgF+a1oMW7X29ZaFaXrEaGnbiT03",
+	"Op5XON8MpXTXeaTyyNy5VLmewxZeZUVl5SVuH0COY1EVjk8YWiFMdWWAjSBEx0gr3MyefVr80vHsyyEx",
+	"/oqI9lJ34EGHLqVz4TyxDD5mksIMtEatgqxVDr25kRbGBpG9ScOp2x1JD4pYs3UnSMEXqSY37WFe+fPO",
+	"S6kq5z8qxZUsSboePH48TJNSqvBrn6yTDLxWq+x6IayDQmeiIJVCEAp05oNBGfKZiMkHrVb2um9j6NjG",
+	"Q/eo1Yt6FuI1zKcy87EnUxUIuUavsqbiqWoDeuucXZN5s6gZmmuVtJPnT2Fv7/EenJy8fXFE6l5hjS+f",
+	"nZ2eHZ6cpcABiOcnR//vx3dHR/968Z+DJ/95dvifH1++Ts/epu+O0rOf0ucnRHsprl6gmrhpsr83HKY3",
+	"S3yXkufSWNfaggOQzoYNIm0AqeCcfvhNKzznmMMUwx7x93oMeIlm0Rpjcy2NA/fY9MNXh34C+r7ZNmnp",
+	"wCkUHdWlCjw6qkh2d5+gKaQa3KrAHwPN/Ua2KO6g9WVtWaN573A01fqiz2LScTVYF7aCyb4zFfadMvAS",
+	"lTtbzLBH5I/oO6B32IrmWMhLNAcgiiJY3tJbJyxnbgHaBOzaUYDoQhrz8LR2Js1np+went5wcljWE4uZ",
+	"wR4RPJ0Kgzn4r2u7F2hfAE0oXGXQDuCY7aMigQODrjIK8yUFeLi3x7Yp/v7gu2XS0mRupEPKH3guE841",
+	"RUf3KyOXhh0+/v42E0yD1MvsE4GPtbw3mdc7msiPN3OfJ5ZQG8hP4KXb1uTPtgAdvvfJgtefMyOUh52r",
+	"gmBQhPDU6kp156Bw1wjK0nqd7qVwrbXKtLKYVTQpxegr4z9eldJcchguP6lX0o/5534mjrbEd0BUTpeC",
+	"4oVFseg16i2reZuZrMX+M4p5sCGb2IgWcc0y0l7G3rAzz4KJXN0h4RxZ+DXb8hHhgWCN7/YSr/J405BM",
+	"zZPebdo4slMI647WRA/8t8FzhdN2VyJ/Ojt7A/7kE48q9AoEhtaxHjkGpSGmgVlwDWYoL9ed/Sj0f+gH",
+	"6YNh78gpCwhR0cb5SRunxpzTB5tjq5tirrbKfLqjOR9+2ChSFbe0vV31VGkjd20hW5VgDwYqI93ilNTJ",
+	"C61PNlCaoPnteVzqP9+dJSvZMJ86tDYkKMin0jE5FARoNZaTyhBQtLZCkwZsIS0cvjn+hwVR5ZLxKpxm",
+	"eoYWhEGYGKGI2TLksmYiwx2LM2FoOXDOieFzyAohy/12cGffoMjTzieMM9Jo3vwTIFTefMJPDOCMU0y0",
+	"LFHAuc8mhzlgJpUH3X6R4XDsnxm8VzEjwuaPedYIw9S5mc9XSDXWq1J3+OaYecJ7RXJHtBl0RuIl/Wpk",
+	"3o6G2wG8VhnCrBoV0k4p+TOWWOSW37NYCuVkZqGsrAODpZCKdGlUIL2IkOOs0AtflIHmEi1YQnKigEw6",
+	"iXY/nCRitFVkztZ40C+YMkLN5vy8c8afUqTWx2FSqOEjw+j4GoNeyegxIF54JpwgHW9n8G0DLy+llaOC",
+	"orsKKnWh9FzFwaQFg79w2sFndR4PhwM48qegOqutEIkzMYf2z3dnXrZiQosFiWgqpHUWKpWjgagWcbg6",
+	"8zaTimTb6V5mSGe9tPitaDFO1CzQqlgcdFhGp22DMxSOC3cqVyPrWSEytCBd6scLdMQTqohjegnt4ceD",
+	"ARyJbBqyqynIHJWTY+nVU7paZSs2CbRrWFjkb47fgMhzg9amPHSdjMUcZmhaLCbaJugsPH74g5+5lZCt",
+	"93yi0SeieS+bjDRIZZ0ggSZZtlHsQpHK3vDRAE6aGgYZBlm2LVb+Fshj87HEiAePvIo66Qqkgz9pVIP+",
+	"yRAlhE25rCrZTx4MhoMhWW89QyVmMtlPHg2Gg0ccBXZTtpO7LY2k3yd9h6oTPh35INNMTHA5Eq1Njsbv",
+	"RkSxsEVKCCPDO0PblG8PgD1T6TP9lsNzeCU5FYKN/8uEMfQu8Xcmfq24vsdq0yjqK7xyO0/9hyFkuuVF",
+	"64VUF/EjZprB4sf37DDfJ9sHMBPW1oVlwsK5H/q8qSWyokQYy8KhCaZIG0eqMkYXQj5jXRR6TqpHzPB7",
+	"UssRQZTkhbTusMXYbh3af3/3BWO/VmgWTb1Y7fiaBPInSHBep/2ztc8l67Pga15uxQdverWvxq4lNiwr",
+	"7ClYZ310ll2qVyZXl9atcso4+9zoskPBZimlzckKRSmbU3SmPwE9pyRvbU0SNgtIbis4om1iV47N5zvx",
+	"4e21BGrjekWrdezc6Qn2rqfzpY/ggqqTU3wuilrKqrGGGLZwHWrqQNXeMG1iw3vDW0LDPbvZsRicXREw",
+	"M3gpdWWZqH/YXguyjlY/0o2S/mGphvPhcHinMpGN0m3d3PRSpq2ntqvHTnfLaMhU9geTv3/4/fdQkCUN",
+	"CIFLjXjArY49vbmCJk06fF6d62nYpICy6lnqg5FWzaEpytPNFTuPh8N1jKy3aLdVY8uvPLj9lU61FL/0",
+	"6PaXmqpTeuPhD7e/sVy0dp0me5vM1FM+2D4Xsctpn4j+myyfNZIPJMa2KkthFsGDdWSHaqm17Yu4Inle",
+	"tVKzFLyw01CKCwwnAQtWjHEAh6FusOt2L3DBLperPBk4LvxpRRs5kXSciUuGrVKYi4iM6qndzgm/hfk+",
+	"OFNRItBgZdnH8OgrdVOxoHQJaj18OIB/4cKGgrTaN3XQ2tnZC1pJTMhlBFjImfB5hMFSw0DycYJzEPsg",
+	"YsaSETVjSDGzdf1vPLeTS2Rv3x5Gjxt2xYl7IPMPnXK3rBB2Sr90jl8vUBCZlWtVLjkNBbq1eUYQ9ce3",
+	"Jxubyf5hw4oPukiPTKzfYK6gUnHwABSVbi+xFu1OEU3/2vlYPJXh2eWVSDogGRTZNNa3UtiC+CN7BmTs",
+	"/m6KphnIIA3F5tyf80PULe1MRzQXVoenm4PmMjk1zOhstLRgnTaY+9UYLFBYzEFM6CQsx/wt0Uy027Yc",
+	"9s0Riwep3tif5uLpRcxmNEqQyD529gFcn8Rp+aQVhLtk6vnothO2uVKSnDQpZB1FiRu6VYoreLi3RwVR",
+	"RmQ03vb6Fouuzem4h+U0Tq/X5jmf6PzT1XV2yxmuu4GvkCBaQgsPPtnkyzMvgYL6W2gqOnradPpmCI/t",
+	"8jPXKefDY6ahO8vbkxfrK2j68bFPkH1l/nz4+PY36gYOfmEDAFC30zBieLjJQlZLlL9stMFRymW44e0J",
+	"CFA4b4sLjdsOUexaZ1CUayMVbyo7RRtCf178UvB1h/4w76Pxob9rpfPMQkn9GdFv1mEAC7RmNDunqBxw",
+	"YtyGoBSHrjnSx/GzFlDwc2wFJYhkcG17t1R2O23HPJpCXJqXS8WIchoS+VNJCsXBLZlT4VimS9bpQip2",
+	"OgydhQVBEMy4EYoQsiR8QUvj4NhUzGao2HGErgV24ty0orBur6ISmx1eMAX5trRhNH7kI/fbPmDWrJbI",
+	"4rp+dlsWqLcJuFmOkJ3B0D83qhh+5QN47aZo5jLUofnNDSffiNXeJwYtuvdJYHVkRqDaTnVVsH/UIl/e",
+	"Th8MZ/drICu0RdueiI5HndWPRVFYcFrDWBgY4VSqnIpYGNvVrGkQ2Fws6rheIPh98nY2MSLHfQrMW51d",
+	"MO0TbL3GC2GxeoejU34EHJ1+SlJkYmOQTLKQ8Pv7RObvkxTe+zyJ/5EE431y3eecT3l1N8afekIgcQeX",
+	"YnsMWqQNIhvBSitcE/c+BasDMwPwsdjVrYIBp+c/DRZ2hzjS4JIGizLs8YEmv8q/Y9DslLgjbFcPffIp",
+	"8polNxNKaQcWXTh42bXBl0Z/+71xTIfeLfBynHdynF5XW3razmj2YrnOCv9gvIWUaZdJ2Gkcxo1NuMvh",
+	"k8ZCBL1I/oLRhT8WIvBmpn1mqVm17LV/l/l1y2d3zdX/oLvpIMHCQvmKRlQ4e9xF1XdSKgKxK7HByKcD",
+	"eDR87A9CviYsnCRl7P5miID2pr7vpkH9swYOP8lRwDf2fuRBgEZ+5JHwahdbSzKmIpS0e8QDVqrMu4KJ",
+	"vETFjfR/hIYvFvR/XVG//0EHgjZnUmAHhqfJrOpNDIbkbu0Y0+iy01YCQ+Xgy/gH4OtlSL2iKHDI7FIK",
+	"2HV1/VqABnQYCIEJLgMgCQpBizpathobC7TA1uPhD9sD4Pgkfc5aXz/j755Y6Aowl/6QWvdn+zBVODas",
+	"hkQePOT4iy5RK/RoOi5mOYjjIz4lCuVkiXeLI7W+hVJfYo2IiS1xCG3q0Jr4fBEgeBrBEm9mDaM68C6E",
+	"qGh2adrU92HUt8zcz273+xSomWc33gfyBQWD/gQP0LRw/gH7+zeO0zzYIE7TcxfFVximeRsjKTfGaAjt",
+	"7U6lddosWqhvpbvgo0I0KegiR+tgLI11aZNB4nZX2Pp555B+2Dl+tp12MgUUvmi3427XkYRm8Hi8pW+W",
+	"z7cDoKCMDJV9xB6Hqq7so2Ma+y/ffhuCHnEVNJ4vhyp1zpVLfTaxA4R/Cvz7DHbxfvLVTT/5Jvlqeprj",
+	"WxJtd4uTb/DuU8I7FnjmtjNCcifPJurcwmZEeX8q+JDEBn2dmHU4qyMCcozZgtBIzG3uvK+Gw0cYE5zx",
+	"1xpYcOmlj9DYVilzX0p0AE8bOKLy8FaxBE5IZ8eUQvbIkvoA0CxVEvrismg+Ws24ZLtNHgFR2yScTdsI",
+	"y2oQgQVuihahxTWQzmIxZsMgS+RKNLSw5c3We1avvCrQvE+29+sFt5cwwkyXNcuEqwF2qKs0Lf6BNmm4",
+	"vmwRLA8/TZxtMajOZvu8KtHlzV6A6z1Gqmky+auDt5Wmmi8Kv5223eQ37PYNu92G3V7qyyXk1r50LsTR",
+	"l43//ohUzF/DsN7u++ydXS55maHhiw/0OFxvoA28evbP09evuOwmXar+yaZInez1JTmHLcznSyCIilAQ",
+	"VLlMtxuGjZ4PeI5YKR+sN83froTRRVWqDeIVsYGiWARbCFtUGvfo0aMfYi2o3T4I9f5h1BRslU099NMW",
+	"g2vFKyI7ZfssJ0pz3iuwYSo80zrnRPCdLcy+Qio8gFEh1AX/bLvDHKt4d0Opc4StVrPCNqBk4mr+gIyc",
+	"9e6Bc4JCLepbMmwKSvscYoTGUSLpMy6KOlYQbhDxU7ppvGCCL5xgJxTmiGMwj2zYPqL60EGprYMHw+Fw",
+	"2LwXLy/zcuM1Jq1HIYlhfol2lTxXnVrQc5XCg+/gpXxCjjywoM99PWnk+c4F2uEGk56q1eZ6lTvct3Kd",
+	"/pWqZK52VL7q31aW7FMzmb28NSNzf462c71Pj6s9qjWIWvNCvQXdfWgxFqP5i1NCJVm4feULzxjd3VVu",
+	"MMXy5aBNGcvGO6UVvh6zDm6+Z+lGd+196Dt4qqXbb6Yir8sBvWlSeW3SQgVF3Rj20ff0kWn6CjGE5/py",
+	"fcCoKi5WkYP3e2vjPz57GEt1mhd7K3DSusWokBfITW4t682lJuT7twK7G9+/3cIcW1p12qWif90etD5t",
+	"8hJN/ahfCZhKWW5z04b/U9rBCEGqrKjy/ojO0dVHOZqQiu93NWQ7Gz/jfwumd/NChm+9Rn/TXqPP0ht0",
+	"t4DiJ0cKq5nn2oYsdTB8aw/5RJFEb9i67CUHYOKlNDv9faWrbZI919jY5D4C1D0TbxSpLgqoV9lmQNpu",
+	"g5X5N9m5sbWol4Xrm4ye0AUH3e4TEXpPhFu5MyycvLsXow3gdf1AyLY3d3+1ryUjwHeBOPOha768rHtF",
+	"2Xm0hecgMqOthWenZ7H4iQpquxe3kjVCI0Uhf/OB4RIE90xLJUzXHXBaHowuGH6yUGh10FpZqPabc83r",
+	"cinESOsLem+pDoKWGm+FbK4P554gH9y2urjsxy8+sNOnKZ8tX96rlvfbRbGWhOXil1UR7m+suFOzRJ9m",
+	"/IXaJv6OTQ29W7reX9blkjkW2He//anTM9tcY9hkvXxbQJ0tWzYbU6q/5jJhnwuCBfYGyZ7xvP1qfw/Z",
+	"6Md9hWY9uubZk//Fmn6+Lhn3orJWxtO1Jb9/mnQNvwg/MW4k5FtpwyerXF0nhL3Fq296zGTwxU1ReFXg",
+	"etvp0x2d0kgXylbj/bIt7BcbgKUBrprtAlKehMaL8cOQ56eYslR8QxZ/fYEzt76g8j616suCf1+GWsdS",
+	"yr996+rX5cVCHfuNUC1es3djNONdfOg+Ihhhsk2jFnEB3wIVQSw6Vyn2RiniE+sDE618QuzqNAhvXp+e",
+	"+ZYCzjw4DeeVKc5Dy3BM4sam3593wk769re09UG8lRW2QjZ4hqa+0NO3Aea4k1e1LG233z6TJVonyhls",
+	"vVXyCqz/I2K+8rV57DReir1PdWhT8XDvux/fJ7U7m+IV/PTy8OnO6U+HD/e+SykjHVNb5/6C6vM0ek4X",
+	"p0y5dXfQGseIOWfxB3CoFk1lQbgycSoUPLy6Co1XRsYZ8MpvNVUcUFxFj8fU6hB2xrtNitbY5hKP1r2/",
+	"Pt4Rr/XYCf0VfuQ3b8/Wxzyibn02R1cr7/3GNjrTLl0b678CgxNpHZqPimI0L8ct+ha7+JJN3xp36Dex",
+	"0bOuC7w1QHGCvmGpfR+30xNkVWflk87WdgwKPRms6KE/WTZ6+OcEHqJSfA2xhq9JxOq4wbwBMetCBfcq",
+	"A8P7NLXfogCfELH5EEBLnm5uWSXb9PbkRRquKvCVJuHy6cygG8B5gAvnnb8mxSIFVoebjf3dcjXgmNdO",
+	"NIANvhJFcPmqRWfZ8I39ffz+D3ytP8x/brn/83HNvSrbt7O5+wqRSDyYrwciuwFHhD9XcdvZ/Fnz9Odp",
+	"W/noeqsN/5DC7RfxNgwhg+XvsagvD4a94fbmF/Pe4S7eD/cY84jn4k1iH82GpxSaXWos/OsYgq/MVXf+",
+	"LlehJ75NZN624/7iLq+a/Adx+I9Q2P3dXTGTg0y6xQ532M20cYNMl7uXD6jK938HAD+YoEOKhAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -5,34 +5,149 @@ package api
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
 // Defines values for AssignmentStatus.
 const (
 	AssignmentStatusActive    AssignmentStatus = "active"
+	AssignmentStatusCancelled AssignmentStatus = "cancelled"
 	AssignmentStatusCompleted AssignmentStatus = "completed"
 	AssignmentStatusPending   AssignmentStatus = "pending"
 )
 
+// Defines values for AuditEntryAction.
+const (
+	AuditEntryActionCreated       AuditEntryAction = "created"
+	AuditEntryActionStatusChanged AuditEntryAction = "status_changed"
+	AuditEntryActionUpdated       AuditEntryAction = "updated"
+)
+
+// Defines values for ImportReportMode.
+const (
+	ImportReportModeAtomic  ImportReportMode = "atomic"
+	ImportReportModePartial ImportReportMode = "partial"
+)
+
+// Defines values for ImportRowResultStatus.
+const (
+	ImportRowResultStatusCreated ImportRowResultStatus = "created"
+	ImportRowResultStatusFailed  ImportRowResultStatus = "failed"
+	ImportRowResultStatusSkipped ImportRowResultStatus = "skipped"
+)
+
+// Defines values for NewWebhookEventTypes.
+const (
+	NewWebhookEventTypesAssignmentCreated       NewWebhookEventTypes = "AssignmentCreated"
+	NewWebhookEventTypesAssignmentStatusChanged NewWebhookEventTypes = "AssignmentStatusChanged"
+)
+
+// Defines values for StatusTransitionTo.
+const (
+	StatusTransitionToActive    StatusTransitionTo = "active"
+	StatusTransitionToCancelled StatusTransitionTo = "cancelled"
+	StatusTransitionToCompleted StatusTransitionTo = "completed"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
 // Defines values for ListAssignmentsParamsStatus.
 const (
 	ListAssignmentsParamsStatusActive    ListAssignmentsParamsStatus = "active"
+	ListAssignmentsParamsStatusCancelled ListAssignmentsParamsStatus = "cancelled"
 	ListAssignmentsParamsStatusCompleted ListAssignmentsParamsStatus = "completed"
 	ListAssignmentsParamsStatusPending   ListAssignmentsParamsStatus = "pending"
 )
 
+// Defines values for ListAssignmentsParamsSort.
+const (
+	ListAssignmentsParamsSortMinusStartsAt ListAssignmentsParamsSort = "-startsAt"
+	ListAssignmentsParamsSortStartsAt      ListAssignmentsParamsSort = "startsAt"
+)
+
+// Defines values for StreamAssignmentsParamsStatus.
+const (
+	StreamAssignmentsParamsStatusActive    StreamAssignmentsParamsStatus = "active"
+	StreamAssignmentsParamsStatusCancelled StreamAssignmentsParamsStatus = "cancelled"
+	StreamAssignmentsParamsStatusCompleted StreamAssignmentsParamsStatus = "completed"
+	StreamAssignmentsParamsStatusPending   StreamAssignmentsParamsStatus = "pending"
+)
+
+// Defines values for BatchImportAssignmentsParamsMode.
+const (
+	BatchImportAssignmentsParamsModeAtomic  BatchImportAssignmentsParamsMode = "atomic"
+	BatchImportAssignmentsParamsModePartial BatchImportAssignmentsParamsMode = "partial"
+)
+
+// Defines values for ExportAssignmentsParamsFormat.
+const (
+	ExportAssignmentsParamsFormatCsv    ExportAssignmentsParamsFormat = "csv"
+	ExportAssignmentsParamsFormatNdjson ExportAssignmentsParamsFormat = "ndjson"
+)
+
+// Defines values for ExportAssignmentsParamsStatus.
+const (
+	ExportAssignmentsParamsStatusActive    ExportAssignmentsParamsStatus = "active"
+	ExportAssignmentsParamsStatusCancelled ExportAssignmentsParamsStatus = "cancelled"
+	ExportAssignmentsParamsStatusCompleted ExportAssignmentsParamsStatus = "completed"
+	ExportAssignmentsParamsStatusPending   ExportAssignmentsParamsStatus = "pending"
+)
+
+// Defines values for ExportAssignmentsParamsSort.
+const (
+	ExportAssignmentsParamsSortMinusStartsAt ExportAssignmentsParamsSort = "-startsAt"
+	ExportAssignmentsParamsSortStartsAt      ExportAssignmentsParamsSort = "startsAt"
+)
+
+// Defines values for ListWebhookDeliveriesParamsStatus.
+const (
+	ListWebhookDeliveriesParamsStatusFailed    ListWebhookDeliveriesParamsStatus = "failed"
+	ListWebhookDeliveriesParamsStatusPending   ListWebhookDeliveriesParamsStatus = "pending"
+	ListWebhookDeliveriesParamsStatusSucceeded ListWebhookDeliveriesParamsStatus = "succeeded"
+)
+
 // Assignment defines model for Assignment.
 type Assignment struct {
-	Metadata  *EntityMetadata  `json:"metadata,omitempty"`
-	RouteId   string           `json:"routeId"`
-	StartsAt  time.Time        `json:"startsAt"`
-	Status    AssignmentStatus `json:"status"`
-	VehicleId string           `json:"vehicleId"`
+	EndsAt   time.Time        `json:"endsAt"`
+	Metadata *EntityMetadata  `json:"metadata,omitempty"`
+	RouteId  string           `json:"routeId"`
+	StartsAt time.Time        `json:"startsAt"`
+	Status   AssignmentStatus `json:"status"`
+
+	// VehicleAutoSelected True when the vehicle service chose vehicleId because the assignment was created without one.
+	VehicleAutoSelected *bool  `json:"vehicleAutoSelected,omitempty"`
+	VehicleId           string `json:"vehicleId"`
 }
 
 // AssignmentStatus defines model for Assignment.Status.
 type AssignmentStatus string
 
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action AuditEntryAction `json:"action"`
+	Actor  string           `json:"actor"`
+	After  Assignment       `json:"after"`
+	At     time.Time        `json:"at"`
+	Before *Assignment      `json:"before,omitempty"`
+	Id     int64            `json:"id"`
+
+	// RequestId X-Request-ID of the request that made the change; absent for background jobs.
+	RequestId *string `json:"requestId,omitempty"`
+}
+
+// AuditEntryAction defines model for AuditEntry.Action.
+type AuditEntryAction string
+
 // EntityMetadata defines model for EntityMetadata.
 type EntityMetadata struct {
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
@@ -40,31 +155,317 @@ type EntityMetadata struct {
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+// Error defines model for Error.
+type Error struct {
+	Details *string `json:"details,omitempty"`
+	Error   string  `json:"error"`
+}
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	// Failed Number of rows that failed.
+	Failed int `json:"failed"`
+
+	// Imported Number of assignments created.
+	Imported int               `json:"imported"`
+	Mode     ImportReportMode  `json:"mode"`
+	Rows     []ImportRowResult `json:"rows"`
+}
+
+// ImportReportMode defines model for ImportReport.Mode.
+type ImportReportMode string
+
+// ImportRowResult defines model for ImportRowResult.
+type ImportRowResult struct {
+	// Error Why the row failed.
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of the created assignment.
+	Id *string `json:"id,omitempty"`
+
+	// Row Position of the row among the data rows, from 1; a CSV header is not counted.
+	Row int `json:"row"`
+
+	// Status skipped rows were valid but not created because the atomic import failed.
+	Status ImportRowResultStatus `json:"status"`
+}
+
+// ImportRowResultStatus skipped rows were valid but not created because the atomic import failed.
+type ImportRowResultStatus string
+
 // NewAssignment defines model for NewAssignment.
 type NewAssignment struct {
-	RouteId   string    `json:"routeId"`
-	StartsAt  time.Time `json:"startsAt"`
-	VehicleId string    `json:"vehicleId"`
+	// EndsAt End of the window (exclusive); defaults to one hour after startsAt.
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
+	RouteId  string     `json:"routeId"`
+	StartsAt time.Time  `json:"startsAt"`
+
+	// VehicleId Vehicle to assign; when omitted the vehicle service picks one on the route that is free for the window.
+	VehicleId *string `json:"vehicleId,omitempty"`
 }
 
+// NewRecurringAssignment defines model for NewRecurringAssignment.
+type NewRecurringAssignment struct {
+	DurationMinutes int `json:"durationMinutes"`
+
+	// EndsOn Last local date an occurrence may start on.
+	EndsOn *openapi_types.Date `json:"endsOn,omitempty"`
+
+	// Exceptions Local dates on which the rule does not run.
+	Exceptions *[]openapi_types.Date `json:"exceptions,omitempty"`
+	RouteId    string                `json:"routeId"`
+
+	// Rrule RFC 5545 RRULE value without DTSTART, e.g. FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR.
+	Rrule string `json:"rrule"`
+
+	// StartsAt First occurrence; its local time in `timezone` is the start time of every occurrence.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Timezone IANA time zone the rule is expanded in, e.g. Europe/Berlin.
+	Timezone  string `json:"timezone"`
+	VehicleId string `json:"vehicleId"`
+}
+
+// NewWebhook defines model for NewWebhook.
+type NewWebhook struct {
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EventTypes Event types to deliver; all of them when empty or absent.
+	EventTypes *[]NewWebhookEventTypes `json:"eventTypes,omitempty"`
+
+	// Secret Shared secret for the delivery signatures. It is never returned.
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// NewWebhookEventTypes defines model for NewWebhook.EventTypes.
+type NewWebhookEventTypes string
+
+// RecurringAssignment defines model for RecurringAssignment.
+type RecurringAssignment struct {
+	DurationMinutes int                  `json:"durationMinutes"`
+	EndsOn          *openapi_types.Date  `json:"endsOn,omitempty"`
+	Exceptions      []openapi_types.Date `json:"exceptions"`
+	Metadata        *EntityMetadata      `json:"metadata,omitempty"`
+	RouteId         string               `json:"routeId"`
+	Rrule           string               `json:"rrule"`
+	StartsAt        time.Time            `json:"startsAt"`
+	Timezone        string               `json:"timezone"`
+	VehicleId       string               `json:"vehicleId"`
+}
+
+// StatusTransition defines model for StatusTransition.
+type StatusTransition struct {
+	Reason *string            `json:"reason,omitempty"`
+	To     StatusTransitionTo `json:"to"`
+}
+
+// StatusTransitionTo defines model for StatusTransition.To.
+type StatusTransitionTo string
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+
+	// DisabledReason Why the webhook was disabled automatically.
+	DisabledReason *string         `json:"disabledReason,omitempty"`
+	Enabled        bool            `json:"enabled"`
+	EventTypes     []string        `json:"eventTypes"`
+	Metadata       *EntityMetadata `json:"metadata,omitempty"`
+	Url            string          `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+	EventId     int64      `json:"eventId"`
+	EventType   string     `json:"eventType"`
+	Id          int64      `json:"id"`
+	LastError   *string    `json:"lastError,omitempty"`
+
+	// LastStatusCode HTTP status of the last attempt; absent if no response was received.
+	LastStatusCode *int `json:"lastStatusCode,omitempty"`
+
+	// NextAttemptAt When a pending delivery is attempted next.
+	NextAttemptAt *time.Time            `json:"nextAttemptAt,omitempty"`
+	Status        WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest struct {
 	Details *string `json:"details,omitempty"`
 	Error   *string `json:"error,omitempty"`
 }
 
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // NotFound defines model for NotFound.
 type NotFound struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// PayloadTooLarge defines model for PayloadTooLarge.
+type PayloadTooLarge = Error
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = Error
+
+// ServiceUnavailable defines model for ServiceUnavailable.
+type ServiceUnavailable = Error
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = Error
+
 // ListAssignmentsParams defines parameters for ListAssignments.
 type ListAssignmentsParams struct {
-	Status *ListAssignmentsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Status    *ListAssignmentsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	VehicleId *string                      `form:"vehicleId,omitempty" json:"vehicleId,omitempty"`
+	RouteId   *string                      `form:"routeId,omitempty" json:"routeId,omitempty"`
+
+	// StartsFrom Only assignments starting at or after this instant.
+	StartsFrom *time.Time `form:"startsFrom,omitempty" json:"startsFrom,omitempty"`
+
+	// StartsTo Only assignments starting before this instant.
+	StartsTo *time.Time `form:"startsTo,omitempty" json:"startsTo,omitempty"`
+
+	// Sort Sort by startsAt ascending (default) or descending (-startsAt).
+	Sort *ListAssignmentsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Maximum number of items in the page.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's X-Next-Cursor header.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // ListAssignmentsParamsStatus defines parameters for ListAssignments.
 type ListAssignmentsParamsStatus string
 
+// ListAssignmentsParamsSort defines parameters for ListAssignments.
+type ListAssignmentsParamsSort string
+
+// CreateAssignmentParams defines parameters for CreateAssignment.
+type CreateAssignmentParams struct {
+	// IdempotencyKey Client-chosen unique key for this request (max 255 characters).
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamAssignmentsParams defines parameters for StreamAssignments.
+type StreamAssignmentsParams struct {
+	// Status Only changes of assignments in this status before or after the change, so clients also see assignments leave the status. The same holds for vehicleId and routeId.
+	Status    *StreamAssignmentsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	VehicleId *string                        `form:"vehicleId,omitempty" json:"vehicleId,omitempty"`
+	RouteId   *string                        `form:"routeId,omitempty" json:"routeId,omitempty"`
+
+	// LastEventId Same as Last-Event-ID, for clients that cannot set headers.
+	LastEventId *int64 `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+
+	// LastEventID Id of the last event the client received.
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// StreamAssignmentsParamsStatus defines parameters for StreamAssignments.
+type StreamAssignmentsParamsStatus string
+
+// GetAssignmentParams defines parameters for GetAssignment.
+type GetAssignmentParams struct {
+	// IfNoneMatch ETag from a previous response; 304 is returned while it still matches.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// UpdateAssignmentParams defines parameters for UpdateAssignment.
+type UpdateAssignmentParams struct {
+	// IfMatch Only apply the change if the assignment still has this ETag.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// TransitionAssignmentParams defines parameters for TransitionAssignment.
+type TransitionAssignmentParams struct {
+	// IfMatch Only apply the change if the assignment still has this ETag.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// BatchImportAssignmentsParams defines parameters for BatchImportAssignments.
+type BatchImportAssignmentsParams struct {
+	Mode *BatchImportAssignmentsParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// IdempotencyKey Client-chosen unique key for this request (max 255 characters).
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// BatchImportAssignmentsParamsMode defines parameters for BatchImportAssignments.
+type BatchImportAssignmentsParamsMode string
+
+// ExportAssignmentsParams defines parameters for ExportAssignments.
+type ExportAssignmentsParams struct {
+	Format    *ExportAssignmentsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	Status    *ExportAssignmentsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	VehicleId *string                        `form:"vehicleId,omitempty" json:"vehicleId,omitempty"`
+	RouteId   *string                        `form:"routeId,omitempty" json:"routeId,omitempty"`
+
+	// StartsFrom Only assignments starting at or after this instant.
+	StartsFrom *time.Time `form:"startsFrom,omitempty" json:"startsFrom,omitempty"`
+
+	// StartsTo Only assignments starting before this instant.
+	StartsTo *time.Time                   `form:"startsTo,omitempty" json:"startsTo,omitempty"`
+	Sort     *ExportAssignmentsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ExportAssignmentsParamsFormat defines parameters for ExportAssignments.
+type ExportAssignmentsParamsFormat string
+
+// ExportAssignmentsParamsStatus defines parameters for ExportAssignments.
+type ExportAssignmentsParamsStatus string
+
+// ExportAssignmentsParamsSort defines parameters for ExportAssignments.
+type ExportAssignmentsParamsSort string
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	Status *ListWebhookDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of deliveries to return (default 50).
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListWebhookDeliveriesParamsStatus defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParamsStatus string
+
 // CreateAssignmentJSONRequestBody defines body for CreateAssignment for application/json ContentType.
 type CreateAssignmentJSONRequestBody = NewAssignment
+
+// UpdateAssignmentJSONRequestBody defines body for UpdateAssignment for application/json ContentType.
+type UpdateAssignmentJSONRequestBody = NewAssignment
+
+// TransitionAssignmentJSONRequestBody defines body for TransitionAssignment for application/json ContentType.
+type TransitionAssignmentJSONRequestBody = StatusTransition
+
+// CreateRecurringAssignmentJSONRequestBody defines body for CreateRecurringAssignment for application/json ContentType.
+type CreateRecurringAssignmentJSONRequestBody = NewRecurringAssignment
+
+// UpdateRecurringAssignmentJSONRequestBody defines body for UpdateRecurringAssignment for application/json ContentType.
+type UpdateRecurringAssignmentJSONRequestBody = NewRecurringAssignment
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = NewWebhook
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = NewWebhook
@@ -0,0 +1,232 @@
+package converter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// Media types of the bulk import and export endpoints.
+const (
+	CSVMediaType    = "text/csv"
+	NDJSONMediaType = "application/x-ndjson"
+)
+
+// csvExportColumns is the header of a CSV export. The import reads the
+// columns it knows by name and ignores the rest, so an edited export can be
+// imported again.
+var csvExportColumns = []string{"id", "vehicleId", "routeId", "startsAt", "endsAt", "status", "version", "updatedAt"}
+
+// maxNDJSONLine bounds a single NDJSON row.
+const maxNDJSONLine = 64 << 10
+
+// DecodeImportRows parses a bulk import body of the given media type. A row
+// that cannot be parsed is returned with Err set; the error is for bodies
+// that cannot be read at all, and is returned as the body reported it when
+// reading failed. Decoding stops after models.MaxImportRows+1 rows, enough
+// for the service to refuse the import, so an oversized body is not held in
+// memory.
+func DecodeImportRows(r io.Reader, mediaType string) ([]models.ImportRow, error) {
+	switch mediaType {
+	case CSVMediaType:
+		return decodeCSVRows(r)
+	case NDJSONMediaType:
+		return decodeNDJSONRows(r)
+	}
+	return nil, models.NewValidationError("unsupported content type %q, expected %s or %s", mediaType, CSVMediaType, NDJSONMediaType)
+}
+
+func decodeCSVRows(r io.Reader) ([]models.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // a short row is the row's problem, not the file's
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // spreadsheets like to add a BOM
+		}
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"vehicleId", "routeId", "startsAt"} {
+		if _, ok := columns[name]; !ok {
+			return nil, models.NewValidationError("CSV header lacks the %s column", name)
+		}
+	}
+
+	var rows []models.ImportRow
+	for len(rows) <= models.MaxImportRows {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+		row := models.ImportRow{Row: len(rows) + 1}
+		if len(record) != len(header) {
+			row.Err = models.NewValidationError("row has %d fields, the header %d", len(record), len(header))
+		} else {
+			row.Assignment, row.Err = csvAssignment(record, columns)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvError tells a malformed CSV body apart from one that could not be read.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	return models.NewValidationError("malformed CSV: %v", err)
+}
+
+// csvAssignment reads the known columns of one record, reporting every
+// unparsable instant at once like the service's validation does.
+func csvAssignment(record []string, columns map[string]int) (models.Assignment, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	a := models.Assignment{VehicleID: field("vehicleId"), RouteID: field("routeId")}
+	var problems []string
+	for _, f := range []struct {
+		name string
+		dst  *time.Time
+	}{{"startsAt", &a.StartsAt}, {"endsAt", &a.EndsAt}} {
+		v := field(f.name)
+		if v == "" {
+			continue // reported as missing by the service, or defaulted
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			problems = append(problems, f.name+" is not an RFC 3339 instant")
+			continue
+		}
+		*f.dst = t
+	}
+	if len(problems) > 0 {
+		return models.Assignment{}, models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
+	return a, nil
+}
+
+func decodeNDJSONRows(r io.Reader) ([]models.ImportRow, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), maxNDJSONLine)
+
+	var rows []models.ImportRow
+	for len(rows) <= models.MaxImportRows && sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row := models.ImportRow{Row: len(rows) + 1}
+		var body api.NewAssignment
+		if err := json.Unmarshal(line, &body); err != nil {
+			row.Err = models.NewValidationError("invalid JSON: %v", err)
+		} else {
+			row.Assignment = NewAssignmentToDomain(body)
+		}
+		rows = append(rows, row)
+	}
+	if err := sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, models.NewValidationError("NDJSON line %d is longer than %d bytes", len(rows)+1, maxNDJSONLine)
+		}
+		return nil, err
+	}
+	return rows, nil
+}
+
+// AssignmentEncoder writes assignments in a bulk export format. Flush must
+// be called once all assignments are written.
+type AssignmentEncoder interface {
+	Encode(a models.Assignment) error
+	Flush() error
+}
+
+// NewAssignmentEncoder returns the encoder for mediaType, CSV or NDJSON.
+func NewAssignmentEncoder(w io.Writer, mediaType string) AssignmentEncoder {
+	if mediaType == NDJSONMediaType {
+		bw := bufio.NewWriter(w)
+		return &ndjsonEncoder{w: bw, enc: json.NewEncoder(bw)}
+	}
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+// csvEncoder writes the header before the first row, or on Flush for an
+// empty export.
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.w.Write(csvExportColumns)
+}
+
+func (e *csvEncoder) Encode(a models.Assignment) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	var updatedAt string
+	if !a.UpdatedAt.IsZero() {
+		updatedAt = a.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return e.w.Write([]string{
+		a.ID,
+		a.VehicleID,
+		a.RouteID,
+		a.StartsAt.UTC().Format(time.RFC3339Nano),
+		a.EndsAt.UTC().Format(time.RFC3339Nano),
+		a.Status,
+		strconv.FormatInt(a.Version, 10),
+		updatedAt,
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(a models.Assignment) error {
+	return e.enc.Encode(AssignmentFromDomain(a)) // Encode ends every value with a newline
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.w.Flush()
+}
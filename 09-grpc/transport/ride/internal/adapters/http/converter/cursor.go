@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// cursorToken is the wire form of a page cursor. Clients treat it as opaque;
+// the sort order is embedded so a cursor cannot be replayed with another one.
+type cursorToken struct {
+	StartsAt time.Time        `json:"s"`
+	ID       string           `json:"i"`
+	Order    models.SortOrder `json:"o"`
+}
+
+// EncodeCursor turns a keyset position into an opaque, URL-safe token.
+func EncodeCursor(c models.AssignmentCursor, order models.SortOrder) string {
+	raw, _ := json.Marshal(cursorToken{StartsAt: c.StartsAt.UTC(), ID: c.ID, Order: order})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor for the given order.
+func DecodeCursor(token string, order models.SortOrder) (models.AssignmentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return models.AssignmentCursor{}, models.NewValidationError("cursor is malformed")
+	}
+	var t cursorToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.ID == "" {
+		return models.AssignmentCursor{}, models.NewValidationError("cursor is malformed")
+	}
+	if t.Order != order {
+		return models.AssignmentCursor{}, models.NewValidationError("cursor was issued for a different sort order")
+	}
+	return models.AssignmentCursor{StartsAt: t.StartsAt, ID: t.ID}, nil
+}
+
+// AssignmentQueryFromParams maps listAssignments query parameters onto a
+// domain query. Limits and defaults are left to the service.
+func AssignmentQueryFromParams(p api.ListAssignmentsParams) (models.AssignmentQuery, error) {
+	q := models.AssignmentQuery{
+		VehicleID:  p.VehicleId,
+		RouteID:    p.RouteId,
+		StartsFrom: p.StartsFrom,
+		StartsTo:   p.StartsTo,
+		Order:      models.SortAscending,
+	}
+	if p.Status != nil {
+		s := string(*p.Status)
+		q.Status = &s
+	}
+	if p.Sort != nil && *p.Sort == api.ListAssignmentsParamsSortMinusStartsAt {
+		q.Order = models.SortDescending
+	}
+	if p.Limit != nil {
+		q.Limit = *p.Limit
+		if q.Limit == 0 {
+			return models.AssignmentQuery{}, models.NewValidationError("limit must be positive")
+		}
+	}
+	if p.Cursor != nil && *p.Cursor != "" {
+		after, err := DecodeCursor(*p.Cursor, q.Order)
+		if err != nil {
+			return models.AssignmentQuery{}, err
+		}
+		q.After = &after
+	}
+	return q, nil
+}
+
+// ExportQueryFromParams maps exportAssignments query parameters onto a
+// domain query; the service pages through it.
+func ExportQueryFromParams(p api.ExportAssignmentsParams) models.AssignmentQuery {
+	q := models.AssignmentQuery{
+		VehicleID:  p.VehicleId,
+		RouteID:    p.RouteId,
+		StartsFrom: p.StartsFrom,
+		StartsTo:   p.StartsTo,
+		Order:      models.SortAscending,
+	}
+	if p.Status != nil {
+		s := string(*p.Status)
+		q.Status = &s
+	}
+	if p.Sort != nil && *p.Sort == api.ExportAssignmentsParamsSortMinusStartsAt {
+		q.Order = models.SortDescending
+	}
+	return q
+}
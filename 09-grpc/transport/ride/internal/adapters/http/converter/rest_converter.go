@@ -1,6 +1,9 @@
 package converter
 
 import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/yourname/transport/ride/internal/adapters/http/api"
 	"github.com/yourname/transport/ride/internal/models"
 )
@@ -12,19 +15,192 @@ func AssignmentToDomain(r api.Assignment) models.Assignment {
 		VehicleID: r.VehicleId,
 		RouteID:   r.RouteId,
 		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
 		Status:    string(r.Status),
 	}
 }
 
+// API (create request) -> Domain. ID and status are assigned by the service,
+// which also defaults a missing end time and may choose the vehicle.
+func NewAssignmentToDomain(r api.NewAssignment) models.Assignment {
+	a := models.Assignment{
+		RouteID:  r.RouteId,
+		StartsAt: r.StartsAt,
+	}
+	if r.VehicleId != nil {
+		a.VehicleID = *r.VehicleId
+	}
+	if r.EndsAt != nil {
+		a.EndsAt = *r.EndsAt
+	}
+	return a
+}
+
 // Domain -> API
 func AssignmentFromDomain(r models.Assignment) api.Assignment {
 	return api.Assignment{
 		Metadata: &api.EntityMetadata{
-			Id: &r.ID,
+			Id:        &r.ID,
+			UpdatedAt: nonZeroTime(r.UpdatedAt),
 		},
-		VehicleId: r.VehicleID,
-		RouteId:   r.RouteID,
+		VehicleId:           r.VehicleID,
+		VehicleAutoSelected: &r.VehicleAutoSelected,
+		RouteId:             r.RouteID,
+		StartsAt:            r.StartsAt,
+		EndsAt:              r.EndsAt,
+		Status:              api.AssignmentStatus(r.Status),
+	}
+}
+
+// Domain -> API
+func AuditEntryFromDomain(e models.AuditEntry) api.AuditEntry {
+	out := api.AuditEntry{
+		Id:     e.ID,
+		Action: api.AuditEntryAction(e.Action),
+		Actor:  e.Actor,
+		At:     e.At,
+		After:  AssignmentFromDomain(e.After),
+	}
+	if e.RequestID != "" {
+		out.RequestId = &e.RequestID
+	}
+	if e.Before != nil {
+		before := AssignmentFromDomain(*e.Before)
+		out.Before = &before
+	}
+	return out
+}
+
+// API (create or replace request) -> Domain. The ID comes from the path or
+// is assigned by the service.
+func NewRecurringAssignmentToDomain(r api.NewRecurringAssignment) models.RecurringAssignment {
+	ra := models.RecurringAssignment{
+		VehicleID: r.VehicleId,
+		RouteID:   r.RouteId,
+		RRule:     r.Rrule,
+		Timezone:  r.Timezone,
 		StartsAt:  r.StartsAt,
-		Status:    api.AssignmentStatus(r.Status),
+		Duration:  time.Duration(r.DurationMinutes) * time.Minute,
+	}
+	if r.EndsOn != nil {
+		ra.EndsOn = &r.EndsOn.Time
+	}
+	if r.Exceptions != nil {
+		for _, d := range *r.Exceptions {
+			ra.Exceptions = append(ra.Exceptions, d.Time)
+		}
+	}
+	return ra
+}
+
+// Domain -> API
+func RecurringAssignmentFromDomain(r models.RecurringAssignment) api.RecurringAssignment {
+	out := api.RecurringAssignment{
+		Metadata: &api.EntityMetadata{
+			Id:        &r.ID,
+			UpdatedAt: nonZeroTime(r.UpdatedAt),
+		},
+		VehicleId:       r.VehicleID,
+		RouteId:         r.RouteID,
+		Rrule:           r.RRule,
+		Timezone:        r.Timezone,
+		StartsAt:        r.StartsAt,
+		DurationMinutes: int(r.Duration / time.Minute),
+		Exceptions:      make([]openapi_types.Date, 0, len(r.Exceptions)),
+	}
+	if r.EndsOn != nil {
+		out.EndsOn = &openapi_types.Date{Time: *r.EndsOn}
+	}
+	for _, d := range r.Exceptions {
+		out.Exceptions = append(out.Exceptions, openapi_types.Date{Time: d})
+	}
+	return out
+}
+
+// API (create or replace request) -> Domain. The ID comes from the path or
+// is assigned by the service; a missing enabled flag means enabled.
+func NewWebhookToDomain(r api.NewWebhook) models.Webhook {
+	w := models.Webhook{URL: r.Url, Enabled: true}
+	if r.Secret != nil {
+		w.Secret = *r.Secret
+	}
+	if r.Enabled != nil {
+		w.Enabled = *r.Enabled
+	}
+	if r.EventTypes != nil {
+		for _, t := range *r.EventTypes {
+			w.EventTypes = append(w.EventTypes, string(t))
+		}
+	}
+	return w
+}
+
+// Domain -> API. The secret is never returned.
+func WebhookFromDomain(w models.Webhook) api.Webhook {
+	out := api.Webhook{
+		Metadata: &api.EntityMetadata{
+			Id:        &w.ID,
+			UpdatedAt: nonZeroTime(w.UpdatedAt),
+		},
+		Url:                 w.URL,
+		EventTypes:          append([]string{}, w.EventTypes...),
+		Enabled:             w.Enabled,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+	}
+	if w.DisabledReason != "" {
+		out.DisabledReason = &w.DisabledReason
+	}
+	return out
+}
+
+// Domain -> API
+func WebhookDeliveryFromDomain(d models.WebhookDelivery) api.WebhookDelivery {
+	out := api.WebhookDelivery{
+		Id:          d.ID,
+		EventId:     d.EventID,
+		EventType:   d.EventType,
+		Status:      api.WebhookDeliveryStatus(d.Status),
+		Attempts:    d.Attempts,
+		CreatedAt:   d.CreatedAt,
+		DeliveredAt: d.DeliveredAt,
+	}
+	if d.Status == models.WebhookDeliveryPending {
+		out.NextAttemptAt = &d.NextAttemptAt
+	}
+	if d.LastStatusCode != 0 {
+		out.LastStatusCode = &d.LastStatusCode
+	}
+	if d.LastError != "" {
+		out.LastError = &d.LastError
+	}
+	return out
+}
+
+// Domain -> API
+func ImportReportFromDomain(r models.ImportReport) api.ImportReport {
+	out := api.ImportReport{
+		Mode:     api.ImportReportMode(r.Mode),
+		Imported: r.Imported,
+		Failed:   r.Failed,
+		Rows:     make([]api.ImportRowResult, 0, len(r.Rows)),
+	}
+	for _, row := range r.Rows {
+		res := api.ImportRowResult{Row: row.Row, Status: api.ImportRowResultStatus(row.Status)}
+		if row.ID != "" {
+			res.Id = &row.ID
+		}
+		if row.Err != nil {
+			msg := models.ErrorMessage(row.Err, "internal error")
+			res.Error = &msg
+		}
+		out.Rows = append(out.Rows, res)
+	}
+	return out
+}
+
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
+	return &t
 }
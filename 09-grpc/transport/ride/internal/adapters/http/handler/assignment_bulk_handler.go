@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// exportDeadlineRows is how many exported rows may be written before the
+// write deadline is extended again.
+const exportDeadlineRows = 500
+
+// BatchImportAssignments ignores the Idempotency-Key parameter like
+// CreateAssignment does.
+func (h *AssignmentHandler) BatchImportAssignments(c *gin.Context, params api.BatchImportAssignmentsParams) {
+	if !isCustomMethod(c, "batchImport") {
+		notFound(c, "resource not found")
+		return
+	}
+
+	rows, err := converter.DecodeImportRows(c.Request.Body, c.ContentType())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	mode := models.ImportAtomic
+	if params.Mode != nil {
+		mode = models.ImportMode(*params.Mode)
+	}
+
+	report, err := h.service.Import(c.Request.Context(), rows, mode)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	status := http.StatusOK
+	if report.Mode == models.ImportAtomic && report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, converter.ImportReportFromDomain(report))
+}
+
+// ExportAssignments streams the export as it is read. Errors found before
+// the first row get the usual error response; after that the response can
+// only be cut short.
+func (h *AssignmentHandler) ExportAssignments(c *gin.Context, params api.ExportAssignmentsParams) {
+	if !isCustomMethod(c, "export") {
+		notFound(c, "resource not found")
+		return
+	}
+
+	mediaType, filename := converter.CSVMediaType, "assignments.csv"
+	if params.Format != nil && *params.Format == api.ExportAssignmentsParamsFormatNdjson {
+		mediaType, filename = converter.NDJSONMediaType, "assignments.ndjson"
+	}
+	rc := http.NewResponseController(c.Writer)
+	var (
+		enc     converter.AssignmentEncoder
+		written int
+	)
+	start := func() {
+		c.Header("Content-Type", mediaType)
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Status(http.StatusOK)
+		enc = converter.NewAssignmentEncoder(c.Writer, mediaType)
+	}
+
+	err := h.service.Export(c.Request.Context(), converter.ExportQueryFromParams(params), func(a models.Assignment) error {
+		if enc == nil {
+			start()
+		}
+		// A large export outlasts the server's write timeout, which is
+		// meant for single responses; each batch of rows gets its own.
+		if written%exportDeadlineRows == 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		}
+		written++
+		return enc.Encode(a)
+	})
+	if err != nil {
+		if enc == nil {
+			writeError(c, err)
+			return
+		}
+		_ = c.Error(err) // the client sees a truncated body
+		return
+	}
+	if enc == nil {
+		start()
+	}
+	if err := enc.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// isCustomMethod reports whether the request is for the custom method name
+// of a collection, as in /assignments:export. gin has no literal colons in
+// routes: it registers ":export" as a wildcard that matches any suffix, and
+// the wildcard's value tells the real path apart.
+func isCustomMethod(c *gin.Context, name string) bool {
+	return c.Param(name) == ":"+name
+}
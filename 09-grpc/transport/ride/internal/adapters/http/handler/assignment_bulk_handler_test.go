@@ -0,0 +1,211 @@
+package handler_test
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+func serveImport(router http.Handler, query, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/assignments:batchImport"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBatchImportAssignments(t *testing.T) {
+	const (
+		validCSV = "vehicleId,routeId,startsAt,endsAt\n" +
+			"V1,R1,2025-01-02T08:00:00Z,\n" +
+			"V2,R1,2025-01-02T08:00:00Z,2025-01-02T09:30:00Z\n"
+		mixedCSV = "routeId,vehicleId,startsAt,note\n" +
+			"R1,V1,2025-01-02T08:00:00Z,first\n" +
+			"R1,,2025-01-02T08:00:00Z,no vehicle\n" +
+			"R1,V3,tomorrow,bad time\n"
+		mixedNDJSON = `{"vehicleId":"V1","routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}` + "\n\n" +
+			`{"vehicleId":"V2",` + "\n"
+		csvRow = "V1,R1,2025-01-02T08:00:00Z\n"
+	)
+	testCases := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		wantStatus  int
+		wantRows    []api.ImportRowResultStatus
+		wantStored  int
+	}{
+		{
+			name:        "atomic csv",
+			contentType: "text/csv",
+			body:        validCSV,
+			wantStatus:  http.StatusOK,
+			wantRows:    []api.ImportRowResultStatus{"created", "created"},
+			wantStored:  2,
+		},
+		{
+			name:        "atomic with failing rows creates nothing",
+			contentType: "text/csv",
+			body:        mixedCSV,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantRows:    []api.ImportRowResultStatus{"skipped", "failed", "failed"},
+		},
+		{
+			name:        "partial csv",
+			query:       "?mode=partial",
+			contentType: "text/csv; charset=utf-8",
+			body:        mixedCSV,
+			wantStatus:  http.StatusOK,
+			wantRows:    []api.ImportRowResultStatus{"created", "failed", "failed"},
+			wantStored:  1,
+		},
+		{
+			name:        "partial ndjson",
+			query:       "?mode=partial",
+			contentType: "application/x-ndjson",
+			body:        mixedNDJSON,
+			wantStatus:  http.StatusOK,
+			wantRows:    []api.ImportRowResultStatus{"created", "failed"},
+			wantStored:  1,
+		},
+		{
+			name:        "csv header without a required column",
+			contentType: "text/csv",
+			body:        "vehicleId,startsAt\nV1,2025-01-02T08:00:00Z\n",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "empty body",
+			contentType: "text/csv",
+			body:        "vehicleId,routeId,startsAt\n",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "too many rows",
+			contentType: "text/csv",
+			body:        "vehicleId,routeId,startsAt\n" + strings.Repeat(csvRow, models.MaxImportRows+1),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "body over the limit",
+			contentType: "text/csv",
+			body:        "vehicleId,routeId,startsAt\n" + strings.Repeat(csvRow, middleware.DefaultMaxImportBodyBytes/len(csvRow)),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "unknown mode",
+			query:       "?mode=best-effort",
+			contentType: "text/csv",
+			body:        validCSV,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			rec := serveImport(newRouter(repo), tc.query, tc.contentType, tc.body)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if len(repo.items) != tc.wantStored {
+				t.Fatalf("expected %d stored assignments, got %d", tc.wantStored, len(repo.items))
+			}
+			if tc.wantRows == nil {
+				return
+			}
+			var report api.ImportReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if report.Imported != tc.wantStored || len(report.Rows) != len(tc.wantRows) {
+				t.Fatalf("unexpected report %s", rec.Body.String())
+			}
+			for i, row := range report.Rows {
+				if row.Row != i+1 || row.Status != tc.wantRows[i] {
+					t.Errorf("row %d: expected %s, got %+v", i+1, tc.wantRows[i], row)
+				}
+				if (row.Status == "failed") != (row.Error != nil) || (row.Status == "created") != (row.Id != nil) {
+					t.Errorf("row %d: inconsistent result %+v", i+1, row)
+				}
+			}
+		})
+	}
+}
+
+func TestExportAssignments(t *testing.T) {
+	repo := newFakeRepository()
+	start := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	// More than a page, so the export has to follow the cursor.
+	const total = 503
+	for i := range total {
+		id := fmt.Sprintf("A%03d", i)
+		startsAt := start.Add(time.Duration(i) * time.Hour)
+		repo.items[id] = models.Assignment{ID: id, VehicleID: "V1", RouteID: "R1", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour), Status: "pending", Version: 1}
+	}
+	router := newRouter(repo)
+
+	rec := serve(router, http.MethodGet, "/assignments:export", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/csv" {
+		t.Fatalf("expected a CSV export, got %d %s: %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+	}
+	exported := rec.Body.String()
+	records, err := csv.NewReader(strings.NewReader(exported)).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(records) != total+1 || strings.Join(records[0], ",") != "id,vehicleId,routeId,startsAt,endsAt,status,version,updatedAt" {
+		t.Fatalf("expected a header and %d rows, got %d records starting with %v", total, len(records), records[0])
+	}
+	if got := records[1]; got[0] != "A000" || got[3] != "2025-01-02T08:00:00Z" || got[6] != "1" {
+		t.Fatalf("unexpected first row %v", got)
+	}
+
+	// An export can be imported again as it is.
+	fresh := newFakeRepository()
+	if rec := serveImport(newRouter(fresh), "", "text/csv", exported); rec.Code != http.StatusOK || len(fresh.items) != total {
+		t.Fatalf("expected the export to import, got %d with %d stored: %s", rec.Code, len(fresh.items), rec.Body.String())
+	}
+
+	rec = serve(router, http.MethodGet, "/assignments:export?format=ndjson&sort=-startsAt", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("expected an NDJSON export, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	sc := bufio.NewScanner(rec.Body)
+	var lines int
+	for sc.Scan() {
+		var a api.Assignment
+		if err := json.Unmarshal(sc.Bytes(), &a); err != nil {
+			t.Fatalf("decode line %d: %v", lines+1, err)
+		}
+		if lines == 0 && *a.Metadata.Id != "A502" {
+			t.Fatalf("expected the latest assignment first, got %s", *a.Metadata.Id)
+		}
+		lines++
+	}
+	if lines != total {
+		t.Fatalf("expected %d lines, got %d", total, lines)
+	}
+
+	rec = serve(router, http.MethodGet, "/assignments:export?vehicleId=V9", "")
+	if rec.Code != http.StatusOK || rec.Body.String() != "id,vehicleId,routeId,startsAt,endsAt,status,version,updatedAt\n" {
+		t.Fatalf("expected only the header for an empty export, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// gin matches the custom method as a wildcard; other suffixes are not found.
+	if rec := serve(router, http.MethodGet, "/assignmentsexport", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown path, got %d", rec.Code)
+	}
+}
@@ -1,15 +1,21 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
 	"github.com/yourname/transport/ride/internal/ports"
 )
 
-// AssignmentHandler is the HTTP adapter. It implements api.ServerInterface
-// and delegates to the core AssignmentService (a hexagonal port).
+// AssignmentHandler is the HTTP adapter for /assignments. It delegates to
+// the core AssignmentService (a hexagonal port); Server combines it with
+// the other handlers into an api.ServerInterface.
 type AssignmentHandler struct {
 	service ports.AssignmentService
 }
@@ -20,21 +26,163 @@ func NewAssignmentHandler(service ports.AssignmentService) *AssignmentHandler {
 }
 
 func (h *AssignmentHandler) ListAssignments(c *gin.Context, params api.ListAssignmentsParams) {
-	notImplemented(c)
+	q, err := converter.AssignmentQueryFromParams(params)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	page, err := h.service.List(c.Request.Context(), q)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if page.Next != nil {
+		token := converter.EncodeCursor(*page.Next, q.Order)
+		c.Header("X-Next-Cursor", token)
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, nextPageURL(c, token)))
+	}
+
+	// Always answer with a JSON array, never null, so clients can range over it.
+	out := make([]api.Assignment, 0, len(page.Items))
+	for _, a := range page.Items {
+		out = append(out, converter.AssignmentFromDomain(a))
+	}
+	c.JSON(http.StatusOK, out)
 }
-func (h *AssignmentHandler) CreateAssignment(c *gin.Context) {
-	notImplemented(c)
+
+// CreateAssignment ignores params: Idempotency-Key is handled by
+// middleware.Idempotency before the request gets here.
+func (h *AssignmentHandler) CreateAssignment(c *gin.Context, _ api.CreateAssignmentParams) {
+	var body api.CreateAssignmentJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	saved, err := h.service.Save(c.Request.Context(), converter.NewAssignmentToDomain(body))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", assignmentLocation(c, saved.ID))
+	c.Header("ETag", assignmentETag(saved))
+	c.JSON(http.StatusCreated, converter.AssignmentFromDomain(saved))
 }
-func (h *AssignmentHandler) GetAssignment(c *gin.Context, id string) {
-	notImplemented(c)
+
+func (h *AssignmentHandler) GetAssignment(c *gin.Context, id string, params api.GetAssignmentParams) {
+	a, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	etag := assignmentETag(a)
+	c.Header("ETag", etag)
+	if params.IfNoneMatch != nil && etagMatches(*params.IfNoneMatch, etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, converter.AssignmentFromDomain(a))
 }
 
-func notImplemented(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error":   "not implemented",
-		"message": "ListAssignments endpoint is not implemented yet",
-	})
+func (h *AssignmentHandler) GetAssignmentHistory(c *gin.Context, id string) {
+	entries, err := h.service.History(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	out := make([]api.AuditEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, converter.AuditEntryFromDomain(e))
+	}
+	c.JSON(http.StatusOK, out)
 }
 
-// Ensure we implement the generated interface
-var _ api.ServerInterface = (*AssignmentHandler)(nil)
+func (h *AssignmentHandler) UpdateAssignment(c *gin.Context, id string, params api.UpdateAssignmentParams) {
+	var body api.UpdateAssignmentJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	version, err := h.expectedVersion(c, id, params.IfMatch)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	a := converter.NewAssignmentToDomain(body)
+	a.ID, a.Version = id, version
+	updated, err := h.service.Update(c.Request.Context(), a)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Header("ETag", assignmentETag(updated))
+	c.JSON(http.StatusOK, converter.AssignmentFromDomain(updated))
+}
+
+func (h *AssignmentHandler) TransitionAssignment(c *gin.Context, id string, params api.TransitionAssignmentParams) {
+	var body api.TransitionAssignmentJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	var reason string
+	if body.Reason != nil {
+		reason = *body.Reason
+	}
+
+	version, err := h.expectedVersion(c, id, params.IfMatch)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	a, err := h.service.Transition(c.Request.Context(), id, models.AssignmentStatus(body.To), reason, version)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Header("ETag", assignmentETag(a))
+	c.JSON(http.StatusOK, converter.AssignmentFromDomain(a))
+}
+
+// expectedVersion resolves an If-Match header to the version the update must
+// still find in place; 0 means the client sent no precondition. Resolving it
+// against the stored assignment supports "*" and lists of ETags, while the
+// repository's compare-and-set keeps the check atomic.
+func (h *AssignmentHandler) expectedVersion(c *gin.Context, id string, ifMatch *string) (int64, error) {
+	if ifMatch == nil {
+		return 0, nil
+	}
+	current, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		return 0, err
+	}
+	if etag := assignmentETag(current); !etagMatches(*ifMatch, etag, false) {
+		return 0, models.NewPreconditionFailedError("If-Match %s does not match current ETag %s", *ifMatch, etag)
+	}
+	return current.Version, nil
+}
+
+// assignmentLocation builds the URL of an assignment relative to the
+// collection the request was sent to, so a BaseURL prefix is preserved.
+func assignmentLocation(c *gin.Context, id string) string {
+	return strings.TrimSuffix(c.Request.URL.Path, "/") + "/" + url.PathEscape(id)
+}
+
+// nextPageURL repeats the current request with the cursor replaced, so the
+// next page keeps the caller's filters, sort and limit.
+func nextPageURL(c *gin.Context, cursor string) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set("cursor", cursor)
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
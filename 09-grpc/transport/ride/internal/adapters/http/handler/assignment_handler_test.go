@@ -0,0 +1,513 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/handler"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// fakeRepository is an in-memory ports.AssignmentRepository so the handler
+// can be exercised through the real service.
+type fakeRepository struct {
+	items       map[string]models.Assignment
+	transitions []models.AssignmentTransition
+	audit       []models.AuditEntry // returned by History and AuditSince as is
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{items: map[string]models.Assignment{}}
+}
+
+func (f *fakeRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
+	current, exists := f.items[a.ID]
+	if a.Version > 0 && (!exists || current.Version != a.Version) {
+		return false, models.NewPreconditionFailedError("assignment %s is at version %d", a.ID, current.Version)
+	}
+	if exists {
+		a.Status = current.Status
+	}
+	a.Version = current.Version + 1
+	f.items[a.ID] = a
+	return !exists, nil
+}
+
+// SaveAll has nothing to roll back: Save cannot fail for new assignments.
+func (f *fakeRepository) SaveAll(ctx context.Context, as []models.Assignment) error {
+	for i, a := range as {
+		if _, err := f.Save(ctx, a); err != nil {
+			return &models.BatchError{Items: []models.ItemError{{Index: i, Err: err}}}
+		}
+	}
+	return nil
+}
+
+func (f *fakeRepository) CheckAll(ctx context.Context, as []models.Assignment) error {
+	return nil
+}
+
+func (f *fakeRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
+	a, ok := f.items[id]
+	if !ok {
+		return models.Assignment{}, models.NewNotFoundError("assignment %s not found", id)
+	}
+	return a, nil
+}
+
+func (f *fakeRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	var out []models.Assignment
+	for _, a := range f.items {
+		if q.Status != nil && a.Status != *q.Status {
+			continue
+		}
+		if q.VehicleID != nil && a.VehicleID != *q.VehicleID {
+			continue
+		}
+		if q.After != nil && !q.Order.Less(*q.After, models.CursorOf(a)) {
+			continue
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return q.Order.Less(models.CursorOf(out[i]), models.CursorOf(out[j]))
+	})
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+		next := models.CursorOf(out[len(out)-1])
+		return models.AssignmentPage{Items: out, Next: &next}, nil
+	}
+	return models.AssignmentPage{Items: out}, nil
+}
+
+func (f *fakeRepository) UpdateStatus(ctx context.Context, t models.AssignmentTransition) error {
+	a, ok := f.items[t.AssignmentID]
+	if !ok {
+		return models.NewNotFoundError("assignment %s not found", t.AssignmentID)
+	}
+	if a.Status != string(t.From) {
+		return models.NewConflictError("assignment %s is %s", a.ID, a.Status)
+	}
+	if t.Version > 0 && a.Version != t.Version {
+		return models.NewPreconditionFailedError("assignment %s is at version %d", a.ID, a.Version)
+	}
+	a.Status = string(t.To)
+	a.Version++
+	f.items[a.ID] = a
+	f.transitions = append(f.transitions, t)
+	return nil
+}
+
+func (f *fakeRepository) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	var out []models.AuditEntry
+	for _, e := range f.audit {
+		if e.AssignmentID == id {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	var out []models.AuditEntry
+	for _, e := range f.audit {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepository) LatestAuditID(ctx context.Context) (int64, error) {
+	if len(f.audit) == 0 {
+		return 0, nil
+	}
+	return f.audit[len(f.audit)-1].ID, nil
+}
+
+// testTenants are served by newRouter; requests without X-Tenant-ID act for
+// the default one.
+var testTenants = []string{requestctx.DefaultTenant, "paris"}
+
+func newRouter(repo ports.AssignmentRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	// Strict validation fails any response that drifts from api/openapi.yaml.
+	validator, err := middleware.OpenAPIValidator(middleware.OpenAPIValidatorOptions{ValidateResponses: true})
+	if err != nil {
+		panic(err)
+	}
+	limit, err := middleware.BodyLimit(middleware.BodyLimitOptions{
+		Operations: map[string]int64{"batchImportAssignments": middleware.DefaultMaxImportBodyBytes},
+	})
+	if err != nil {
+		panic(err)
+	}
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Actor(), middleware.Tenant(testTenants), limit, validator)
+	rules := repository.NewMemoryRecurringAssignmentRepository()
+	recurring := service.NewRecurringAssignmentService(rules, service.NewRecurrenceMaterializer(rules, repo, nil, service.RecurrenceMaterializerOptions{}))
+	server := handler.NewServer(
+		handler.NewAssignmentHandler(service.NewAssignmentService(repo, nil, nil)),
+		handler.NewRecurringAssignmentHandler(recurring),
+		handler.NewWebhookHandler(service.NewWebhookService(repository.NewMemoryWebhookStore())),
+		handler.NewAssignmentStreamHandler(service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{}), 0),
+	)
+	api.RegisterHandlersWithOptions(router, server, api.GinServerOptions{ErrorHandler: handler.ParamErrorHandler})
+	return router
+}
+
+func serve(router http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreateAssignment(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "created with location",
+			body:       `{"vehicleId":"V1","routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "malformed json",
+			body:       `{"vehicleId":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing required fields",
+			body:       `{"routeId":"R1"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no vehicle and no vehicle service",
+			body:       `{"routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newRouter(newFakeRepository())
+			rec := serve(router, http.MethodPost, "/assignments", tc.body)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tc.wantStatus == http.StatusCreated {
+				location := rec.Header().Get("Location")
+				if !strings.HasPrefix(location, "/assignments/") {
+					t.Fatalf("unexpected Location %q", location)
+				}
+				if got := serve(router, http.MethodGet, location, ""); got.Code != http.StatusOK {
+					t.Errorf("GET %s: expected 200, got %d", location, got.Code)
+				}
+			}
+			if tc.wantStatus == http.StatusBadRequest {
+				var br api.BadRequest
+				if err := json.Unmarshal(rec.Body.Bytes(), &br); err != nil {
+					t.Fatalf("decode body: %v", err)
+				}
+				if br.Details == nil || *br.Details == "" {
+					t.Errorf("expected details in bad request body, got %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGetAssignment(t *testing.T) {
+	repo := newFakeRepository()
+	repo.items["A1"] = models.Assignment{
+		ID:        "A1",
+		VehicleID: "V1",
+		RouteID:   "R1",
+		StartsAt:  time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+		Status:    "pending",
+	}
+	router := newRouter(repo)
+
+	rec := serve(router, http.MethodGet, "/assignments/A1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var got api.Assignment
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got.Metadata == nil || got.Metadata.Id == nil || *got.Metadata.Id != "A1" {
+		t.Errorf("unexpected metadata: %+v", got.Metadata)
+	}
+
+	rec = serve(router, http.MethodGet, "/assignments/missing", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var nf api.NotFound
+	if err := json.Unmarshal(rec.Body.Bytes(), &nf); err != nil || nf.Error == nil {
+		t.Fatalf("expected NotFound body, got %s", rec.Body.String())
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	repo := newFakeRepository()
+	repo.items["A1"] = models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending", Version: 3}
+	router := newRouter(repo)
+
+	do := func(method, target, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	update := `{"vehicleId":"V2","routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}`
+
+	steps := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		header     map[string]string
+		wantStatus int
+		wantETag   string
+	}{
+		{name: "get returns etag", method: http.MethodGet, target: "/assignments/A1", wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "if-none-match hit", method: http.MethodGet, target: "/assignments/A1", header: map[string]string{"If-None-Match": `W/"3"`}, wantStatus: http.StatusNotModified, wantETag: `"3"`},
+		{name: "if-none-match miss", method: http.MethodGet, target: "/assignments/A1", header: map[string]string{"If-None-Match": `"2"`}, wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "stale if-match", method: http.MethodPut, target: "/assignments/A1", body: update, header: map[string]string{"If-Match": `"2"`}, wantStatus: http.StatusPreconditionFailed},
+		{name: "weak if-match never matches", method: http.MethodPut, target: "/assignments/A1", body: update, header: map[string]string{"If-Match": `W/"3"`}, wantStatus: http.StatusPreconditionFailed},
+		{name: "matching if-match", method: http.MethodPut, target: "/assignments/A1", body: update, header: map[string]string{"If-Match": `"1", "3"`}, wantStatus: http.StatusOK, wantETag: `"4"`},
+		{name: "transition with stale etag", method: http.MethodPost, target: "/assignments/A1/transitions", body: `{"to":"active"}`, header: map[string]string{"If-Match": `"3"`}, wantStatus: http.StatusPreconditionFailed},
+		{name: "transition with current etag", method: http.MethodPost, target: "/assignments/A1/transitions", body: `{"to":"active"}`, header: map[string]string{"If-Match": `"4"`}, wantStatus: http.StatusOK, wantETag: `"5"`},
+		{name: "unconditional update", method: http.MethodPut, target: "/assignments/A1", body: update, wantStatus: http.StatusOK, wantETag: `"6"`},
+		{name: "update missing", method: http.MethodPut, target: "/assignments/missing", body: update, wantStatus: http.StatusNotFound},
+	}
+	for _, step := range steps {
+		rec := do(step.method, step.target, step.body, step.header)
+		if rec.Code != step.wantStatus {
+			t.Fatalf("%s: expected %d, got %d: %s", step.name, step.wantStatus, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("ETag"); step.wantETag != "" && got != step.wantETag {
+			t.Fatalf("%s: expected ETag %s, got %q", step.name, step.wantETag, got)
+		}
+	}
+
+	if a := repo.items["A1"]; a.VehicleID != "V2" || a.Status != "active" {
+		t.Fatalf("unexpected stored assignment: %+v", a)
+	}
+}
+
+func TestListAssignmentsReturnsEmptyArray(t *testing.T) {
+	rec := serve(newRouter(newFakeRepository()), http.MethodGet, "/assignments?status=active", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Fatalf("expected empty array, got %s", body)
+	}
+}
+
+func TestTransitionAssignment(t *testing.T) {
+	repo := newFakeRepository()
+	repo.items["A1"] = models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending"}
+	router := newRouter(repo)
+
+	steps := []struct {
+		body       string
+		wantStatus int
+	}{
+		{body: `{"to":"active"}`, wantStatus: http.StatusOK},
+		{body: `{"to":"completed","reason":"arrived"}`, wantStatus: http.StatusOK},
+		{body: `{"to":"active"}`, wantStatus: http.StatusConflict},
+		{body: `{"to":"pending"}`, wantStatus: http.StatusBadRequest}, // not a transition target in the spec
+		{body: `{"to":"archived"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, "/assignments/A1/transitions", strings.NewReader(step.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.ActorHeader, "dispatcher-7")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != step.wantStatus {
+			t.Fatalf("%s: expected %d, got %d: %s", step.body, step.wantStatus, rec.Code, rec.Body.String())
+		}
+	}
+
+	if got := repo.items["A1"].Status; got != "completed" {
+		t.Fatalf("expected completed, got %s", got)
+	}
+	if len(repo.transitions) != 2 {
+		t.Fatalf("expected 2 recorded transitions, got %d", len(repo.transitions))
+	}
+	last := repo.transitions[1]
+	if last.Actor != "dispatcher-7" || last.Reason != "arrived" || last.From != "active" {
+		t.Errorf("unexpected transition record: %+v", last)
+	}
+
+	if rec := serve(router, http.MethodPost, "/assignments/missing/transitions", `{"to":"active"}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestListAssignmentsPaginates(t *testing.T) {
+	repo := newFakeRepository()
+	base := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	for i, id := range []string{"A1", "A2", "A3", "A4", "A5"} {
+		repo.items[id] = models.Assignment{ID: id, VehicleID: "V1", RouteID: "R1", StartsAt: base.Add(time.Duration(i) * time.Hour), Status: "pending"}
+	}
+	repo.items["B1"] = models.Assignment{ID: "B1", VehicleID: "V2", RouteID: "R1", StartsAt: base, Status: "pending"}
+	router := newRouter(repo)
+
+	var seen []string
+	target := "/assignments?vehicleId=V1&sort=-startsAt&limit=2"
+	for pages := 0; target != ""; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		rec := serve(router, http.MethodGet, target, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+		var items []api.Assignment
+		if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		for _, it := range items {
+			seen = append(seen, *it.Metadata.Id)
+		}
+
+		target = ""
+		if cursor := rec.Header().Get("X-Next-Cursor"); cursor != "" {
+			link := rec.Header().Get("Link")
+			if !strings.Contains(link, `rel="next"`) || !strings.Contains(link, "vehicleId=V1") {
+				t.Fatalf("unexpected Link header %q", link)
+			}
+			target = "/assignments?vehicleId=V1&sort=-startsAt&limit=2&cursor=" + cursor
+		}
+	}
+
+	want := []string{"A5", "A4", "A3", "A2", "A1"}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+
+	// A cursor issued for descending order is rejected for ascending order.
+	rec := serve(router, http.MethodGet, "/assignments?limit=1&sort=-startsAt", "")
+	cursor := rec.Header().Get("X-Next-Cursor")
+	if rec := serve(router, http.MethodGet, "/assignments?cursor="+cursor, ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for mismatched cursor, got %d", rec.Code)
+	}
+	if rec := serve(router, http.MethodGet, "/assignments?cursor=%21%21", ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed cursor, got %d", rec.Code)
+	}
+}
+
+func TestGetAssignmentHistory(t *testing.T) {
+	repo := newFakeRepository()
+	created := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), Status: "pending", Version: 1}
+	activated := created
+	activated.Status, activated.Version = "active", 2
+	repo.items["A1"] = activated
+	repo.audit = []models.AuditEntry{
+		{ID: 1, AssignmentID: "A1", Action: models.AuditActionCreated, Actor: "dispatcher-7", RequestID: "req-1", After: created, At: created.StartsAt.Add(-time.Hour)},
+		{ID: 2, AssignmentID: "A1", Action: models.AuditActionStatusChanged, Actor: service.SchedulerActor, Before: &created, After: activated, At: created.StartsAt},
+	}
+	router := newRouter(repo)
+
+	rec := serve(router, http.MethodGet, "/assignments/A1/history", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(middleware.RequestIDHeader) == "" {
+		t.Errorf("expected a generated %s header", middleware.RequestIDHeader)
+	}
+	var got []api.AuditEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	first, second := got[0], got[1]
+	if first.Action != api.AuditEntryActionCreated || first.Before != nil || first.RequestId == nil || *first.RequestId != "req-1" {
+		t.Errorf("unexpected created entry: %+v", first)
+	}
+	if second.Action != api.AuditEntryActionStatusChanged || second.Actor != service.SchedulerActor || second.RequestId != nil {
+		t.Errorf("unexpected status change entry: %+v", second)
+	}
+	if second.Before == nil || second.Before.Status != api.AssignmentStatusPending || second.After.Status != api.AssignmentStatusActive {
+		t.Errorf("unexpected snapshots: before %+v, after %+v", second.Before, second.After)
+	}
+
+	if rec := serve(router, http.MethodGet, "/assignments/missing/history", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func serveAs(router http.Handler, tenant, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(middleware.TenantHeader, tenant)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAssignmentsAreScopedByTenant(t *testing.T) {
+	router := newRouter(repository.NewMemoryAssignmentRepository())
+	body := `{"vehicleId":"V1","routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}`
+	rec := serve(router, http.MethodPost, "/assignments", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	location := rec.Header().Get("Location")
+
+	for _, target := range []string{location, location + "/history"} {
+		if rec := serveAs(router, "paris", http.MethodGet, target, ""); rec.Code != http.StatusNotFound {
+			t.Fatalf("GET %s: expected 404 for another tenant, got %d: %s", target, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := serveAs(router, "paris", http.MethodPut, location, body); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an update from another tenant, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serveAs(router, "paris", http.MethodGet, "/assignments", ""); rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Fatalf("expected an empty list for another tenant, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// The vehicle is only booked in the default tenant.
+	if rec := serveAs(router, "paris", http.MethodPost, "/assignments", body); rec.Code != http.StatusCreated {
+		t.Fatalf("expected the vehicle to be free in another tenant, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serveAs(router, requestctx.DefaultTenant, http.MethodGet, location, ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected the default tenant to keep its assignment, got %d", rec.Code)
+	}
+	if rec := serveAs(router, "berlin", http.MethodGet, location, ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a tenant that is not served, got %d", rec.Code)
+	}
+}
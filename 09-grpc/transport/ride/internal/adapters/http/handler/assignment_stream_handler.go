@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+const (
+	defaultStreamHeartbeat = 15 * time.Second
+
+	// streamWriteTimeout bounds every write to a stream, so a client that
+	// stopped reading does not hold its connection forever.
+	streamWriteTimeout = 10 * time.Second
+
+	// streamResetEvent tells a resuming client that it missed changes.
+	streamResetEvent = "reset"
+)
+
+// AssignmentStreamHandler is the HTTP adapter for /assignments/stream. It
+// speaks Server-Sent Events, or WebSocket when the request asks to upgrade.
+type AssignmentStreamHandler struct {
+	feed      ports.AssignmentFeed
+	heartbeat time.Duration
+}
+
+// NewAssignmentStreamHandler sends a heartbeat on idle streams every
+// heartbeat, 15s when zero.
+func NewAssignmentStreamHandler(feed ports.AssignmentFeed, heartbeat time.Duration) *AssignmentStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+	return &AssignmentStreamHandler{feed: feed, heartbeat: heartbeat}
+}
+
+func (h *AssignmentStreamHandler) StreamAssignments(c *gin.Context, params api.StreamAssignmentsParams) {
+	var lastEventID int64
+	switch {
+	case params.LastEventID != nil:
+		id, err := strconv.ParseInt(*params.LastEventID, 10, 64)
+		if err != nil || id < 1 {
+			badRequest(c, "invalid Last-Event-ID header", "expected the id of a previous event")
+			return
+		}
+		lastEventID = id
+	case params.LastEventId != nil:
+		lastEventID = *params.LastEventId
+	}
+	filter := models.AssignmentChangeFilter{
+		TenantID:  requestctx.Tenant(c.Request.Context()),
+		VehicleID: params.VehicleId,
+		RouteID:   params.RouteId,
+	}
+	if params.Status != nil {
+		status := string(*params.Status)
+		filter.Status = &status
+	}
+
+	sub := h.feed.Subscribe(filter, lastEventID)
+	defer sub.Close()
+
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		h.serveWebSocket(c, sub)
+		return
+	}
+	h.serveEventStream(c, sub)
+}
+
+// streamEvent is one message on a stream. Over WebSocket it is sent as JSON.
+type streamEvent struct {
+	ID    string `json:"id,omitempty"`
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// streamWriter is a transport for pump.
+type streamWriter interface {
+	send(e streamEvent) error
+	heartbeat() error
+}
+
+// pump writes the replayed and then the live changes of sub to w until the
+// client goes away, the subscription is closed or a write fails.
+func (h *AssignmentStreamHandler) pump(ctx context.Context, sub ports.AssignmentSubscription, w streamWriter) {
+	if !sub.Resumed() {
+		if err := w.send(streamEvent{Event: streamResetEvent, Data: struct{}{}}); err != nil {
+			return
+		}
+	}
+	for _, e := range sub.Replay() {
+		if err := w.send(changeEvent(e)); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Evicted or shutting down: the client reconnects and resumes.
+				return
+			}
+			if err := w.send(changeEvent(e)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := w.heartbeat(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func changeEvent(e models.AuditEntry) streamEvent {
+	return streamEvent{ID: strconv.FormatInt(e.ID, 10), Event: string(e.Action), Data: converter.AuditEntryFromDomain(e)}
+}
+
+func (h *AssignmentStreamHandler) serveEventStream(c *gin.Context, sub ports.AssignmentSubscription) {
+	rc := http.NewResponseController(c.Writer)
+	// The server's write timeout is meant for single responses; each write
+	// to the stream gets its own deadline instead.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no") // keep proxies from buffering the stream
+	c.Status(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	h.pump(c.Request.Context(), sub, &eventStreamWriter{w: c.Writer, rc: rc})
+}
+
+type eventStreamWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s *eventStreamWriter) send(e streamEvent) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", e.Event, data)
+	return s.write(b.String())
+}
+
+func (s *eventStreamWriter) heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *eventStreamWriter) write(chunk string) error {
+	_ = s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+func (h *AssignmentStreamHandler) serveWebSocket(c *gin.Context, sub ports.AssignmentSubscription) {
+	server := websocket.Server{
+		// Any origin may connect: the stream is read-only and no cookies
+		// are involved.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			// The hijacked connection keeps the server's deadlines.
+			_ = ws.SetDeadline(time.Time{})
+
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+			go func() {
+				// Clients do not send anything; reading processes their
+				// pings and notices when they close the connection.
+				var discard string
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+				cancel()
+			}()
+			h.pump(ctx, sub, webSocketWriter{ws: ws})
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+type webSocketWriter struct {
+	ws *websocket.Conn
+}
+
+func (s webSocketWriter) send(e streamEvent) error {
+	_ = s.ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	return websocket.JSON.Send(s.ws, e)
+}
+
+func (s webSocketWriter) heartbeat() error {
+	return s.send(streamEvent{Event: "heartbeat", Data: struct{}{}})
+}
@@ -0,0 +1,230 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/handler"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// newStreamServer serves only /assignments/stream. Streams cannot go through
+// newRouter: its response validation buffers the whole body.
+func newStreamServer(t *testing.T, feed ports.AssignmentFeed, heartbeat time.Duration) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	validator, err := middleware.OpenAPIValidator(middleware.OpenAPIValidatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Actor(), validator)
+	server := handler.NewServer(nil, nil, nil, handler.NewAssignmentStreamHandler(feed, heartbeat))
+	api.RegisterHandlersWithOptions(router, server, api.GinServerOptions{ErrorHandler: handler.ParamErrorHandler})
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// sseEvent is one event read from a text/event-stream body; comment is set
+// for comment lines such as heartbeats.
+type sseEvent struct {
+	id, event, data, comment string
+}
+
+// readEvents parses the stream into a channel until the body ends.
+func readEvents(body *bufio.Reader) <-chan sseEvent {
+	out := make(chan sseEvent, 16)
+	go func() {
+		defer close(out)
+		var e sseEvent
+		for {
+			line, err := body.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "":
+				out <- e
+				e = sseEvent{}
+			case strings.HasPrefix(line, ":"):
+				e.comment = strings.TrimSpace(line[1:])
+			case strings.HasPrefix(line, "id: "):
+				e.id = line[len("id: "):]
+			case strings.HasPrefix(line, "event: "):
+				e.event = line[len("event: "):]
+			case strings.HasPrefix(line, "data: "):
+				e.data = line[len("data: "):]
+			}
+		}
+	}()
+	return out
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("stream ended")
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return sseEvent{}
+	}
+}
+
+func openStream(t *testing.T, url, lastEventID string) <-chan sseEvent {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	return readEvents(bufio.NewReader(resp.Body))
+}
+
+func saveTestAssignment(t *testing.T, repo ports.AssignmentRepository, id, vehicleID string) {
+	t.Helper()
+	now := time.Now().UTC()
+	a := models.Assignment{ID: id, VehicleID: vehicleID, RouteID: "R1", StartsAt: now, EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := repo.Save(context.Background(), a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+// publish makes b read the audit trail. Streams opened by openStream are
+// already subscribed, so they receive whatever it publishes.
+func publish(t *testing.T, b *service.AssignmentBroadcaster, want int) {
+	t.Helper()
+	if n, err := b.PollOnce(context.Background()); err != nil || n != want {
+		t.Fatalf("PollOnce: expected %d changes, got %d (err %v)", want, n, err)
+	}
+}
+
+func TestStreamAssignmentsServerSentEvents(t *testing.T) {
+	repo := repository.NewMemoryAssignmentRepository()
+	b := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{})
+	publish(t, b, 0)
+	srv := newStreamServer(t, b, time.Hour)
+
+	events := openStream(t, srv.URL+"/assignments/stream?vehicleId=V1", "")
+	saveTestAssignment(t, repo, "A1", "V2")
+	saveTestAssignment(t, repo, "A2", "V1")
+	publish(t, b, 2)
+
+	e := nextEvent(t, events)
+	if e.id != "2" || e.event != string(models.AuditActionCreated) {
+		t.Fatalf("expected the creation of A2, got %+v", e)
+	}
+	var entry api.AuditEntry
+	if err := json.Unmarshal([]byte(e.data), &entry); err != nil {
+		t.Fatalf("decode data %q: %v", e.data, err)
+	}
+	if *entry.After.Metadata.Id != "A2" || entry.After.VehicleId != "V1" {
+		t.Fatalf("unexpected entry %s", e.data)
+	}
+
+	// A reconnecting client gets what it missed, then live changes.
+	resumed := openStream(t, srv.URL+"/assignments/stream", "1")
+	saveTestAssignment(t, repo, "A3", "V3")
+	publish(t, b, 1)
+	for _, want := range []string{"2", "3"} {
+		if e := nextEvent(t, resumed); e.id != want {
+			t.Fatalf("expected event %s, got %+v", want, e)
+		}
+	}
+
+	// A replica that just started cannot replay anything.
+	fresh := newStreamServer(t, service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{}), time.Hour)
+	stale := openStream(t, fresh.URL+"/assignments/stream?lastEventId=1", "")
+	if e := nextEvent(t, stale); e.event != "reset" || e.id != "" {
+		t.Fatalf("expected a reset event, got %+v", e)
+	}
+}
+
+func TestStreamAssignmentsHeartbeat(t *testing.T) {
+	b := service.NewAssignmentBroadcaster(repository.NewMemoryAssignmentRepository(), service.AssignmentBroadcasterOptions{})
+	publish(t, b, 0)
+	srv := newStreamServer(t, b, 20*time.Millisecond)
+
+	if e := nextEvent(t, openStream(t, srv.URL+"/assignments/stream", "")); e.comment != "heartbeat" {
+		t.Fatalf("expected a heartbeat, got %+v", e)
+	}
+}
+
+func TestStreamAssignmentsRejectsInvalidLastEventID(t *testing.T) {
+	b := service.NewAssignmentBroadcaster(repository.NewMemoryAssignmentRepository(), service.AssignmentBroadcasterOptions{})
+	srv := newStreamServer(t, b, time.Hour)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/assignments/stream", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestStreamAssignmentsWebSocket(t *testing.T) {
+	repo := repository.NewMemoryAssignmentRepository()
+	b := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{})
+	publish(t, b, 0)
+	saveTestAssignment(t, repo, "A1", "V1")
+	publish(t, b, 1)
+	srv := newStreamServer(t, b, time.Hour)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/assignments/stream?status=pending&lastEventId=1"
+	ws, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer ws.Close()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	saveTestAssignment(t, repo, "A2", "V2")
+	publish(t, b, 1)
+
+	var msg struct {
+		ID    string         `json:"id"`
+		Event string         `json:"event"`
+		Data  api.AuditEntry `json:"data"`
+	}
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if msg.ID != "2" || msg.Event != string(models.AuditActionCreated) || *msg.Data.After.Metadata.Id != "A2" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+}
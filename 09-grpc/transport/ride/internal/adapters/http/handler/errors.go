@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// writeError maps domain errors onto the response bodies declared in
+// api/openapi.yaml. Anything unclassified becomes an opaque 500 so internal
+// details never leak to clients.
+func writeError(c *gin.Context, err error) {
+	_ = c.Error(err) // keep the cause visible to logging middleware
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		errorBody(c, http.StatusRequestEntityTooLarge, "request body too large",
+			fmt.Sprintf("the body of this request may have at most %d bytes", tooLarge.Limit))
+	case errors.Is(err, models.ErrValidation):
+		badRequest(c, "validation failed", models.ErrorMessage(err, err.Error()))
+	case errors.Is(err, models.ErrNotFound):
+		notFound(c, models.ErrorMessage(err, "resource not found"))
+	case errors.Is(err, models.ErrConflict):
+		errorBody(c, http.StatusConflict, "conflict", models.ErrorMessage(err, ""))
+	case errors.Is(err, models.ErrPreconditionFailed):
+		errorBody(c, http.StatusPreconditionFailed, "precondition failed", models.ErrorMessage(err, ""))
+	case errors.Is(err, models.ErrUnavailable):
+		errorBody(c, http.StatusServiceUnavailable, "service unavailable", models.ErrorMessage(err, ""))
+	default:
+		c.JSON(http.StatusInternalServerError, api.Error{Error: "internal server error"})
+	}
+}
+
+// ParamErrorHandler renders parameter binding failures reported by the
+// generated wrapper in the spec's BadRequest shape instead of {"msg": ...}.
+func ParamErrorHandler(c *gin.Context, err error, statusCode int) {
+	msg := http.StatusText(statusCode)
+	details := err.Error()
+	c.JSON(statusCode, api.BadRequest{Error: &msg, Details: &details})
+}
+
+func badRequest(c *gin.Context, msg, details string) {
+	c.JSON(http.StatusBadRequest, api.BadRequest{Error: &msg, Details: &details})
+}
+
+func notFound(c *gin.Context, msg string) {
+	c.JSON(http.StatusNotFound, api.NotFound{Error: &msg})
+}
+
+func errorBody(c *gin.Context, status int, msg, details string) {
+	body := api.Error{Error: msg}
+	if details != "" {
+		body.Details = &details
+	}
+	c.JSON(status, body)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// assignmentETag is a strong validator derived from the row version.
+func assignmentETag(a models.Assignment) string {
+	return `"` + strconv.FormatInt(a.Version, 10) + `"`
+}
+
+// etagMatches reports whether header (an If-Match or If-None-Match value)
+// lists etag. If-Match uses strong comparison, so weak tags never match it;
+// If-None-Match uses weak comparison (RFC 9110, section 13.1).
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// RecurringAssignmentHandler is the HTTP adapter for /recurring-assignments.
+type RecurringAssignmentHandler struct {
+	service ports.RecurringAssignmentService
+}
+
+func NewRecurringAssignmentHandler(service ports.RecurringAssignmentService) *RecurringAssignmentHandler {
+	return &RecurringAssignmentHandler{service: service}
+}
+
+func (h *RecurringAssignmentHandler) ListRecurringAssignments(c *gin.Context) {
+	rules, err := h.service.List(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	out := make([]api.RecurringAssignment, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, converter.RecurringAssignmentFromDomain(r))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *RecurringAssignmentHandler) CreateRecurringAssignment(c *gin.Context) {
+	var body api.CreateRecurringAssignmentJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	saved, err := h.service.Save(c.Request.Context(), converter.NewRecurringAssignmentToDomain(body))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", assignmentLocation(c, saved.ID))
+	c.JSON(http.StatusCreated, converter.RecurringAssignmentFromDomain(saved))
+}
+
+func (h *RecurringAssignmentHandler) GetRecurringAssignment(c *gin.Context, id string) {
+	r, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, converter.RecurringAssignmentFromDomain(r))
+}
+
+func (h *RecurringAssignmentHandler) UpdateRecurringAssignment(c *gin.Context, id string) {
+	var body api.UpdateRecurringAssignmentJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	r := converter.NewRecurringAssignmentToDomain(body)
+	r.ID = id
+	updated, err := h.service.Update(c.Request.Context(), r)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, converter.RecurringAssignmentFromDomain(updated))
+}
+
+func (h *RecurringAssignmentHandler) DeleteRecurringAssignment(c *gin.Context, id string) {
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
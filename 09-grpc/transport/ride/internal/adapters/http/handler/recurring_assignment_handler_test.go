@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+)
+
+func TestRecurringAssignmentLifecycle(t *testing.T) {
+	repo := newFakeRepository()
+	router := newRouter(repo)
+	body := `{"vehicleId":"V1","routeId":"R1","rrule":"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR","timezone":"Europe/Berlin",` +
+		`"startsAt":"2031-03-03T07:30:00+01:00","durationMinutes":45,"exceptions":["2031-03-05"],"endsOn":"2031-12-19"}`
+
+	rec := serve(router, http.MethodPost, "/recurring-assignments", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	location := rec.Header().Get("Location")
+	if !strings.HasPrefix(location, "/recurring-assignments/") {
+		t.Fatalf("unexpected Location %q", location)
+	}
+	var created api.RecurringAssignment
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if created.DurationMinutes != 45 || len(created.Exceptions) != 1 || created.EndsOn == nil {
+		t.Fatalf("unexpected rule %s", rec.Body.String())
+	}
+
+	if rec := serve(router, http.MethodGet, "/recurring-assignments", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), *created.Metadata.Id) {
+		t.Fatalf("list: expected the rule, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	updated := strings.Replace(body, `"durationMinutes":45`, `"durationMinutes":60`, 1)
+	if rec := serve(router, http.MethodPut, location, updated); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"durationMinutes":60`) {
+		t.Fatalf("update: expected 200 with new duration, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serve(router, http.MethodDelete, location, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(router, http.MethodGet, location, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("get after delete: expected 404, got %d", rec.Code)
+	}
+}
+
+func TestCreateRecurringAssignmentRejectsInvalidRule(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "malformed rrule",
+			body: `{"vehicleId":"V1","routeId":"R1","rrule":"EVERY=MONDAY","timezone":"UTC","startsAt":"2031-03-03T07:30:00Z","durationMinutes":30}`,
+		},
+		{
+			name: "unknown timezone",
+			body: `{"vehicleId":"V1","routeId":"R1","rrule":"FREQ=DAILY","timezone":"Nowhere/Town","startsAt":"2031-03-03T07:30:00Z","durationMinutes":30}`,
+		},
+		{
+			name: "duration out of range",
+			body: `{"vehicleId":"V1","routeId":"R1","rrule":"FREQ=DAILY","timezone":"UTC","startsAt":"2031-03-03T07:30:00Z","durationMinutes":0}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newRouter(newFakeRepository()), http.MethodPost, "/recurring-assignments", tc.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import "github.com/yourname/transport/ride/internal/adapters/http/api"
+
+// Server implements api.ServerInterface by combining the handlers of each
+// resource.
+type Server struct {
+	*AssignmentHandler
+	*RecurringAssignmentHandler
+	*WebhookHandler
+	*AssignmentStreamHandler
+}
+
+func NewServer(assignments *AssignmentHandler, recurring *RecurringAssignmentHandler, webhooks *WebhookHandler, stream *AssignmentStreamHandler) *Server {
+	return &Server{
+		AssignmentHandler:          assignments,
+		RecurringAssignmentHandler: recurring,
+		WebhookHandler:             webhooks,
+		AssignmentStreamHandler:    stream,
+	}
+}
+
+// Ensure we implement the generated interface
+var _ api.ServerInterface = (*Server)(nil)
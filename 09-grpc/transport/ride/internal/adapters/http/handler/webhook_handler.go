@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/converter"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// WebhookHandler is the HTTP adapter for /webhooks.
+type WebhookHandler struct {
+	service ports.WebhookService
+}
+
+func NewWebhookHandler(service ports.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.service.List(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	out := make([]api.Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		out = append(out, converter.WebhookFromDomain(w))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var body api.CreateWebhookJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	saved, err := h.service.Save(c.Request.Context(), converter.NewWebhookToDomain(body))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", assignmentLocation(c, saved.ID))
+	c.JSON(http.StatusCreated, converter.WebhookFromDomain(saved))
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context, id string) {
+	w, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, converter.WebhookFromDomain(w))
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context, id string) {
+	var body api.UpdateWebhookJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		badRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	w := converter.NewWebhookToDomain(body)
+	w.ID = id
+	updated, err := h.service.Update(c.Request.Context(), w)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, converter.WebhookFromDomain(updated))
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context, id string) {
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context, id string, params api.ListWebhookDeliveriesParams) {
+	q := models.WebhookDeliveryQuery{WebhookID: id}
+	if params.Status != nil {
+		status := models.WebhookDeliveryStatus(*params.Status)
+		q.Status = &status
+	}
+	if params.Limit != nil {
+		q.Limit = *params.Limit
+	}
+
+	deliveries, err := h.service.Deliveries(c.Request.Context(), q)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	out := make([]api.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, converter.WebhookDeliveryFromDomain(d))
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+)
+
+func TestWebhookLifecycle(t *testing.T) {
+	router := newRouter(newFakeRepository())
+	body := `{"url":"https://partner.example/hooks","eventTypes":["AssignmentStatusChanged","AssignmentCreated"],"secret":"0123456789abcdef"}`
+
+	rec := serve(router, http.MethodPost, "/webhooks", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	location := rec.Header().Get("Location")
+	if !strings.HasPrefix(location, "/webhooks/") {
+		t.Fatalf("unexpected Location %q", location)
+	}
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Fatalf("the secret must not be returned: %s", rec.Body.String())
+	}
+	var created api.Webhook
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if !created.Enabled || len(created.EventTypes) != 2 || created.EventTypes[0] != "AssignmentCreated" {
+		t.Fatalf("unexpected webhook %s", rec.Body.String())
+	}
+
+	if rec := serve(router, http.MethodGet, "/webhooks", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), *created.Metadata.Id) {
+		t.Fatalf("list: expected the webhook, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	disabled := `{"url":"https://partner.example/v2","secret":"0123456789abcdef","enabled":false}`
+	rec = serve(router, http.MethodPut, location, disabled)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"enabled":false`) || !strings.Contains(rec.Body.String(), `"eventTypes":[]`) {
+		t.Fatalf("update: expected a disabled webhook for all events, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serve(router, http.MethodGet, location+"/deliveries?status=failed&limit=10", ""); rec.Code != http.StatusOK || rec.Body.String() != "[]" {
+		t.Fatalf("deliveries: expected an empty log, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serve(router, http.MethodDelete, location, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(router, http.MethodGet, location, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("get after delete: expected 404, got %d", rec.Code)
+	}
+	if rec := serve(router, http.MethodGet, location+"/deliveries", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("deliveries after delete: expected 404, got %d", rec.Code)
+	}
+}
+
+func TestCreateWebhookRejectsInvalidInput(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "relative url",
+			body: `{"url":"/hooks","secret":"0123456789abcdef"}`,
+		},
+		{
+			name: "unsupported scheme",
+			body: `{"url":"ftp://partner.example/hooks","secret":"0123456789abcdef"}`,
+		},
+		{
+			name: "short secret",
+			body: `{"url":"https://partner.example/hooks","secret":"short"}`,
+		},
+		{
+			name: "missing secret",
+			body: `{"url":"https://partner.example/hooks"}`,
+		},
+		{
+			name: "unknown event type",
+			body: `{"url":"https://partner.example/hooks","secret":"0123456789abcdef","eventTypes":["AssignmentDeleted"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newRouter(newFakeRepository()), http.MethodPost, "/webhooks", tc.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// ActorHeader identifies the caller on mutating requests.
+const ActorHeader = "X-Actor-ID"
+
+// Actor copies the caller identity from the ActorHeader into the request
+// context so services can record who made a change. The header is trusted
+// as-is; with Auth enabled the token subject replaces it.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := c.GetHeader(ActorHeader); actor != "" {
+			c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/tenancy"
+)
+
+// bearerScheme is the security scheme of the spec the middleware enforces.
+const bearerScheme = "bearerAuth"
+
+type AuthOptions struct {
+	// RequireTenantClaim refuses tokens without a tenant claim; otherwise
+	// they act for the default tenant.
+	RequireTenantClaim bool
+}
+
+// Auth authenticates requests with the bearer token in the Authorization
+// header and enforces the scopes the spec lists for each operation. A
+// missing or invalid token is answered with 401, a token lacking the scopes
+// with 403, both with a WWW-Authenticate challenge. On success the claims
+// go into the request context, the token subject becomes the actor, and the
+// request is pinned to the tenant of the token (see tenancy.OfClaims) for
+// the Tenant middleware; with RequireTenantClaim a token without a tenant
+// claim is answered with 401. Requests for paths that are not in the spec,
+// or for operations without security requirements, are passed through
+// untouched.
+func Auth(verifier ports.TokenVerifier, opts AuthOptions) (gin.HandlerFunc, error) {
+	_, router, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route, _, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+		requirements := route.Spec.Security
+		if route.Operation.Security != nil {
+			requirements = *route.Operation.Security
+		}
+		if len(requirements) == 0 {
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="ride"`)
+			abortWithError(c, http.StatusUnauthorized, "unauthorized", "a bearer token is required")
+			return
+		}
+		claims, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="ride", error="invalid_token"`)
+			abortWithError(c, http.StatusUnauthorized, "unauthorized", err.Error())
+			return
+		}
+		if missing, ok := missingScopes(requirements, claims); !ok {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer realm="ride", error="insufficient_scope", scope=%q`, missing))
+			abortWithError(c, http.StatusForbidden, "forbidden", "token lacks scope "+missing)
+			return
+		}
+
+		tenant, err := tenancy.OfClaims(claims, opts.RequireTenantClaim)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="ride", error="invalid_token"`)
+			abortWithError(c, http.StatusUnauthorized, "unauthorized", err.Error())
+			return
+		}
+
+		ctx := requestctx.WithClaims(c.Request.Context(), claims)
+		ctx = requestctx.WithActor(ctx, claims.Subject)
+		ctx = requestctx.WithTenant(ctx, tenant)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}, nil
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// missingScopes reports whether the claims satisfy one of the requirements
+// and, when they do not, the scopes of the first one, for the challenge.
+func missingScopes(requirements openapi3.SecurityRequirements, claims requestctx.Claims) (string, bool) {
+	var first []string
+	for i, req := range requirements {
+		scopes, ok := req[bearerScheme]
+		if !ok {
+			continue
+		}
+		if i == 0 {
+			first = scopes
+		}
+		satisfied := true
+		for _, s := range scopes {
+			if !claims.HasScope(s) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return "", true
+		}
+	}
+	return strings.Join(first, " "), false
+}
@@ -0,0 +1,99 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// fakeVerifier accepts the tokens it knows.
+type fakeVerifier map[string]requestctx.Claims
+
+func (f fakeVerifier) Verify(ctx context.Context, token string) (requestctx.Claims, error) {
+	claims, ok := f[token]
+	if !ok {
+		return requestctx.Claims{}, errors.New("token is expired")
+	}
+	return claims, nil
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	verifier := fakeVerifier{
+		"reader":   {Subject: "ops-1", Scopes: []string{"assignments:read"}},
+		"writer":   {Subject: "ops-2", Scopes: []string{"assignments:read", "assignments:write"}},
+		"parisian": {Subject: "ops-3", Scopes: []string{"assignments:read"}, Tenant: "paris"},
+	}
+
+	testCases := []struct {
+		name          string
+		method, path  string
+		authorization string
+		tenantHeader  string
+		requireTenant bool
+		wantStatus    int
+		wantChallenge string
+		wantActor     string
+		wantTenant    string
+	}{
+		{name: "no token", method: http.MethodGet, path: "/assignments", wantStatus: http.StatusUnauthorized, wantChallenge: `Bearer realm="ride"`},
+		{name: "other scheme", method: http.MethodGet, path: "/assignments", authorization: "Basic b3BzOnB3", wantStatus: http.StatusUnauthorized, wantChallenge: `Bearer realm="ride"`},
+		{name: "invalid token", method: http.MethodGet, path: "/assignments", authorization: "Bearer stale", wantStatus: http.StatusUnauthorized, wantChallenge: `error="invalid_token"`},
+		{name: "missing scope", method: http.MethodPost, path: "/assignments", authorization: "Bearer reader", wantStatus: http.StatusForbidden, wantChallenge: `scope="assignments:write"`},
+		{name: "read scope", method: http.MethodGet, path: "/assignments", authorization: "Bearer reader", wantStatus: http.StatusOK, wantActor: "ops-1", wantTenant: requestctx.DefaultTenant},
+		{name: "write scope", method: http.MethodPost, path: "/assignments", authorization: "bearer writer", wantStatus: http.StatusOK, wantActor: "ops-2", wantTenant: requestctx.DefaultTenant},
+		{name: "token pins the tenant", method: http.MethodGet, path: "/assignments", authorization: "Bearer parisian", wantStatus: http.StatusOK, wantActor: "ops-3", wantTenant: "paris"},
+		{name: "header contradicts the token", method: http.MethodGet, path: "/assignments", authorization: "Bearer parisian", tenantHeader: requestctx.DefaultTenant, wantStatus: http.StatusForbidden},
+		{name: "header cannot stand in for the token", method: http.MethodGet, path: "/assignments", authorization: "Bearer reader", tenantHeader: "paris", wantStatus: http.StatusForbidden},
+		{name: "tenant claim required", method: http.MethodGet, path: "/assignments", authorization: "Bearer reader", tenantHeader: requestctx.DefaultTenant, requireTenant: true, wantStatus: http.StatusUnauthorized, wantChallenge: `error="invalid_token"`},
+		{name: "required tenant claim present", method: http.MethodGet, path: "/assignments", authorization: "Bearer parisian", requireTenant: true, wantStatus: http.StatusOK, wantActor: "ops-3", wantTenant: "paris"},
+		{name: "path outside the spec", method: http.MethodGet, path: "/internal/ping", wantStatus: http.StatusOK, wantActor: requestctx.AnonymousActor, wantTenant: requestctx.DefaultTenant},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth, err := middleware.Auth(verifier, middleware.AuthOptions{RequireTenantClaim: tc.requireTenant})
+			if err != nil {
+				t.Fatalf("Auth: %v", err)
+			}
+			router := gin.New()
+			router.Use(auth, middleware.Tenant([]string{requestctx.DefaultTenant, "paris"}))
+			var actor, tenant string
+			handle := func(c *gin.Context) {
+				actor = requestctx.Actor(c.Request.Context())
+				tenant = requestctx.Tenant(c.Request.Context())
+				c.Status(http.StatusOK)
+			}
+			router.GET("/assignments", handle)
+			router.POST("/assignments", handle)
+			router.GET("/internal/ping", handle)
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			if tc.tenantHeader != "" {
+				req.Header.Set(middleware.TenantHeader, tc.tenantHeader)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if challenge := rec.Header().Get("WWW-Authenticate"); !strings.Contains(challenge, tc.wantChallenge) {
+				t.Fatalf("expected a challenge containing %q, got %q", tc.wantChallenge, challenge)
+			}
+			if actor != tc.wantActor || tenant != tc.wantTenant {
+				t.Fatalf("expected actor %q and tenant %q, got %q and %q", tc.wantActor, tc.wantTenant, actor, tenant)
+			}
+		})
+	}
+}
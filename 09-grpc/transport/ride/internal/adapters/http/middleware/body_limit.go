@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultMaxBodyBytes       = 1 << 20
+	DefaultMaxImportBodyBytes = 16 << 20
+)
+
+type BodyLimitOptions struct {
+	// Default bounds the body of every operation; DefaultMaxBodyBytes when
+	// zero.
+	Default int64
+	// Operations overrides Default per operationId.
+	Operations map[string]int64
+}
+
+// BodyLimit answers requests whose body is larger than the limit of their
+// operation with 413. A declared Content-Length is checked up front; other
+// bodies are cut off by http.MaxBytesReader once they go over, and whoever
+// reads them gets a *http.MaxBytesError to report with IsBodyTooLarge. It
+// must run before anything that reads the body, the validator and
+// Idempotency included. Requests for paths that are not in the spec are
+// passed through untouched. It fails when opts has a limit for an operation
+// the spec does not know.
+func BodyLimit(opts BodyLimitOptions) (gin.HandlerFunc, error) {
+	if opts.Default <= 0 {
+		opts.Default = DefaultMaxBodyBytes
+	}
+	swagger, router, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+	known := operationIDs(swagger)
+	for op, limit := range opts.Operations {
+		if !known[op] {
+			return nil, fmt.Errorf("body limit for unknown operation %q", op)
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("body limit for %s must be positive", op)
+		}
+	}
+
+	return func(c *gin.Context) {
+		route, _, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+		limit := opts.Default
+		if l, ok := opts.Operations[operationID(route.Operation)]; ok {
+			limit = l
+		}
+		if c.Request.ContentLength > limit {
+			abortBodyTooLarge(c, limit)
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}, nil
+}
+
+// abortIfBodyTooLarge answers 413 when err comes from reading a body
+// BodyLimit cut off.
+func abortIfBodyTooLarge(c *gin.Context, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return false
+	}
+	abortBodyTooLarge(c, tooLarge.Limit)
+	return true
+}
+
+func abortBodyTooLarge(c *gin.Context, limit int64) {
+	abortWithError(c, http.StatusRequestEntityTooLarge, "request body too large",
+		fmt.Sprintf("the body of this request may have at most %d bytes", limit))
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	DefaultIdempotencyKeysTTL = 24 * time.Hour
+)
+
+// replayedHeaders are the response headers stored with a key and restored on
+// replay; everything else is regenerated by the server.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency makes POST requests that carry an Idempotency-Key safe to retry.
+// The first request with a key runs normally and its response is stored; an
+// identical retry gets that response back, a retry with a different body is
+// rejected with 422, and a retry that races the first one gets 409.
+// Requests that fail with 5xx or panic release the key so the client can try
+// again.
+func Idempotency(store ports.IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeysTTL
+	}
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, http.StatusBadRequest, "invalid Idempotency-Key", "key must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			if abortIfBodyTooLarge(c, err) {
+				return
+			}
+			abortWithError(c, http.StatusBadRequest, "invalid request body", err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := fingerprintRequest(c.Request, body)
+
+		rec, reserved, err := store.Reserve(c.Request.Context(), key, fingerprint, ttl)
+		switch {
+		case errors.Is(err, models.ErrConflict):
+			abortWithError(c, http.StatusConflict, "conflict", models.ErrorMessage(err, ""))
+			return
+		case err != nil:
+			_ = c.Error(err)
+			abortWithError(c, http.StatusServiceUnavailable, "service unavailable", "idempotency store unavailable")
+			return
+		}
+
+		if !reserved {
+			switch {
+			case rec.Fingerprint != fingerprint:
+				abortWithError(c, http.StatusUnprocessableEntity, "idempotency key reused",
+					"Idempotency-Key was already used with a different request")
+			case !rec.Completed:
+				abortWithError(c, http.StatusConflict, "conflict",
+					"a request with this Idempotency-Key is still in progress")
+			default:
+				replay(c, rec.Response)
+			}
+			return
+		}
+
+		// Store the outcome even if the client has gone away: the retry it is
+		// about to send must see this response.
+		ctx := context.WithoutCancel(c.Request.Context())
+		release := func() {
+			if err := store.Release(ctx, key); err != nil {
+				log.Printf("idempotency: release key %q: %v", key, err)
+			}
+		}
+		// A panicking handler would otherwise leave the key in progress, and
+		// every retry would get 409 until it expires.
+		defer func() {
+			if p := recover(); p != nil {
+				release()
+				panic(p)
+			}
+		}()
+
+		rw := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = rw
+		c.Next()
+
+		if rw.Status() >= http.StatusInternalServerError {
+			release()
+			return
+		}
+		resp := models.StoredResponse{
+			StatusCode: rw.Status(),
+			Header:     map[string]string{},
+			Body:       rw.body.Bytes(),
+		}
+		for _, h := range replayedHeaders {
+			if v := rw.Header().Get(h); v != "" {
+				resp.Header[h] = v
+			}
+		}
+		if err := store.Complete(ctx, key, resp); err != nil {
+			log.Printf("idempotency: complete key %q: %v", key, err)
+		}
+	}
+}
+
+// fingerprintRequest identifies a request by method, path and body so that a
+// key cannot be replayed against a different operation.
+func fingerprintRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.Path)
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(c *gin.Context, resp models.StoredResponse) {
+	for k, v := range resp.Header {
+		c.Header(k, v)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(resp.StatusCode)
+	_, _ = c.Writer.Write(resp.Body)
+	c.Abort()
+}
+
+func abortWithError(c *gin.Context, status int, msg, details string) {
+	body := api.Error{Error: msg}
+	if details != "" {
+		body.Details = &details
+	}
+	c.AbortWithStatusJSON(status, body)
+}
+
+// capturingWriter keeps a copy of the response body for the store.
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,165 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// memoryStore is an in-memory ports.IdempotencyStore.
+type memoryStore struct {
+	mu   sync.Mutex
+	recs map[string]models.IdempotencyRecord
+}
+
+func (m *memoryStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if rec, ok := m.recs[key]; ok {
+		return rec, false, nil
+	}
+	rec := models.IdempotencyRecord{Key: key, Fingerprint: fingerprint, ExpiresAt: time.Now().Add(ttl)}
+	m.recs[key] = rec
+	return rec, true, nil
+}
+
+func (m *memoryStore) Complete(ctx context.Context, key string, resp models.StoredResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec := m.recs[key]
+	rec.Completed, rec.Response = true, resp
+	m.recs[key] = rec
+	return nil
+}
+
+func (m *memoryStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.recs, key)
+	return nil
+}
+
+func (m *memoryStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &memoryStore{recs: map[string]models.IdempotencyRecord{}}
+	calls, fail := 0, false
+
+	router := gin.New()
+	router.Use(middleware.Idempotency(store, time.Hour))
+	router.POST("/assignments", func(c *gin.Context) {
+		calls++
+		if fail {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "down"})
+			return
+		}
+		c.Header("Location", "/assignments/A1")
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/assignments", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := post("K1", `{"vehicleId":"V1"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", first.Code)
+	}
+
+	testCases := []struct {
+		name         string
+		key          string
+		body         string
+		wantStatus   int
+		wantReplayed bool
+	}{
+		{name: "identical retry is replayed", key: "K1", body: `{"vehicleId":"V1"}`, wantStatus: http.StatusCreated, wantReplayed: true},
+		{name: "reused key with different body", key: "K1", body: `{"vehicleId":"V2"}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "key too long", key: strings.Repeat("k", 256), body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "no key runs handler", body: `{"vehicleId":"V1"}`, wantStatus: http.StatusCreated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := post(tc.key, tc.body)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			replayed := rec.Header().Get(middleware.IdempotentReplayedHeader) == "true"
+			if replayed != tc.wantReplayed {
+				t.Fatalf("expected replayed=%v, got %v", tc.wantReplayed, replayed)
+			}
+			if tc.wantReplayed {
+				if rec.Body.String() != first.Body.String() || rec.Header().Get("Location") != "/assignments/A1" {
+					t.Fatalf("replay differs from original: %s %v", rec.Body.String(), rec.Header())
+				}
+			}
+		})
+	}
+	if calls != 2 {
+		t.Fatalf("expected handler to run twice, ran %d times", calls)
+	}
+
+	// Server errors release the key so the client can retry.
+	fail = true
+	if rec := post("K2", `{}`); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	fail = false
+	if rec := post("K2", `{}`); rec.Code != http.StatusCreated || rec.Header().Get(middleware.IdempotentReplayedHeader) != "" {
+		t.Fatalf("expected fresh 201 after released key, got %d", rec.Code)
+	}
+
+	// A panicking handler releases the key too; the panic still reaches the
+	// recovery middleware.
+	panicking := gin.New()
+	panicking.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	panicking.Use(middleware.Idempotency(store, time.Hour))
+	panicking.POST("/assignments", func(c *gin.Context) { panic("boom") })
+	req := httptest.NewRequest(http.MethodPost, "/assignments", strings.NewReader(`{}`))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "K4")
+	rec := httptest.NewRecorder()
+	panicking.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 from recovery, got %d", rec.Code)
+	}
+	if rec := post("K4", `{}`); rec.Code != http.StatusCreated || rec.Header().Get(middleware.IdempotentReplayedHeader) != "" {
+		t.Fatalf("expected fresh 201 after panic released key, got %d", rec.Code)
+	}
+
+	// A retry that arrives while the first request is still running is a
+	// conflict rather than a second execution.
+	var inner int
+	router.POST("/slow", func(c *gin.Context) {
+		req := httptest.NewRequest(http.MethodPost, "/slow", strings.NewReader(`{}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "K3")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		inner = rec.Code
+		c.Status(http.StatusNoContent)
+	})
+	req = httptest.NewRequest(http.MethodPost, "/slow", strings.NewReader(`{}`))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "K3")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if inner != http.StatusConflict {
+		t.Fatalf("expected 409 for in-flight key, got %d", inner)
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+)
+
+// LoadShedder answers the API requests shedder turns away with 503 and
+// Retry-After. Requests for paths that are not in the spec are passed
+// through untouched. It fails when shedder exempts an operation the spec
+// does not know.
+func LoadShedder(shedder *admission.LoadShedder) (gin.HandlerFunc, error) {
+	swagger, router, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+	known := operationIDs(swagger)
+	for _, op := range shedder.Exempt() {
+		if !known[op] {
+			return nil, fmt.Errorf("unknown exempt operation %q", op)
+		}
+	}
+
+	return func(c *gin.Context) {
+		route, _, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+		release, ok := shedder.Admit(operationID(route.Operation))
+		if !ok {
+			c.Header("Retry-After", "1")
+			abortWithError(c, http.StatusServiceUnavailable, "service unavailable", "too many requests in flight; retry later")
+			return
+		}
+		defer release()
+		c.Next()
+	}, nil
+}
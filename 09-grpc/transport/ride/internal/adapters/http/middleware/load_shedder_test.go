@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+)
+
+func TestLoadShedder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	shedder, err := admission.NewLoadShedder(admission.LoadShedderOptions{
+		MaxInFlight: 1,
+		Exempt:      []string{"streamAssignments"},
+		Registerer:  reg,
+	})
+	if err != nil {
+		t.Fatalf("NewLoadShedder: %v", err)
+	}
+	shed, err := middleware.LoadShedder(shedder)
+	if err != nil {
+		t.Fatalf("LoadShedder: %v", err)
+	}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	router := gin.New()
+	router.Use(shed)
+	router.GET("/assignments", func(c *gin.Context) {
+		if c.Query("block") != "" {
+			entered <- struct{}{}
+			<-release
+		}
+		c.Status(http.StatusOK)
+	})
+	router.GET("/assignments/stream", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	blocked := make(chan int)
+	go func() { blocked <- get("/assignments?block=1").Code }()
+	<-entered
+
+	rec := get("/assignments")
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected 503 with Retry-After while the slot is taken, got %d %v", rec.Code, rec.Header())
+	}
+	if rec := get("/assignments/stream"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the exempt stream to pass, got %d", rec.Code)
+	}
+
+	close(release)
+	if code := <-blocked; code != http.StatusOK {
+		t.Fatalf("expected the blocked request to finish, got %d", code)
+	}
+	if rec := get("/assignments"); rec.Code != http.StatusOK {
+		t.Fatalf("expected the freed slot to be reused, got %d", rec.Code)
+	}
+
+	if got := decisions(t, reg, "ride_http_load_shedder_decisions_total", "listAssignments", "shed"); got != 1 {
+		t.Errorf("expected 1 shed request, got %v", got)
+	}
+	if got := decisions(t, reg, "ride_http_load_shedder_decisions_total", "listAssignments", "admitted"); got != 2 {
+		t.Errorf("expected 2 admitted requests, got %v", got)
+	}
+}
+
+func TestLoadShedderRejectsBadOptions(t *testing.T) {
+	if _, err := admission.NewLoadShedder(admission.LoadShedderOptions{}); err == nil {
+		t.Fatal("expected an error without a bound")
+	}
+	shedder, err := admission.NewLoadShedder(admission.LoadShedderOptions{MaxInFlight: 1, Exempt: []string{"stream"}})
+	if err != nil {
+		t.Fatalf("NewLoadShedder: %v", err)
+	}
+	if _, err := middleware.LoadShedder(shedder); err == nil {
+		t.Fatal("expected an error for an unknown exempt operation")
+	}
+}
@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+)
+
+func init() {
+	// kin-openapi decodes text/csv but not NDJSON; the spec declares both
+	// as plain strings.
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson", openapi3filter.PlainBodyDecoder)
+}
+
+type OpenAPIValidatorOptions struct {
+	// ValidateResponses buffers every response and checks it against the
+	// spec too, answering 500 when the handler produced something the spec
+	// does not allow. Meant for tests: it costs a copy of every body.
+	ValidateResponses bool
+}
+
+// OpenAPIValidator checks requests against the spec embedded in server.gen.go
+// (path and query parameters, headers and JSON bodies, including enums,
+// required fields and formats) and rejects invalid ones with the spec's
+// BadRequest body before they reach a handler. Requests for paths that are
+// not in the spec, such as /healthz, are passed through untouched.
+func OpenAPIValidator(opts OpenAPIValidatorOptions) (gin.HandlerFunc, error) {
+	_, router, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	filterOpts := &openapi3filter.Options{
+		MultiError:            true,
+		SkipSettingDefaults:   true, // defaults are the service's business
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			// Unknown path or method: let gin answer 404/405.
+			c.Next()
+			return
+		}
+
+		reqInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    filterOpts,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), reqInput); err != nil {
+			if abortIfBodyTooLarge(c, err) {
+				return
+			}
+			msg := "request validation failed"
+			details := describeValidationError(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, api.BadRequest{Error: &msg, Details: &details})
+			return
+		}
+
+		if !opts.ValidateResponses {
+			c.Next()
+			return
+		}
+
+		rw := &bufferingWriter{ResponseWriter: c.Writer}
+		c.Writer = rw
+		c.Next()
+		c.Writer = rw.ResponseWriter
+
+		err = openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: reqInput,
+			Status:                 rw.Status(),
+			Header:                 rw.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(rw.body.Bytes())),
+			Options:                filterOpts,
+		})
+		if err != nil {
+			log.Printf("openapi: %s %s: response does not match spec: %v", c.Request.Method, route.Path, err)
+			rw.Header().Del("Content-Length")
+			c.JSON(http.StatusInternalServerError, api.Error{Error: "response does not match spec", Details: ptr(err.Error())})
+			return
+		}
+		_, _ = rw.ResponseWriter.Write(rw.body.Bytes())
+	}, nil
+}
+
+// loadSpec returns the spec embedded in server.gen.go and a router matching
+// requests to its operations.
+func loadSpec() (*openapi3.T, routers.Router, error) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load embedded spec: %w", err)
+	}
+	// Match on paths only; the servers block names the public URL, not ours.
+	swagger.Servers = nil
+
+	router, err := legacy.NewRouter(swagger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build spec router: %w", err)
+	}
+	return swagger, router, nil
+}
+
+// operationIDs lists the operations of the spec.
+func operationIDs(swagger *openapi3.T) map[string]bool {
+	ids := map[string]bool{}
+	for _, item := range swagger.Paths.Map() {
+		for _, op := range item.Operations() {
+			ids[operationID(op)] = true
+		}
+	}
+	return ids
+}
+
+// operationID returns the operationId as spelled in openapi.yaml; the
+// embedded copy has it capitalized by oapi-codegen.
+func operationID(op *openapi3.Operation) string {
+	r, size := utf8.DecodeRuneInString(op.OperationID)
+	return string(unicode.ToLower(r)) + op.OperationID[size:]
+}
+
+// describeValidationError flattens kin-openapi errors into one line per
+// problem, naming the offending parameter or body field.
+func describeValidationError(err error) string {
+	if multi, ok := err.(openapi3.MultiError); ok {
+		parts := make([]string, 0, len(multi))
+		for _, e := range multi {
+			parts = append(parts, describeValidationError(e))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err.Error()
+	}
+
+	var where string
+	switch {
+	case reqErr.Parameter != nil:
+		where = reqErr.Parameter.In + " parameter " + reqErr.Parameter.Name
+	case reqErr.RequestBody != nil:
+		where = "body"
+	}
+
+	var problems []string
+	var schemaErrs openapi3.MultiError
+	if !errors.As(reqErr.Err, &schemaErrs) {
+		schemaErrs = openapi3.MultiError{reqErr.Err}
+	}
+	for _, e := range schemaErrs {
+		var schemaErr *openapi3.SchemaError
+		switch {
+		case errors.As(e, &schemaErr):
+			field := where
+			if path := strings.Join(schemaErr.JSONPointer(), "."); path != "" {
+				field += " field " + path
+			}
+			problems = append(problems, strings.TrimSpace(field+": "+schemaErr.Reason))
+		case e != nil:
+			problems = append(problems, strings.TrimSpace(where+": "+e.Error()))
+		}
+	}
+	if len(problems) == 0 {
+		return strings.TrimSpace(where + ": " + reqErr.Reason)
+	}
+	return strings.Join(problems, "; ")
+}
+
+func ptr(s string) *string { return &s }
+
+// bufferingWriter holds the response back until it has been validated.
+type bufferingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferingWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferingWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
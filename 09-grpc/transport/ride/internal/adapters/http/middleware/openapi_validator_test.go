@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/api"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+)
+
+func TestOpenAPIValidatorRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator, err := middleware.OpenAPIValidator(middleware.OpenAPIValidatorOptions{})
+	if err != nil {
+		t.Fatalf("OpenAPIValidator: %v", err)
+	}
+	router := gin.New()
+	router.Use(validator)
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/assignments", ok)
+	router.POST("/assignments", ok)
+	router.GET("/healthz", ok)
+
+	testCases := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		wantStatus  int
+		wantDetails string
+	}{
+		{name: "valid list", method: http.MethodGet, target: "/assignments?status=active&limit=10", wantStatus: http.StatusNoContent},
+		{name: "unknown enum value", method: http.MethodGet, target: "/assignments?status=parked", wantStatus: http.StatusBadRequest, wantDetails: "query parameter status"},
+		{name: "limit above maximum", method: http.MethodGet, target: "/assignments?limit=501", wantStatus: http.StatusBadRequest, wantDetails: "query parameter limit"},
+		{name: "malformed date-time", method: http.MethodGet, target: "/assignments?startsFrom=yesterday", wantStatus: http.StatusBadRequest, wantDetails: "startsFrom"},
+		{name: "valid body", method: http.MethodPost, target: "/assignments", body: `{"vehicleId":"V1","routeId":"R1","startsAt":"2025-01-02T08:00:00Z"}`, wantStatus: http.StatusNoContent},
+		{name: "missing required field", method: http.MethodPost, target: "/assignments", body: `{"vehicleId":"V1","startsAt":"2025-01-02T08:00:00Z"}`, wantStatus: http.StatusBadRequest, wantDetails: "routeId"},
+		{name: "bad body date-time", method: http.MethodPost, target: "/assignments", body: `{"vehicleId":"V1","routeId":"R1","startsAt":"soon"}`, wantStatus: http.StatusBadRequest, wantDetails: "body field startsAt"},
+		{name: "path outside the spec", method: http.MethodGet, target: "/healthz", wantStatus: http.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tc.wantDetails == "" {
+				return
+			}
+			var br api.BadRequest
+			if err := json.Unmarshal(rec.Body.Bytes(), &br); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if br.Details == nil || !strings.Contains(*br.Details, tc.wantDetails) {
+				t.Fatalf("expected details mentioning %q, got %s", tc.wantDetails, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPIValidatorStrictResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator, err := middleware.OpenAPIValidator(middleware.OpenAPIValidatorOptions{ValidateResponses: true})
+	if err != nil {
+		t.Fatalf("OpenAPIValidator: %v", err)
+	}
+	router := gin.New()
+	router.Use(validator)
+	router.GET("/assignments/:id", func(c *gin.Context) {
+		if c.Param("id") == "drift" {
+			// status is required by the Assignment schema
+			c.JSON(http.StatusOK, gin.H{"vehicleId": "V1", "routeId": "R1", "startsAt": "2025-01-02T08:00:00Z"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"vehicleId": "V1", "routeId": "R1", "startsAt": "2025-01-02T08:00:00Z", "endsAt": "2025-01-02T09:00:00Z", "status": "pending"})
+	})
+
+	for id, want := range map[string]int{"ok": http.StatusOK, "drift": http.StatusInternalServerError} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/assignments/"+id, nil))
+		if rec.Code != want {
+			t.Fatalf("%s: expected %d, got %d: %s", id, want, rec.Code, rec.Body.String())
+		}
+	}
+}
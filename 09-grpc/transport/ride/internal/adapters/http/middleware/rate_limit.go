@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+)
+
+// RateLimiter answers requests that find the token bucket of their client
+// and operation empty with 429 and a Retry-After header. Clients are told
+// apart by the subject of their token, so it must run after Auth, or else
+// by IP address. Requests for paths that are not in the spec are passed
+// through untouched. It fails when limiter has a limit for an operation the
+// spec does not know.
+func RateLimiter(limiter *admission.RateLimiter) (gin.HandlerFunc, error) {
+	swagger, router, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+	known := operationIDs(swagger)
+	for _, op := range limiter.Operations() {
+		if !known[op] {
+			return nil, fmt.Errorf("rate limit for unknown operation %q", op)
+		}
+	}
+
+	return func(c *gin.Context) {
+		route, _, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+		wait, err := limiter.Allow(operationID(route.Operation), admission.ClientKey(c.Request.Context(), c.ClientIP()))
+		if err != nil {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			abortWithError(c, http.StatusTooManyRequests, "too many requests", err.Error())
+			return
+		}
+		c.Next()
+	}, nil
+}
@@ -0,0 +1,123 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// decisions returns the value of the counter named name for op and decision.
+func decisions(t *testing.T, reg *prometheus.Registry, name, op, decision string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["operation"] == op && labels["decision"] == decision {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestRateLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	limiter, err := admission.NewRateLimiter(admission.RateLimiterOptions{
+		Default:    admission.Limit{Rate: 0.01, Burst: 2},
+		Operations: map[string]admission.Limit{"createAssignment": {Rate: 0.01}},
+		Registerer: reg,
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+	limit, err := middleware.RateLimiter(limiter)
+	if err != nil {
+		t.Fatalf("RateLimiter: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if sub := c.GetHeader("X-Test-Subject"); sub != "" {
+			ctx := requestctx.WithClaims(c.Request.Context(), requestctx.Claims{Subject: sub})
+			c.Request = c.Request.WithContext(ctx)
+		}
+	}, limit)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/assignments", ok)
+	router.POST("/assignments", ok)
+	router.GET("/healthz", ok)
+
+	do := func(method, path, subject, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":4711"
+		if subject != "" {
+			req.Header.Set("X-Test-Subject", subject)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	steps := []struct {
+		name           string
+		method, path   string
+		subject, ip    string
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{name: "default burst 1", method: http.MethodGet, path: "/assignments", ip: "10.0.0.1", wantStatus: http.StatusOK},
+		{name: "default burst 2", method: http.MethodGet, path: "/assignments", ip: "10.0.0.1", wantStatus: http.StatusOK},
+		{name: "default exhausted", method: http.MethodGet, path: "/assignments", ip: "10.0.0.1", wantStatus: http.StatusTooManyRequests, wantRetryAfter: "100"},
+		{name: "another operation has its own bucket", method: http.MethodPost, path: "/assignments", ip: "10.0.0.1", wantStatus: http.StatusOK},
+		{name: "operation limit with burst from rate", method: http.MethodPost, path: "/assignments", ip: "10.0.0.1", wantStatus: http.StatusTooManyRequests, wantRetryAfter: "100"},
+		{name: "another address has its own bucket", method: http.MethodPost, path: "/assignments", ip: "10.0.0.2", wantStatus: http.StatusOK},
+		{name: "token subject over address", method: http.MethodPost, path: "/assignments", subject: "ops-1", ip: "10.0.0.2", wantStatus: http.StatusOK},
+		{name: "subject exhausted from any address", method: http.MethodPost, path: "/assignments", subject: "ops-1", ip: "10.0.0.3", wantStatus: http.StatusTooManyRequests, wantRetryAfter: "100"},
+		{name: "path outside the spec", method: http.MethodGet, path: "/healthz", ip: "10.0.0.1", wantStatus: http.StatusOK},
+	}
+	for _, st := range steps {
+		rec := do(st.method, st.path, st.subject, st.ip)
+		if rec.Code != st.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", st.name, st.wantStatus, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Retry-After"); got != st.wantRetryAfter {
+			t.Fatalf("%s: expected Retry-After %q, got %q", st.name, st.wantRetryAfter, got)
+		}
+	}
+
+	if got := decisions(t, reg, "ride_http_rate_limit_decisions_total", "listAssignments", "allowed"); got != 2 {
+		t.Errorf("expected 2 allowed listings, got %v", got)
+	}
+	if got := decisions(t, reg, "ride_http_rate_limit_decisions_total", "createAssignment", "limited"); got != 2 {
+		t.Errorf("expected 2 limited creations, got %v", got)
+	}
+}
+
+func TestRateLimiterRejectsUnknownOperations(t *testing.T) {
+	limiter, err := admission.NewRateLimiter(admission.RateLimiterOptions{
+		Operations: map[string]admission.Limit{"createAssigment": {Rate: 1}},
+	})
+	if err != nil {
+		t.Fatalf("NewRateLimiter: %v", err)
+	}
+	if _, err := middleware.RateLimiter(limiter); err == nil {
+		t.Fatal("expected an error for a misspelled operationId")
+	}
+}
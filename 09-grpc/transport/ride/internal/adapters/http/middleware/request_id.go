@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// RequestIDHeader carries the ID that correlates a request with its logs
+// and audit entries.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds caller-supplied request IDs; longer ones are
+// replaced rather than stored.
+const maxRequestIDLength = 128
+
+// RequestID stores the caller's X-Request-ID in the request context, or a
+// new one if the caller sent none or an oversized one, and echoes it in the
+// response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(requestctx.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
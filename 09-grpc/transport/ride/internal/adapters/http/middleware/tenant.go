@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/tenancy"
+)
+
+// TenantHeader names the tenant a request acts for.
+const TenantHeader = "X-Tenant-ID"
+
+// Tenant puts the tenant of the request into its context, where the storage
+// adapters pick it up to scope every query, under the rules of
+// tenancy.Resolver: the tenant pinned by the Auth middleware wins and a
+// TenantHeader naming a different one is rejected with 403; without
+// credentials the header names the tenant, or it is
+// requestctx.DefaultTenant. Only the listed tenants are served; an empty
+// list serves the default tenant alone.
+func Tenant(tenants []string) gin.HandlerFunc {
+	resolver := tenancy.NewResolver(tenants, TenantHeader)
+	return func(c *gin.Context) {
+		tenant, err := resolver.Resolve(c.Request.Context(), c.GetHeader(TenantHeader))
+		switch {
+		case errors.Is(err, tenancy.ErrForbidden):
+			abortWithError(c, http.StatusForbidden, "forbidden", err.Error())
+			return
+		case errors.Is(err, tenancy.ErrMissing):
+			abortWithError(c, http.StatusBadRequest, "missing "+TenantHeader, err.Error())
+			return
+		case err != nil:
+			abortWithError(c, http.StatusBadRequest, "invalid "+TenantHeader, err.Error())
+			return
+		}
+		c.Request = c.Request.WithContext(requestctx.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+func TestTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name        string
+		tenants     []string
+		credentials string // tenant set by an earlier middleware
+		header      string
+		wantStatus  int
+		wantTenant  string
+	}{
+		{name: "no tenants configured", wantStatus: http.StatusOK, wantTenant: requestctx.DefaultTenant},
+		{name: "header picks a served tenant", tenants: []string{"berlin", "paris"}, header: "paris", wantStatus: http.StatusOK, wantTenant: "paris"},
+		{name: "unknown tenant", tenants: []string{"berlin"}, header: "paris", wantStatus: http.StatusBadRequest},
+		{name: "header required without a served default", tenants: []string{"berlin"}, wantStatus: http.StatusBadRequest},
+		{name: "header optional with a served default", tenants: []string{"default", "berlin"}, wantStatus: http.StatusOK, wantTenant: requestctx.DefaultTenant},
+		{name: "credentials without header", tenants: []string{"berlin"}, credentials: "berlin", wantStatus: http.StatusOK, wantTenant: "berlin"},
+		{name: "credentials and matching header", tenants: []string{"berlin"}, credentials: "berlin", header: "berlin", wantStatus: http.StatusOK, wantTenant: "berlin"},
+		{name: "header contradicts credentials", tenants: []string{"berlin", "paris"}, credentials: "berlin", header: "paris", wantStatus: http.StatusForbidden},
+		{name: "credentials for a tenant not served", tenants: []string{"berlin"}, credentials: "paris", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			if tc.credentials != "" {
+				router.Use(func(c *gin.Context) {
+					c.Request = c.Request.WithContext(requestctx.WithTenant(c.Request.Context(), tc.credentials))
+				})
+			}
+			router.Use(middleware.Tenant(tc.tenants))
+			var got string
+			router.GET("/assignments", func(c *gin.Context) {
+				got = requestctx.Tenant(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/assignments", nil)
+			if tc.header != "" {
+				req.Header.Set(middleware.TenantHeader, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if got != tc.wantTenant {
+				t.Fatalf("expected tenant %q, got %q", tc.wantTenant, got)
+			}
+		})
+	}
+}
@@ -1,34 +1,120 @@
 package httpserver
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/yourname/transport/ride/configs"
+	"github.com/yourname/transport/ride/internal/adapters/admission"
 	"github.com/yourname/transport/ride/internal/adapters/http/api"
 	"github.com/yourname/transport/ride/internal/adapters/http/handler"
+	"github.com/yourname/transport/ride/internal/adapters/http/middleware"
 	"github.com/yourname/transport/ride/internal/ports"
-	"github.com/yourname/transport/ride/internal/service"
 )
 
+// Deps holds the services and settings the HTTP server is built from.
+// Verifier, RateLimiter and LoadShedder may be nil, which turns
+// authentication, rate limiting and load shedding off.
+type Deps struct {
+	Assignments ports.AssignmentService
+	Recurring   ports.RecurringAssignmentService
+	Webhooks    ports.WebhookService
+	// Feed publishes the changes pushed by /assignments/stream.
+	Feed        ports.AssignmentFeed
+	Idempotency ports.IdempotencyStore
+	Verifier    ports.TokenVerifier
+	// RequireTenantClaim refuses tokens without a tenant claim.
+	RequireTenantClaim bool
+
+	// RateLimiter and LoadShedder are shared with the gRPC server.
+	RateLimiter *admission.RateLimiter
+	LoadShedder *admission.LoadShedder
+
+	IdempotencyConfig configs.IdempotencyConfig
+	Tenancy           configs.TenancyConfig
+}
+
 // Run initializes and starts the HTTP server based on the provided configuration.
-// It returns an error if the server fails to start.
-func Run(cfg configs.ServerConfig, repo ports.AssignmentRepository) error {
+// It returns an error if the server fails to start, and shuts the server down
+// gracefully once ctx is cancelled.
+func Run(ctx context.Context, cfg configs.ServerConfig, deps Deps) error {
 	log.Printf("Starting server on port %d", cfg.Port)
 
+	validator, err := middleware.OpenAPIValidator(middleware.OpenAPIValidatorOptions{})
+	if err != nil {
+		return fmt.Errorf("openapi validator: %w", err)
+	}
+
+	var authenticate gin.HandlerFunc
+	if deps.Verifier != nil {
+		if authenticate, err = middleware.Auth(deps.Verifier, middleware.AuthOptions{RequireTenantClaim: deps.RequireTenantClaim}); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	} else {
+		log.Println("WARNING: authentication is disabled; API requests are not checked")
+	}
+
+	importBodyBytes := cfg.MaxImportBodyBytes
+	if importBodyBytes == 0 {
+		importBodyBytes = middleware.DefaultMaxImportBodyBytes
+	}
+	bodyLimit, err := middleware.BodyLimit(middleware.BodyLimitOptions{
+		Default:    cfg.MaxBodyBytes,
+		Operations: map[string]int64{"batchImportAssignments": importBodyBytes},
+	})
+	if err != nil {
+		return fmt.Errorf("body limit: %w", err)
+	}
+
+	var rateLimit, shed gin.HandlerFunc
+	if deps.RateLimiter != nil {
+		if rateLimit, err = middleware.RateLimiter(deps.RateLimiter); err != nil {
+			return fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+	if deps.LoadShedder != nil {
+		if shed, err = middleware.LoadShedder(deps.LoadShedder); err != nil {
+			return fmt.Errorf("load shedder: %w", err)
+		}
+	}
+
 	router := gin.Default()
-	// Add health endpoint
+	// Add health endpoint; it and /metrics are registered ahead of the API
+	// middleware, so probes and scrapers need no tenant.
 	router.GET("/healthz", func(c *gin.Context) {
 		c.String(http.StatusOK, "Ok")
 	})
-	assignmentService := service.NewAssignmentService(repo)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Actor())
+	// Limits apply per authenticated client, and only admitted requests
+	// take a load shedder slot.
+	for _, m := range []gin.HandlerFunc{authenticate, rateLimit, shed} {
+		if m != nil {
+			router.Use(m)
+		}
+	}
+	router.Use(middleware.Tenant(deps.Tenancy.Tenants))
+	// Bodies are bounded before the validator or Idempotency read them.
+	router.Use(bodyLimit)
+	router.Use(validator)
+	router.Use(middleware.Idempotency(deps.Idempotency, deps.IdempotencyConfig.TTL))
 	// Initialize your handler that implements api.ServerInterface, injecting any services needed
-	hndlr := handler.NewAssignmentHandler(assignmentService)
-	// Register OpenAPI routes (e.g. /assignments)
-	api.RegisterHandlers(router, hndlr)
+	hndlr := handler.NewServer(
+		handler.NewAssignmentHandler(deps.Assignments),
+		handler.NewRecurringAssignmentHandler(deps.Recurring),
+		handler.NewWebhookHandler(deps.Webhooks),
+		handler.NewAssignmentStreamHandler(deps.Feed, time.Duration(cfg.StreamHeartbeatSec)*time.Second),
+	)
+	// Register OpenAPI routes (e.g. /assignments, /assignments/stream, /recurring-assignments, /webhooks)
+	api.RegisterHandlersWithOptions(router, hndlr, api.GinServerOptions{
+		ErrorHandler: handler.ParamErrorHandler,
+	})
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
@@ -37,12 +123,25 @@ func Run(cfg configs.ServerConfig, repo ports.AssignmentRepository) error {
 		WriteTimeout: time.Duration(cfg.WriteTimeoutSec) * time.Second,
 	}
 
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		log.Println("Shutting down server...")
+		// Give in-flight requests up to the write timeout to finish.
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), max(server.WriteTimeout, 5*time.Second))
+		defer cancel()
+		shutdownErr <- server.Shutdown(shutdownCtx)
+	}()
+
 	log.Println("Starting server on port", cfg.Port)
-	// The ListenAndServe call is blocking. It will only return on an
-	// unrecoverable error. We return that error to the caller (main).
+	// The ListenAndServe call is blocking. It returns http.ErrServerClosed
+	// after Shutdown, or an unrecoverable error which we return to main.
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("could not listen on %s: %w", server.Addr, err)
 	}
+	if err := <-shutdownErr; err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
 
 	log.Println("Server exited gracefully.")
 	return nil
@@ -0,0 +1,87 @@
+package pulsar_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// Consumer wraps a Pulsar consumer and delegates message handling to a Processor.
+type Consumer[T any] struct {
+	consumer pulsar.Consumer
+}
+
+// NewConsumer subscribes with opts. Messages are decoded with the
+// subscription schema, so opts.Schema is required.
+func NewConsumer[T any](client pulsar.Client, opts pulsar.ConsumerOptions) (*Consumer[T], error) {
+	if client == nil {
+		return nil, fmt.Errorf("pulsarconsumer: client is nil")
+	}
+	if opts.Topic == "" {
+		return nil, fmt.Errorf("pulsarconsumer: topic is required")
+	}
+	if opts.Schema == nil {
+		return nil, fmt.Errorf("pulsarconsumer: schema is required")
+	}
+
+	cons, err := client.Subscribe(opts)
+	if err != nil {
+		return nil, fmt.Errorf("pulsarconsumer: subscribe: %w", err)
+	}
+	return &Consumer[T]{consumer: cons}, nil
+}
+
+// Start blocks, receiving messages and invoking the provided Processor until
+// the context is canceled.
+func (c *Consumer[T]) Start(ctx context.Context, processor ports.Processor[T]) error {
+	if processor == nil {
+		return fmt.Errorf("pulsarconsumer: processor is nil")
+	}
+
+	for {
+		msg, err := c.consumer.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			log.Printf("pulsarconsumer: receive: %v", err)
+			continue
+		}
+		c.handleMessage(ctx, processor, msg)
+	}
+}
+
+// Close shuts down the underlying Pulsar consumer.
+func (c *Consumer[T]) Close() {
+	c.consumer.Close()
+}
+
+func (c *Consumer[T]) handleMessage(ctx context.Context, processor ports.Processor[T], msg pulsar.Message) {
+	var value T
+	if err := msg.GetSchemaValue(&value); err != nil {
+		log.Printf("pulsarconsumer: decode %s: %v", msg.ID(), err)
+		c.consumer.Nack(msg)
+		return
+	}
+
+	err := processor.Process(ctx, ports.Message[T]{
+		Key:      msg.Key(),
+		Value:    value,
+		Attempt:  int(msg.RedeliveryCount()),
+		Metadata: msg.Properties(),
+	})
+	if err != nil {
+		c.consumer.Nack(msg)
+		return
+	}
+	if err := c.consumer.Ack(msg); err != nil {
+		log.Printf("pulsarconsumer: ack %s: %v", msg.ID(), err)
+	}
+}
+
+var _ ports.EventConsumer[any] = (*Consumer[any])(nil)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/google/uuid"
 
 	avroschemas "github.com/yourname/transport/ride/avro_schemas"
 	"github.com/yourname/transport/ride/configs"
@@ -46,3 +47,49 @@ func NewAssignmentCreatedProducer(client pulsar.Client, pcfg configs.PulsarProdu
 
 	return prod, nil
 }
+
+func NewAssignmentStatusChangedProducer(client pulsar.Client, pcfg configs.PulsarProducerConfig) (*Producer[ports.AssignmentStatusChanged], error) {
+	schema := pulsar.NewAvroSchema(string(avroschemas.AssignmentStatusChanged), nil)
+
+	if pcfg.Topic == "" {
+		pcfg.Topic = "assignment-status"
+	}
+
+	prod, err := NewProducer(ProducerConfig[ports.AssignmentStatusChanged]{
+		Client:        client,
+		Topic:         pcfg.Topic,
+		Schema:        schema,
+		PulsarConfigs: pcfg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create producer: %w", err)
+	}
+
+	return prod, nil
+}
+
+// NewAssignmentEventsBroadcastConsumer reads AssignmentCreated events on a
+// subscription of its own, so every ride instance sees every event. The
+// subscription is non-durable and starts at the latest message: it only
+// matters while this instance runs.
+func NewAssignmentEventsBroadcastConsumer(client pulsar.Client, ccfg configs.PulsarConsumerConfig) (*Consumer[ports.AssignmentCreated], error) {
+	if ccfg.Topic == "" {
+		ccfg.Topic = "assignments"
+	}
+	if ccfg.SubscriptionName == "" {
+		ccfg.SubscriptionName = "ride-cache"
+	}
+
+	return NewConsumer[ports.AssignmentCreated](client, pulsar.ConsumerOptions{
+		Topic:                       ccfg.Topic,
+		SubscriptionName:            ccfg.SubscriptionName + "-" + uuid.NewString(),
+		Name:                        ccfg.Name,
+		Type:                        pulsar.Exclusive,
+		SubscriptionMode:            pulsar.NonDurable,
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionLatest,
+		ReceiverQueueSize:           ccfg.ReceiverQueueSize,
+		NackRedeliveryDelay:         ccfg.NackRedeliveryDelay,
+		MaxReconnectToBroker:        ccfg.MaxReconnectToBroker,
+		Schema:                      pulsar.NewAvroSchema(string(avroschemas.Assignment), nil),
+	})
+}
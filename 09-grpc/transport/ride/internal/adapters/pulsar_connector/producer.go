@@ -8,6 +8,7 @@ import (
 
 	"github.com/yourname/transport/ride/configs"
 	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
 )
 
 // Producer is a wrapper around pulsar.Producer that supports custom encoding.
@@ -92,9 +93,13 @@ func getProducerOptions(schema pulsar.Schema, pcfg configs.PulsarProducerConfig)
 // Send publishes a message with the given payload. If an encoder was
 // provided, it is used to serialize the payload to bytes.  Otherwise,
 // the payload is sent directly as the message Value (which requires a
-// matching Pulsar schema).  Returns the Pulsar MessageID or an error.
+// matching Pulsar schema).  The tenant of ctx is stamped on the message
+// as the ports.TenantProperty property.  Returns the Pulsar MessageID or
+// an error.
 func (p *Producer[T]) Send(ctx context.Context, payload T) (string, error) {
-	var msg pulsar.ProducerMessage
+	msg := pulsar.ProducerMessage{
+		Properties: map[string]string{ports.TenantProperty: requestctx.Tenant(ctx)},
+	}
 	if p.encoder != nil {
 		data, err := p.encoder(payload)
 		if err != nil {
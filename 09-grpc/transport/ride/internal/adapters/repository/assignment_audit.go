@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// auditSnapshot is the JSON form of an assignment in the before_state and
+// after_state columns of assignment_audit.
+type auditSnapshot struct {
+	ID                  string    `json:"id"`
+	VehicleID           string    `json:"vehicleId"`
+	VehicleAutoSelected bool      `json:"vehicleAutoSelected,omitempty"`
+	RouteID             string    `json:"routeId"`
+	StartsAt            time.Time `json:"startsAt"`
+	EndsAt              time.Time `json:"endsAt"`
+	Status              string    `json:"status"`
+	Version             int64     `json:"version"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+// encodeAuditSnapshot returns the column value for a; nil becomes NULL.
+func encodeAuditSnapshot(a *models.Assignment) (any, error) {
+	if a == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(auditSnapshot{
+		ID:                  a.ID,
+		VehicleID:           a.VehicleID,
+		VehicleAutoSelected: a.VehicleAutoSelected,
+		RouteID:             a.RouteID,
+		StartsAt:            a.StartsAt.UTC(),
+		EndsAt:              a.EndsAt.UTC(),
+		Status:              a.Status,
+		Version:             a.Version,
+		UpdatedAt:           a.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode audit snapshot: %w", err)
+	}
+	return string(b), nil
+}
+
+func decodeAuditSnapshot(b []byte) (*models.Assignment, error) {
+	if b == nil {
+		return nil, nil
+	}
+	var s auditSnapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("decode audit snapshot: %w", err)
+	}
+	return &models.Assignment{
+		ID:                  s.ID,
+		VehicleID:           s.VehicleID,
+		VehicleAutoSelected: s.VehicleAutoSelected,
+		RouteID:             s.RouteID,
+		StartsAt:            s.StartsAt,
+		EndsAt:              s.EndsAt,
+		Status:              s.Status,
+		Version:             s.Version,
+		UpdatedAt:           s.UpdatedAt,
+	}, nil
+}
+
+// readAuditImage reads assignment id inside tx, or returns nil if it does
+// not exist. With lock set the row stays locked until tx ends, so the image
+// is exactly the version the following write replaces; without it the read
+// may come from the transaction's snapshot. Callers only lock rows they
+// expect to exist: in MySQL, locking a missing key takes a gap lock, and
+// two inserts holding the same gap deadlock.
+func readAuditImage(ctx context.Context, tx *sql.Tx, bind func(string) string, id string, lock bool) (*models.Assignment, error) {
+	query := `SELECT ` + assignmentColumns + ` FROM assignments WHERE tenant_id = ? AND id = ?`
+	if lock {
+		query += " FOR UPDATE"
+	}
+	a, err := scanAssignment(tx.QueryRowContext(ctx, bind(query), requestctx.Tenant(ctx), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapSQLError(err, "read assignment for audit")
+	}
+	return &a, nil
+}
+
+// newAuditEntry describes a change made on behalf of the request in ctx.
+func newAuditEntry(ctx context.Context, id string, action models.AuditAction, before *models.Assignment) models.AuditEntry {
+	return models.AuditEntry{
+		TenantID:     requestctx.Tenant(ctx),
+		AssignmentID: id,
+		Action:       action,
+		Actor:        requestctx.Actor(ctx),
+		RequestID:    requestctx.RequestID(ctx),
+		Before:       before,
+		At:           time.Now().UTC(),
+	}
+}
+
+// transitionAuditEntry describes the status change t. t carries its own
+// actor and time, so they match the transition log.
+func transitionAuditEntry(ctx context.Context, t models.AssignmentTransition, before *models.Assignment) models.AuditEntry {
+	e := newAuditEntry(ctx, t.AssignmentID, models.AuditActionStatusChanged, before)
+	e.Actor, e.At = t.Actor, t.ChangedAt
+	return e
+}
+
+// recordAudit completes e with the assignment as tx has just written it and
+// appends it to assignment_audit.
+func recordAudit(ctx context.Context, tx *sql.Tx, bind func(string) string, e models.AuditEntry) error {
+	after, err := readAuditImage(ctx, tx, bind, e.AssignmentID, false)
+	if err != nil {
+		return err
+	}
+	if after == nil {
+		return fmt.Errorf("record audit: assignment %s vanished in its own transaction", e.AssignmentID)
+	}
+	beforeState, err := encodeAuditSnapshot(e.Before)
+	if err != nil {
+		return err
+	}
+	afterState, err := encodeAuditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, bind(`
+		INSERT INTO assignment_audit (tenant_id, assignment_id, action, actor, request_id, before_state, after_state, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		e.TenantID, e.AssignmentID, e.Action, e.Actor, e.RequestID, beforeState, afterState, e.At,
+	)
+	return mapSQLError(err, "record assignment audit")
+}
+
+// auditHistory returns the audit entries of assignment id of the tenant in
+// ctx, oldest first.
+func auditHistory(ctx context.Context, db *sql.DB, bind func(string) string, id string) ([]models.AuditEntry, error) {
+	rows, err := db.QueryContext(ctx, bind(`
+		SELECT `+auditColumns+`
+		FROM assignment_audit
+		WHERE tenant_id = ? AND assignment_id = ?
+		ORDER BY id`), requestctx.Tenant(ctx), id,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "read assignment history")
+	}
+	return scanAuditEntries(rows, "read assignment history")
+}
+
+// auditSince returns up to limit audit entries of every tenant after
+// afterID, oldest first.
+func auditSince(ctx context.Context, db *sql.DB, bind func(string) string, afterID int64, limit int) ([]models.AuditEntry, error) {
+	rows, err := db.QueryContext(ctx, bind(`
+		SELECT `+auditColumns+`
+		FROM assignment_audit
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?`), afterID, limit,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "read assignment audit")
+	}
+	return scanAuditEntries(rows, "read assignment audit")
+}
+
+func latestAuditID(ctx context.Context, db *sql.DB) (int64, error) {
+	var id sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(id) FROM assignment_audit`).Scan(&id); err != nil {
+		return 0, mapSQLError(err, "read latest audit id")
+	}
+	return id.Int64, nil
+}
+
+const auditColumns = `id, tenant_id, assignment_id, action, actor, request_id, before_state, after_state, recorded_at`
+
+func scanAuditEntries(rows *sql.Rows, op string) ([]models.AuditEntry, error) {
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var (
+			e             models.AuditEntry
+			before, after []byte
+		)
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.AssignmentID, &e.Action, &e.Actor, &e.RequestID, &before, &after, &e.At); err != nil {
+			return nil, mapSQLError(err, op)
+		}
+		var err error
+		if e.Before, err = decodeAuditSnapshot(before); err != nil {
+			return nil, err
+		}
+		afterImage, err := decodeAuditSnapshot(after)
+		if err != nil {
+			return nil, err
+		}
+		if afterImage != nil {
+			e.After = *afterImage
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapSQLError(err, op)
+	}
+	return entries, nil
+}
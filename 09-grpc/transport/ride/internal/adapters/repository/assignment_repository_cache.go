@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// Cache defaults, used when CacheOptions leaves a field zero.
+const (
+	DefaultCacheSize = 10_000
+	DefaultCacheTTL  = 30 * time.Second
+)
+
+// CacheOptions configures NewCachedAssignmentRepository. Registerer, when
+// set, receives the hit/miss counters.
+type CacheOptions struct {
+	Size       int
+	TTL        time.Duration
+	Registerer prometheus.Registerer
+}
+
+// CachedAssignmentRepository is a read-through cache in front of another
+// AssignmentRepository. FindByID and FindAll results are kept in bounded
+// LRUs for at most the TTL, keyed by tenant as well as by the request;
+// concurrent misses for the same key share one load. Every write through the cache drops the written assignment and all
+// cached listings, since any write can move an assignment in or out of a
+// page. Writes made by other instances are only seen after the TTL, unless
+// they are reported through Invalidate.
+type CachedAssignmentRepository struct {
+	next   ports.AssignmentRepository
+	byID   *expirable.LRU[string, models.Assignment]
+	lists  *expirable.LRU[string, models.AssignmentPage]
+	loads  singleflight.Group
+	lookup *prometheus.CounterVec
+
+	// mu orders invalidations against stores: a load that started before
+	// an invalidation must not put its (possibly stale) result back.
+	mu         sync.Mutex
+	generation uint64
+}
+
+func NewCachedAssignmentRepository(next ports.AssignmentRepository, opts CacheOptions) (*CachedAssignmentRepository, error) {
+	if opts.Size <= 0 {
+		opts.Size = DefaultCacheSize
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+
+	lookup := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ride_assignment_cache_lookups_total",
+		Help: "Assignment cache lookups by operation and result (hit or miss).",
+	}, []string{"op", "result"})
+	if opts.Registerer != nil {
+		if err := opts.Registerer.Register(lookup); err != nil {
+			return nil, fmt.Errorf("register cache metrics: %w", err)
+		}
+	}
+
+	return &CachedAssignmentRepository{
+		next:   next,
+		byID:   expirable.NewLRU[string, models.Assignment](opts.Size, nil, opts.TTL),
+		lists:  expirable.NewLRU[string, models.AssignmentPage](opts.Size, nil, opts.TTL),
+		lookup: lookup,
+	}, nil
+}
+
+func (r *CachedAssignmentRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
+	// Invalidate even when the write fails: a precondition failure means
+	// the cached copy is stale, and the caller is about to re-read it.
+	defer r.Invalidate(ctx, a.ID)
+	return r.next.Save(ctx, a)
+}
+
+func (r *CachedAssignmentRepository) SaveAll(ctx context.Context, as []models.Assignment) error {
+	defer func() {
+		for _, a := range as {
+			r.Invalidate(ctx, a.ID)
+		}
+	}()
+	return r.next.SaveAll(ctx, as)
+}
+
+func (r *CachedAssignmentRepository) CheckAll(ctx context.Context, as []models.Assignment) error {
+	return r.next.CheckAll(ctx, as)
+}
+
+func (r *CachedAssignmentRepository) UpdateStatus(ctx context.Context, t models.AssignmentTransition) error {
+	defer r.Invalidate(ctx, t.AssignmentID)
+	return r.next.UpdateStatus(ctx, t)
+}
+
+// History is not cached: audit entries are read rarely and must include
+// every change.
+func (r *CachedAssignmentRepository) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	return r.next.History(ctx, id)
+}
+
+func (r *CachedAssignmentRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	return r.next.AuditSince(ctx, afterID, limit)
+}
+
+func (r *CachedAssignmentRepository) LatestAuditID(ctx context.Context) (int64, error) {
+	return r.next.LatestAuditID(ctx)
+}
+
+func (r *CachedAssignmentRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
+	key := idCacheKey(ctx, id)
+	if a, ok := r.byID.Get(key); ok {
+		r.lookup.WithLabelValues("find_by_id", "hit").Inc()
+		return a, nil
+	}
+	r.lookup.WithLabelValues("find_by_id", "miss").Inc()
+
+	v, err, _ := r.loads.Do("id:"+key, func() (any, error) {
+		gen := r.currentGeneration()
+		a, err := r.next.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.store(gen, func() { r.byID.Add(key, a) })
+		return a, nil
+	})
+	if err != nil {
+		return models.Assignment{}, err
+	}
+	return v.(models.Assignment), nil
+}
+
+func (r *CachedAssignmentRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	key := listCacheKey(ctx, q)
+	if page, ok := r.lists.Get(key); ok {
+		r.lookup.WithLabelValues("find_all", "hit").Inc()
+		return copyPage(page), nil
+	}
+	r.lookup.WithLabelValues("find_all", "miss").Inc()
+
+	v, err, _ := r.loads.Do("list:"+key, func() (any, error) {
+		gen := r.currentGeneration()
+		page, err := r.next.FindAll(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		r.store(gen, func() { r.lists.Add(key, page) })
+		return page, nil
+	})
+	if err != nil {
+		return models.AssignmentPage{}, err
+	}
+	return copyPage(v.(models.AssignmentPage)), nil
+}
+
+// Invalidate drops the cached assignment id of the tenant in ctx and every
+// cached listing. It is called for local writes and for changes reported by
+// other instances.
+func (r *CachedAssignmentRepository) Invalidate(ctx context.Context, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	r.byID.Remove(idCacheKey(ctx, id))
+	r.lists.Purge()
+}
+
+func (r *CachedAssignmentRepository) currentGeneration() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.generation
+}
+
+// store runs add unless an invalidation happened since gen was read.
+func (r *CachedAssignmentRepository) store(gen uint64, add func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == gen {
+		add()
+	}
+}
+
+// idCacheKey identifies assignment id of the tenant in ctx.
+func idCacheKey(ctx context.Context, id string) string {
+	return strconv.Quote(requestctx.Tenant(ctx)) + "/" + id
+}
+
+// listCacheKey identifies q for the tenant in ctx; two queries share a key
+// only if FindAll would answer them identically.
+func listCacheKey(ctx context.Context, q models.AssignmentQuery) string {
+	str := func(s *string) string {
+		if s == nil {
+			return "-"
+		}
+		return "=" + strconv.Quote(*s)
+	}
+	tm := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	parts := []string{
+		"tenant=" + strconv.Quote(requestctx.Tenant(ctx)),
+		"status" + str(q.Status),
+		"vehicle" + str(q.VehicleID),
+		"route" + str(q.RouteID),
+		"from=" + tm(q.StartsFrom),
+		"to=" + tm(q.StartsTo),
+		"order=" + string(q.Order),
+		fmt.Sprintf("limit=%d", q.Limit),
+	}
+	if q.After != nil {
+		parts = append(parts, "after="+tm(&q.After.StartsAt)+"/"+strconv.Quote(q.After.ID))
+	}
+	return strings.Join(parts, "|")
+}
+
+// copyPage keeps callers from mutating a page that is still cached.
+func copyPage(p models.AssignmentPage) models.AssignmentPage {
+	out := models.AssignmentPage{Items: append([]models.Assignment(nil), p.Items...)}
+	if p.Next != nil {
+		next := *p.Next
+		out.Next = &next
+	}
+	return out
+}
+
+var _ ports.AssignmentRepository = (*CachedAssignmentRepository)(nil)
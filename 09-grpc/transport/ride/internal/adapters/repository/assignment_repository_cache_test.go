@@ -0,0 +1,203 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/adapters/repository/repositorytest"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// countingRepository counts reads that reach the wrapped repository and can
+// hold them until release is closed.
+type countingRepository struct {
+	ports.AssignmentRepository
+	finds   atomic.Int32
+	lists   atomic.Int32
+	release chan struct{}
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
+	r.finds.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.AssignmentRepository.FindByID(ctx, id)
+}
+
+func (r *countingRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	r.lists.Add(1)
+	return r.AssignmentRepository.FindAll(ctx, q)
+}
+
+func newCached(t *testing.T, next ports.AssignmentRepository, ttl time.Duration) (*repository.CachedAssignmentRepository, *prometheus.Registry) {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	cached, err := repository.NewCachedAssignmentRepository(next, repository.CacheOptions{Size: 100, TTL: ttl, Registerer: reg})
+	if err != nil {
+		t.Fatalf("NewCachedAssignmentRepository: %v", err)
+	}
+	return cached, reg
+}
+
+// lookups returns the value of the lookup counter for op and result.
+func lookups(t *testing.T, reg *prometheus.Registry, op, result string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["op"] == op && labels["result"] == result {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestCachedAssignmentRepository_Conformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) ports.AssignmentRepository {
+		cached, _ := newCached(t, repository.NewMemoryAssignmentRepository(), time.Minute)
+		return cached
+	})
+}
+
+func TestCachedAssignmentRepository_ReadThrough(t *testing.T) {
+	ctx := context.Background()
+	next := &countingRepository{AssignmentRepository: repository.NewMemoryAssignmentRepository()}
+	cached, reg := newCached(t, next, time.Minute)
+
+	a := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending"}
+	if _, err := cached.Save(ctx, a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	vehicle := "V1"
+	q := models.AssignmentQuery{VehicleID: &vehicle, Order: models.SortAscending}
+
+	for range 3 {
+		if _, err := cached.FindByID(ctx, "A1"); err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if _, err := cached.FindAll(ctx, q); err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+	}
+	if next.finds.Load() != 1 || next.lists.Load() != 1 {
+		t.Fatalf("expected one load each, got %d finds and %d lists", next.finds.Load(), next.lists.Load())
+	}
+	if hits, misses := lookups(t, reg, "find_by_id", "hit"), lookups(t, reg, "find_by_id", "miss"); hits != 2 || misses != 1 {
+		t.Fatalf("expected 2 hits and 1 miss, got %v and %v", hits, misses)
+	}
+
+	// A write evicts the assignment and every listing.
+	a.RouteID = "R2"
+	if _, err := cached.Save(ctx, a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := cached.FindByID(ctx, "A1")
+	if err != nil || got.RouteID != "R2" {
+		t.Fatalf("expected fresh read after Save, got %+v err=%v", got, err)
+	}
+	if _, err := cached.FindAll(ctx, q); err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if next.finds.Load() != 2 || next.lists.Load() != 2 {
+		t.Fatalf("expected reloads after Save, got %d finds and %d lists", next.finds.Load(), next.lists.Load())
+	}
+
+	// Invalidate is how other instances' writes arrive.
+	cached.Invalidate(ctx, "A1")
+	if _, err := cached.FindByID(ctx, "A1"); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if next.finds.Load() != 3 {
+		t.Fatalf("expected reload after Invalidate, got %d finds", next.finds.Load())
+	}
+}
+
+func TestCachedAssignmentRepository_TTL(t *testing.T) {
+	ctx := context.Background()
+	next := &countingRepository{AssignmentRepository: repository.NewMemoryAssignmentRepository()}
+	cached, _ := newCached(t, next, 20*time.Millisecond)
+
+	if _, err := next.Save(ctx, models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := cached.FindByID(ctx, "A1"); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, err := cached.FindByID(ctx, "A1"); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if next.finds.Load() != 2 {
+		t.Fatalf("expected expired entry to be reloaded, got %d finds", next.finds.Load())
+	}
+}
+
+func TestCachedAssignmentRepository_CollapsesConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+	next := &countingRepository{AssignmentRepository: repository.NewMemoryAssignmentRepository(), release: make(chan struct{})}
+	cached, _ := newCached(t, next, time.Minute)
+	if _, err := next.AssignmentRepository.Save(ctx, models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	const readers = 10
+	var wg sync.WaitGroup
+	for range readers {
+		wg.Go(func() {
+			if _, err := cached.FindByID(ctx, "A1"); err != nil {
+				t.Errorf("FindByID: %v", err)
+			}
+		})
+	}
+	// Let every reader reach the cache before the single load completes.
+	time.Sleep(20 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	if n := next.finds.Load(); n != 1 {
+		t.Fatalf("expected concurrent misses to share one load, got %d", n)
+	}
+}
+
+func TestCachedAssignmentRepository_InvalidationDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	next := &countingRepository{AssignmentRepository: repository.NewMemoryAssignmentRepository(), release: make(chan struct{})}
+	cached, _ := newCached(t, next, time.Minute)
+	if _, err := next.AssignmentRepository.Save(ctx, models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: time.Now(), Status: "pending"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cached.FindByID(ctx, "A1")
+	}()
+	time.Sleep(20 * time.Millisecond)
+	// The load in flight may have read the old row; it must not be cached.
+	cached.Invalidate(ctx, "A1")
+	close(next.release)
+	<-done
+
+	if _, err := cached.FindByID(ctx, "A1"); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if n := next.finds.Load(); n != 2 {
+		t.Fatalf("expected the load racing an invalidation to be discarded, got %d finds", n)
+	}
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type memoryAssignmentRepository struct {
+	mu          sync.RWMutex
+	items       map[tenantKey]models.Assignment
+	transitions []models.AssignmentTransition
+	audit       []models.AuditEntry
+}
+
+// NewMemoryAssignmentRepository keeps assignments in process memory. It is
+// meant for unit tests and local runs; repositorytest.Run keeps its
+// behaviour in line with the SQL adapters.
+func NewMemoryAssignmentRepository() ports.AssignmentRepository {
+	return &memoryAssignmentRepository{items: map[tenantKey]models.Assignment{}}
+}
+
+func (r *memoryAssignmentRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.saveLocked(ctx, a)
+}
+
+func (r *memoryAssignmentRepository) SaveAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, true)
+}
+
+func (r *memoryAssignmentRepository) CheckAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, false)
+}
+
+// saveAll saves as and keeps them if asked to and every assignment passed.
+func (r *memoryAssignmentRepository) saveAll(ctx context.Context, as []models.Assignment, keep bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items, audit := maps.Clone(r.items), len(r.audit)
+	var failed []models.ItemError
+	for i, a := range as {
+		// A failed save leaves nothing behind, so the next one goes on
+		// from the same state.
+		if _, err := r.saveLocked(ctx, a); err != nil {
+			failed = append(failed, models.ItemError{Index: i, Err: err})
+		}
+	}
+	if len(failed) > 0 || !keep {
+		r.items, r.audit = items, r.audit[:audit]
+	}
+	if len(failed) > 0 {
+		return &models.BatchError{Items: failed}
+	}
+	return nil
+}
+
+// saveLocked is Save; the caller holds r.mu.
+func (r *memoryAssignmentRepository) saveLocked(ctx context.Context, a models.Assignment) (bool, error) {
+	key := keyOf(ctx, a.ID)
+	current, exists := r.items[key]
+	if a.Version > 0 {
+		if !exists {
+			return false, models.NewNotFoundError("assignment %s not found", a.ID)
+		}
+		if current.Version != a.Version {
+			return false, models.NewPreconditionFailedError("assignment %s is at version %d, not %d", a.ID, current.Version, a.Version)
+		}
+	}
+	if clashing := r.overlapping(key.tenant, a); len(clashing) > 0 {
+		return false, models.NewScheduleConflictError(a.VehicleID, clashing)
+	}
+	var before *models.Assignment
+	action := models.AuditActionCreated
+	if exists {
+		a.Status = current.Status
+		before, action = &current, models.AuditActionUpdated
+	}
+	a.Version = current.Version + 1
+	a.UpdatedAt = time.Now().UTC()
+	r.items[key] = a
+	r.appendAudit(newAuditEntry(ctx, a.ID, action, before), a)
+	return !exists, nil
+}
+
+// appendAudit mirrors recordAudit; the caller holds r.mu.
+func (r *memoryAssignmentRepository) appendAudit(e models.AuditEntry, after models.Assignment) {
+	e.ID = int64(len(r.audit)) + 1
+	e.After = after
+	r.audit = append(r.audit, e)
+}
+
+// overlapping mirrors checkVehicleSchedule; the caller holds r.mu.
+func (r *memoryAssignmentRepository) overlapping(tenant string, a models.Assignment) []string {
+	var clashing []models.Assignment
+	for key, other := range r.items {
+		if key.tenant == tenant && other.ID != a.ID && other.VehicleID == a.VehicleID &&
+			models.AssignmentStatus(other.Status).BlocksVehicle() && other.Overlaps(a) {
+			clashing = append(clashing, other)
+		}
+	}
+	sort.Slice(clashing, func(i, j int) bool {
+		return models.SortAscending.Less(models.CursorOf(clashing[i]), models.CursorOf(clashing[j]))
+	})
+	if len(clashing) > maxReportedClashes {
+		clashing = clashing[:maxReportedClashes]
+	}
+	ids := make([]string, len(clashing))
+	for i, c := range clashing {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func (r *memoryAssignmentRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.items[keyOf(ctx, id)]
+	if !ok {
+		return models.Assignment{}, models.NewNotFoundError("assignment %s not found", id)
+	}
+	return a, nil
+}
+
+func (r *memoryAssignmentRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenant := requestctx.Tenant(ctx)
+	var out []models.Assignment
+	for key, a := range r.items {
+		if key.tenant == tenant && matchesQuery(a, q) {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return q.Order.Less(models.CursorOf(out[i]), models.CursorOf(out[j]))
+	})
+	// pageOf expects the look-ahead row the SQL adapters fetch.
+	if q.Limit > 0 && len(out) > q.Limit+1 {
+		out = out[:q.Limit+1]
+	}
+	return pageOf(out, q.Limit), nil
+}
+
+// matchesQuery applies the filters of q, including the keyset cursor.
+func matchesQuery(a models.Assignment, q models.AssignmentQuery) bool {
+	switch {
+	case q.Status != nil && a.Status != *q.Status,
+		q.VehicleID != nil && a.VehicleID != *q.VehicleID,
+		q.RouteID != nil && a.RouteID != *q.RouteID,
+		q.StartsFrom != nil && a.StartsAt.Before(*q.StartsFrom),
+		q.StartsTo != nil && !a.StartsAt.Before(*q.StartsTo),
+		q.After != nil && !q.Order.Less(*q.After, models.CursorOf(a)):
+		return false
+	}
+	return true
+}
+
+func (r *memoryAssignmentRepository) UpdateStatus(ctx context.Context, t models.AssignmentTransition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := keyOf(ctx, t.AssignmentID)
+	a, ok := r.items[key]
+	if !ok {
+		return models.NewNotFoundError("assignment %s not found", t.AssignmentID)
+	}
+	// Same precedence as the SQL adapters: a stale version wins over a
+	// stale status.
+	if t.Version > 0 && a.Version != t.Version {
+		return models.NewPreconditionFailedError("assignment %s is at version %d, not %d", t.AssignmentID, a.Version, t.Version)
+	}
+	if a.Status != string(t.From) {
+		return models.NewConflictError("assignment %s is %s, not %s", t.AssignmentID, a.Status, t.From)
+	}
+
+	before := a
+	a.Status = string(t.To)
+	a.Version++
+	a.UpdatedAt = time.Now().UTC()
+	r.items[key] = a
+	r.transitions = append(r.transitions, t)
+	r.appendAudit(transitionAuditEntry(ctx, t, &before), a)
+	return nil
+}
+
+func (r *memoryAssignmentRepository) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenant := requestctx.Tenant(ctx)
+	entries := []models.AuditEntry{}
+	for _, e := range r.audit {
+		if e.TenantID == tenant && e.AssignmentID == id {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (r *memoryAssignmentRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Entry IDs are positions in r.audit plus one.
+	start := min(max(afterID, 0), int64(len(r.audit)))
+	end := int64(len(r.audit))
+	if limit > 0 {
+		end = min(end, start+int64(limit))
+	}
+	return slices.Clone(r.audit[start:end]), nil
+}
+
+func (r *memoryAssignmentRepository) LatestAuditID(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.audit)), nil
+}
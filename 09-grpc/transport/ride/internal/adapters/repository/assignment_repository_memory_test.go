@@ -0,0 +1,21 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/adapters/repository/repositorytest"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestMemoryAssignmentRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewMemoryAssignmentRepository()
+	})
+}
+
+func TestMemoryAssignmentRepositorySagaResume(t *testing.T) {
+	repositorytest.RunSagaResume(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewMemoryAssignmentRepository()
+	}, repository.NewMemoryAssignmentSagaStore())
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type postgresAssignmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAssignmentRepository stores assignments in PostgreSQL. It has
+// the same semantics as the MySQL repository; PostgreSQL has no
+// ON UPDATE CURRENT_TIMESTAMP, so every write sets updated_at itself.
+func NewPostgresAssignmentRepository(db *sql.DB) ports.AssignmentRepository {
+	return &postgresAssignmentRepository{db: db}
+}
+
+func (r *postgresAssignmentRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, mapSQLError(err, "save assignment")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	isNew, err := postgresSaveAssignmentTx(ctx, tx, a)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, mapSQLError(err, "save assignment")
+	}
+	return isNew, nil
+}
+
+func (r *postgresAssignmentRepository) SaveAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, true)
+}
+
+func (r *postgresAssignmentRepository) CheckAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, false)
+}
+
+// saveAll saves as in one transaction and commits it if asked to and every
+// assignment passed.
+func (r *postgresAssignmentRepository) saveAll(ctx context.Context, as []models.Assignment, commit bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "save assignments")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	// Advisory locks are reentrant, so taking them all up front in a fixed
+	// order keeps batches sharing vehicles from deadlocking.
+	for _, vehicleID := range batchVehicles(as) {
+		if err := postgresLockVehicleSchedule(ctx, tx, vehicleID); err != nil {
+			return err
+		}
+	}
+	// A failed statement aborts a PostgreSQL transaction, so going on past
+	// a failed assignment needs the savepoints of saveEachTx.
+	if err := saveEachTx(ctx, tx, as, postgresSaveAssignmentTx); err != nil || !commit {
+		return err
+	}
+	return mapSQLError(tx.Commit(), "save assignments")
+}
+
+// postgresSaveAssignmentTx is Save within tx, which the caller commits.
+func postgresSaveAssignmentTx(ctx context.Context, tx *sql.Tx, a models.Assignment) (bool, error) {
+	// Under READ COMMITTED the overlap check sees everything committed by
+	// the previous holder of the vehicle lock.
+	if err := postgresLockVehicleSchedule(ctx, tx, a.VehicleID); err != nil {
+		return false, err
+	}
+	if err := checkVehicleSchedule(ctx, tx, a, rebindPostgres); err != nil {
+		return false, err
+	}
+	before, err := readAuditImage(ctx, tx, rebindPostgres, a.ID, a.Version > 0)
+	if err != nil {
+		return false, err
+	}
+
+	if a.Version > 0 {
+		if err := postgresUpdateAtVersion(ctx, tx, a); err != nil {
+			return false, err
+		}
+		return false, recordAudit(ctx, tx, rebindPostgres, newAuditEntry(ctx, a.ID, models.AuditActionUpdated, before))
+	}
+
+	// xmax is zero only for a row version created by a plain INSERT, which
+	// tells an insert from an upsert without a second query.
+	var isNew bool
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO assignments (tenant_id, id, vehicle_id, vehicle_auto_selected, route_id, starts_at, ends_at, status, version, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1, now())
+		ON CONFLICT (tenant_id, id) DO UPDATE SET
+		    vehicle_id = EXCLUDED.vehicle_id,
+		    vehicle_auto_selected = EXCLUDED.vehicle_auto_selected,
+		    route_id   = EXCLUDED.route_id,
+		    starts_at  = EXCLUDED.starts_at,
+		    ends_at    = EXCLUDED.ends_at,
+		    version    = assignments.version + 1,
+		    updated_at = now()
+		RETURNING (xmax = 0)`,
+		requestctx.Tenant(ctx), a.ID, a.VehicleID, a.VehicleAutoSelected, a.RouteID, a.StartsAt, a.EndsAt, a.Status,
+	).Scan(&isNew)
+	if err != nil {
+		return false, mapSQLError(err, "save assignment")
+	}
+
+	action := models.AuditActionUpdated
+	if isNew {
+		action = models.AuditActionCreated
+		event, err := models.NewAssignmentCreatedEvent(a, time.Now().UTC())
+		if err != nil {
+			return false, err
+		}
+		if err := insertPostgresOutboxEvent(ctx, tx, event); err != nil {
+			return false, err
+		}
+	}
+	if err := recordAudit(ctx, tx, rebindPostgres, newAuditEntry(ctx, a.ID, action, before)); err != nil {
+		return false, err
+	}
+	return isNew, nil
+}
+
+// postgresVehicleLockClass namespaces the per-vehicle advisory locks taken
+// by Save (the two-key form, keyed by a hash of the tenant and vehicle ID).
+const postgresVehicleLockClass int32 = 1
+
+// postgresLockVehicleSchedule serialises writes to one vehicle's schedule
+// until tx ends. Vehicles of different tenants whose keys hash alike share
+// a lock, which only costs some concurrency.
+func postgresLockVehicleSchedule(ctx context.Context, tx *sql.Tx, vehicleID string) error {
+	key := requestctx.Tenant(ctx) + "/" + vehicleID
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, postgresVehicleLockClass, key)
+	return mapSQLError(err, "lock vehicle schedule")
+}
+
+func postgresUpdateAtVersion(ctx context.Context, tx *sql.Tx, a models.Assignment) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE assignments
+		SET vehicle_id = $1, vehicle_auto_selected = $2, route_id = $3, starts_at = $4, ends_at = $5, version = version + 1, updated_at = now()
+		WHERE tenant_id = $6 AND id = $7 AND version = $8`,
+		a.VehicleID, a.VehicleAutoSelected, a.RouteID, a.StartsAt, a.EndsAt, requestctx.Tenant(ctx), a.ID, a.Version,
+	)
+	if err != nil {
+		return mapSQLError(err, "update assignment")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		var current int64
+		err := tx.QueryRowContext(ctx, `SELECT version FROM assignments WHERE tenant_id = $1 AND id = $2`, requestctx.Tenant(ctx), a.ID).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.NewNotFoundError("assignment %s not found", a.ID)
+		}
+		if err != nil {
+			return mapSQLError(err, "update assignment")
+		}
+		return models.NewPreconditionFailedError("assignment %s is at version %d, not %d", a.ID, current, a.Version)
+	}
+	return nil
+}
+
+func (r *postgresAssignmentRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+assignmentColumns+`
+		FROM assignments WHERE tenant_id = $1 AND id = $2`, requestctx.Tenant(ctx), id,
+	)
+
+	a, err := scanAssignment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Assignment{}, models.NewNotFoundError("assignment %s not found", id)
+		}
+		return models.Assignment{}, mapSQLError(err, "find assignment")
+	}
+	return a, nil
+}
+
+func (r *postgresAssignmentRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	query, args := listAssignmentsQuery(requestctx.Tenant(ctx), q)
+	rows, err := r.db.QueryContext(ctx, rebindPostgres(query), args...)
+	if err != nil {
+		return models.AssignmentPage{}, mapSQLError(err, "list assignments")
+	}
+	return scanAssignmentPage(rows, q.Limit)
+}
+
+func (r *postgresAssignmentRepository) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	return auditHistory(ctx, r.db, rebindPostgres, id)
+}
+
+func (r *postgresAssignmentRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	return auditSince(ctx, r.db, rebindPostgres, afterID, limit)
+}
+
+func (r *postgresAssignmentRepository) LatestAuditID(ctx context.Context) (int64, error) {
+	return latestAuditID(ctx, r.db)
+}
+
+func (r *postgresAssignmentRepository) UpdateStatus(ctx context.Context, t models.AssignmentTransition) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "update assignment status")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	before, err := readAuditImage(ctx, tx, rebindPostgres, t.AssignmentID, true)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE assignments SET status = ?, version = version + 1, updated_at = now()
+		WHERE tenant_id = ? AND id = ? AND status = ?`
+	args := []any{t.To, requestctx.Tenant(ctx), t.AssignmentID, t.From}
+	if t.Version > 0 {
+		query += " AND version = ?"
+		args = append(args, t.Version)
+	}
+	res, err := tx.ExecContext(ctx, rebindPostgres(query), args...)
+	if err != nil {
+		return mapSQLError(err, "update assignment status")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		var (
+			current string
+			version int64
+		)
+		err := tx.QueryRowContext(ctx, `SELECT status, version FROM assignments WHERE tenant_id = $1 AND id = $2`, requestctx.Tenant(ctx), t.AssignmentID).Scan(&current, &version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.NewNotFoundError("assignment %s not found", t.AssignmentID)
+		}
+		if err != nil {
+			return mapSQLError(err, "update assignment status")
+		}
+		if t.Version > 0 && version != t.Version {
+			return models.NewPreconditionFailedError("assignment %s is at version %d, not %d", t.AssignmentID, version, t.Version)
+		}
+		return models.NewConflictError("assignment %s is %s, not %s", t.AssignmentID, current, t.From)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO assignment_transitions (tenant_id, assignment_id, from_status, to_status, actor, reason, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		requestctx.Tenant(ctx), t.AssignmentID, t.From, t.To, t.Actor, t.Reason, t.ChangedAt,
+	)
+	if err != nil {
+		return mapSQLError(err, "record assignment transition")
+	}
+
+	event, err := models.NewAssignmentStatusChangedEvent(t)
+	if err != nil {
+		return err
+	}
+	if err := insertPostgresOutboxEvent(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, rebindPostgres, transitionAuditEntry(ctx, t, before)); err != nil {
+		return err
+	}
+
+	return mapSQLError(tx.Commit(), "update assignment status")
+}
@@ -0,0 +1,155 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/adapters/repository/repositorytest"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/migrations"
+	"github.com/yourname/transport/ride/test_containers"
+)
+
+// openPostgresTestDB connects to the shared PostgreSQL container and applies
+// the PostgreSQL migrations.
+func openPostgresTestDB(ctx context.Context, t *testing.T) *sql.DB {
+	t.Helper()
+	host, port := test_containers.GetPostgresContainer(ctx, "testdb", "testuser", "testpass", nil)
+
+	dsn := fmt.Sprintf("postgres://testuser:testpass@%s:%s/testdb?sslmode=disable", host, port)
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	migrator, err := repository.NewPostgresMigrator(db, migrations.Postgres)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	return db
+}
+
+func TestPostgresAssignmentRepository_SaveAndFind(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openPostgresTestDB(ctx, t)
+	repo := repository.NewPostgresAssignmentRepository(db)
+	outbox := repository.NewPostgresOutboxStore(db)
+
+	a := models.Assignment{ID: "PG1", VehicleID: "V-PG1", RouteID: "R1", StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour), Status: "pending"}
+	isNew, err := repo.Save(ctx, a)
+	if err != nil || !isNew {
+		t.Fatalf("expected first Save to insert, got isNew=%v err=%v", isNew, err)
+	}
+	a.RouteID = "R2"
+	isNew, err = repo.Save(ctx, a)
+	if err != nil || isNew {
+		t.Fatalf("expected second Save to update, got isNew=%v err=%v", isNew, err)
+	}
+
+	got, err := repo.FindByID(ctx, "PG1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if got.RouteID != "R2" || got.Version != 2 || got.UpdatedAt.IsZero() {
+		t.Fatalf("expected version 2 on route R2 with updated_at, got %+v", got)
+	}
+
+	// Only the insert emits AssignmentCreated.
+	events := pendingFor(ctx, t, outbox, "PG1", time.Now().UTC())
+	if len(events) != 1 || events[0].Type != models.EventAssignmentCreated {
+		t.Fatalf("expected one AssignmentCreated event, got %+v", events)
+	}
+
+	err = repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: "PG1",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "tester",
+		ChangedAt:    time.Now(),
+		Version:      1,
+	})
+	if !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale transition, got %v", err)
+	}
+	err = repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: "PG1",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "tester",
+		ChangedAt:    time.Now(),
+		Version:      2,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	stale := got
+	stale.RouteID = "R3"
+	if _, err := repo.Save(ctx, stale); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale update, got %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestPostgresIdempotencyStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	store := repository.NewPostgresIdempotencyStore(openPostgresTestDB(ctx, t))
+
+	if _, reserved, err := store.Reserve(ctx, "pg-key", "fp", time.Hour); err != nil || !reserved {
+		t.Fatalf("expected key to be reserved, got reserved=%v err=%v", reserved, err)
+	}
+	rec, reserved, err := store.Reserve(ctx, "pg-key", "fp", time.Hour)
+	if err != nil || reserved || rec.Completed {
+		t.Fatalf("expected in-flight record, got %+v reserved=%v err=%v", rec, reserved, err)
+	}
+
+	resp := models.StoredResponse{StatusCode: 201, Header: map[string]string{"Location": "/assignments/1"}, Body: []byte(`{"id":"1"}`)}
+	if err := store.Complete(ctx, "pg-key", resp); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	rec, _, err = store.Reserve(ctx, "pg-key", "fp", time.Hour)
+	if err != nil || !rec.Completed || rec.Response.StatusCode != 201 || rec.Response.Header["Location"] != "/assignments/1" {
+		t.Fatalf("expected completed record, got %+v err=%v", rec, err)
+	}
+}
+
+func TestPostgresAssignmentRepository_Conformance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openPostgresTestDB(ctx, t)
+
+	repositorytest.Run(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewPostgresAssignmentRepository(db)
+	})
+}
+
+func TestPostgresAssignmentRepository_SagaResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openPostgresTestDB(ctx, t)
+
+	repositorytest.RunSagaResume(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewPostgresAssignmentRepository(db)
+	}, repository.NewPostgresAssignmentSagaStore(db))
+}
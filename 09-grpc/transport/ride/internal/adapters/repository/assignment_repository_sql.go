@@ -4,82 +4,445 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/yourname/transport/ride/internal/models"
 	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
 )
 
 type sqlAssignmentRepository struct {
 	db *sql.DB
 }
 
+// NewSQLAssignmentRepository stores assignments in MySQL. Every query is
+// scoped to the tenant of its context (requestctx.Tenant), so one tenant
+// can neither read nor overwrite another's assignments.
 func NewSQLAssignmentRepository(db *sql.DB) ports.AssignmentRepository {
 	return &sqlAssignmentRepository{db: db}
 }
 
+// assignmentColumns is the column list scanned by scanAssignment. updated_at
+// is maintained by MySQL (ON UPDATE CURRENT_TIMESTAMP); every write bumps
+// version, so it changes on every write as well.
+const assignmentColumns = `id, vehicle_id, vehicle_auto_selected, route_id, starts_at, ends_at, status, version, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAssignment(row rowScanner) (models.Assignment, error) {
+	var a models.Assignment
+	err := row.Scan(&a.ID, &a.VehicleID, &a.VehicleAutoSelected, &a.RouteID, &a.StartsAt, &a.EndsAt, &a.Status, &a.Version, &a.UpdatedAt)
+	return a, err
+}
+
 func (r *sqlAssignmentRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
-	res, err := r.db.ExecContext(ctx, `
-		INSERT INTO assignments (id, vehicle_id, route_id, starts_at, status)
-		VALUES (?, ?, ?, ?, ?)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, mapSQLError(err, "save assignment")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	isNew, err := saveAssignmentTx(ctx, tx, a)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, mapSQLError(err, "save assignment")
+	}
+	return isNew, nil
+}
+
+func (r *sqlAssignmentRepository) SaveAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, true)
+}
+
+func (r *sqlAssignmentRepository) CheckAll(ctx context.Context, as []models.Assignment) error {
+	return r.saveAll(ctx, as, false)
+}
+
+// saveAll saves as in one transaction and commits it if asked to and every
+// assignment passed.
+func (r *sqlAssignmentRepository) saveAll(ctx context.Context, as []models.Assignment, commit bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "save assignments")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	// Take every vehicle lock up front, in a fixed order, so two batches
+	// sharing vehicles cannot deadlock.
+	for _, vehicleID := range batchVehicles(as) {
+		if err := lockVehicleSchedule(ctx, tx, vehicleID); err != nil {
+			return err
+		}
+	}
+	if err := saveEachTx(ctx, tx, as, saveAssignmentTx); err != nil || !commit {
+		return err
+	}
+	return mapSQLError(tx.Commit(), "save assignments")
+}
+
+// saveEachTx saves every assignment of as within tx with save, each under a
+// savepoint that undoes it when it fails, and returns a *models.BatchError
+// naming those that did. The caller rolls tx back on any error.
+func saveEachTx(ctx context.Context, tx *sql.Tx, as []models.Assignment, save func(context.Context, *sql.Tx, models.Assignment) (bool, error)) error {
+	var failed []models.ItemError
+	for i, a := range as {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_item"); err != nil {
+			return mapSQLError(err, "save assignments")
+		}
+		_, err := save(ctx, tx, a)
+		switch {
+		case err == nil:
+			_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_item")
+		case isItemError(err):
+			failed = append(failed, models.ItemError{Index: i, Err: err})
+			_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item")
+		default:
+			return err
+		}
+		if err != nil {
+			return mapSQLError(err, "save assignments")
+		}
+	}
+	if len(failed) > 0 {
+		return &models.BatchError{Items: failed}
+	}
+	return nil
+}
+
+// saveAssignmentTx is Save within tx, which the caller commits.
+func saveAssignmentTx(ctx context.Context, tx *sql.Tx, a models.Assignment) (bool, error) {
+	// The overlap check below is this transaction's first consistent read,
+	// so its snapshot is taken only after the vehicle lock is held and
+	// includes every booking committed by the previous lock holder.
+	if err := lockVehicleSchedule(ctx, tx, a.VehicleID); err != nil {
+		return false, err
+	}
+	if err := checkVehicleSchedule(ctx, tx, a, bindMySQL); err != nil {
+		return false, err
+	}
+	before, err := readAuditImage(ctx, tx, bindMySQL, a.ID, a.Version > 0)
+	if err != nil {
+		return false, err
+	}
+
+	if a.Version > 0 {
+		if err := updateAtVersion(ctx, tx, a); err != nil {
+			return false, err
+		}
+		return false, recordAudit(ctx, tx, bindMySQL, newAuditEntry(ctx, a.ID, models.AuditActionUpdated, before))
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO assignments (tenant_id, id, vehicle_id, vehicle_auto_selected, route_id, starts_at, ends_at, status, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)
 		ON DUPLICATE KEY UPDATE
 		    vehicle_id = VALUES(vehicle_id),
+		    vehicle_auto_selected = VALUES(vehicle_auto_selected),
 		    route_id   = VALUES(route_id),
 		    starts_at  = VALUES(starts_at),
-		    status     = VALUES(status)`,
-		a.ID, a.VehicleID, a.RouteID, a.StartsAt, a.Status,
+		    ends_at    = VALUES(ends_at),
+		    version    = version + 1`,
+		requestctx.Tenant(ctx), a.ID, a.VehicleID, a.VehicleAutoSelected, a.RouteID, a.StartsAt, a.EndsAt, a.Status,
 	)
 	if err != nil {
-		return false, err
+		return false, mapSQLError(err, "save assignment")
 	}
 
 	rows, _ := res.RowsAffected()
 	isNew := rows == 1 // MySQL returns 1 for insert, 2 for update
 
+	action := models.AuditActionUpdated
+	if isNew {
+		action = models.AuditActionCreated
+		event, err := models.NewAssignmentCreatedEvent(a, time.Now().UTC())
+		if err != nil {
+			return false, err
+		}
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return false, err
+		}
+	}
+	if err := recordAudit(ctx, tx, bindMySQL, newAuditEntry(ctx, a.ID, action, before)); err != nil {
+		return false, err
+	}
 	return isNew, nil
 }
 
+// batchVehicles returns the distinct vehicles of as in lock order.
+func batchVehicles(as []models.Assignment) []string {
+	ids := make([]string, 0, len(as))
+	for _, a := range as {
+		ids = append(ids, a.VehicleID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// updateAtVersion is the optimistic-locking path of Save: the row is only
+// written if nobody changed it since the caller read a.Version.
+func updateAtVersion(ctx context.Context, tx *sql.Tx, a models.Assignment) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE assignments
+		SET vehicle_id = ?, vehicle_auto_selected = ?, route_id = ?, starts_at = ?, ends_at = ?, version = version + 1
+		WHERE tenant_id = ? AND id = ? AND version = ?`,
+		a.VehicleID, a.VehicleAutoSelected, a.RouteID, a.StartsAt, a.EndsAt, requestctx.Tenant(ctx), a.ID, a.Version,
+	)
+	if err != nil {
+		return mapSQLError(err, "update assignment")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		var current int64
+		err := tx.QueryRowContext(ctx, `SELECT version FROM assignments WHERE tenant_id = ? AND id = ?`, requestctx.Tenant(ctx), a.ID).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.NewNotFoundError("assignment %s not found", a.ID)
+		}
+		if err != nil {
+			return mapSQLError(err, "update assignment")
+		}
+		return models.NewPreconditionFailedError("assignment %s is at version %d, not %d", a.ID, current, a.Version)
+	}
+	return nil
+}
+
+// bindMySQL is the bind function of the helpers shared with PostgreSQL;
+// MySQL takes "?" placeholders as they are.
+func bindMySQL(query string) string { return query }
+
+// lockVehicleSchedule serialises writes to one vehicle's schedule until tx
+// ends. The upsert takes an exclusive lock on the vehicle's row whether or
+// not it existed, which SELECT ... FOR UPDATE cannot do for a vehicle's
+// first booking.
+func lockVehicleSchedule(ctx context.Context, tx *sql.Tx, vehicleID string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO vehicle_schedule_locks (tenant_id, vehicle_id) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE vehicle_id = vehicle_id`, requestctx.Tenant(ctx), vehicleID,
+	)
+	return mapSQLError(err, "lock vehicle schedule")
+}
+
+// maxReportedClashes bounds the assignment IDs named in a schedule conflict.
+const maxReportedClashes = 10
+
+// checkVehicleSchedule fails with a schedule conflict if another pending or
+// active assignment of a.VehicleID overlaps a. The caller must hold the
+// vehicle's lock. bind adapts the "?" placeholders to the driver.
+func checkVehicleSchedule(ctx context.Context, tx *sql.Tx, a models.Assignment, bind func(string) string) error {
+	rows, err := tx.QueryContext(ctx, bind(`
+		SELECT id FROM assignments
+		WHERE tenant_id = ? AND vehicle_id = ? AND id <> ? AND status NOT IN (?, ?)
+		  AND starts_at < ? AND ends_at > ?
+		ORDER BY starts_at, id
+		LIMIT ?`),
+		requestctx.Tenant(ctx), a.VehicleID, a.ID, models.AssignmentStatusCompleted, models.AssignmentStatusCancelled,
+		a.EndsAt, a.StartsAt, maxReportedClashes,
+	)
+	if err != nil {
+		return mapSQLError(err, "check vehicle schedule")
+	}
+	defer rows.Close()
+
+	var clashing []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return mapSQLError(err, "check vehicle schedule")
+		}
+		clashing = append(clashing, id)
+	}
+	if err := rows.Err(); err != nil {
+		return mapSQLError(err, "check vehicle schedule")
+	}
+	if len(clashing) > 0 {
+		return models.NewScheduleConflictError(a.VehicleID, clashing)
+	}
+	return nil
+}
+
 func (r *sqlAssignmentRepository) FindByID(ctx context.Context, id string) (models.Assignment, error) {
 	row := r.db.QueryRowContext(ctx, `
-		SELECT id, vehicle_id, route_id, starts_at, status
-		FROM assignments WHERE id = ?`, id,
+		SELECT `+assignmentColumns+`
+		FROM assignments WHERE tenant_id = ? AND id = ?`, requestctx.Tenant(ctx), id,
 	)
 
-	var a models.Assignment
-	err := row.Scan(&a.ID, &a.VehicleID, &a.RouteID, &a.StartsAt, &a.Status)
+	a, err := scanAssignment(row)
 	if err != nil {
-		//  err == sql.ErrNoRows
 		if errors.Is(err, sql.ErrNoRows) {
-			return models.Assignment{}, nil // caller decides how to handle "not found"
+			return models.Assignment{}, models.NewNotFoundError("assignment %s not found", id)
 		}
-		return models.Assignment{}, err
+		return models.Assignment{}, mapSQLError(err, "find assignment")
 	}
 	return a, nil
 }
 
-func (r *sqlAssignmentRepository) FindAll(ctx context.Context, status *string) ([]models.Assignment, error) {
-	q := `
-			SELECT id, vehicle_id, route_id, starts_at, status
-			FROM assignments`
-	args := []any{}
+// FindAll runs a keyset query: the page starts strictly after q.After in
+// (starts_at, id) order, so every page is an index range scan no matter how
+// deep the client has paged. One extra row is fetched to detect a next page.
+func (r *sqlAssignmentRepository) FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	query, args := listAssignmentsQuery(requestctx.Tenant(ctx), q)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.AssignmentPage{}, mapSQLError(err, "list assignments")
+	}
+	return scanAssignmentPage(rows, q.Limit)
+}
 
-	if status != nil {
-		q += ` WHERE status = ?`
-		args = append(args, *status)
+// listAssignmentsQuery builds the FindAll query of tenant with "?"
+// placeholders; the row-value comparison it uses for the cursor works in
+// MySQL and PostgreSQL.
+func listAssignmentsQuery(tenant string, q models.AssignmentQuery) (string, []any) {
+	where := []string{"tenant_id = ?"}
+	args := []any{tenant}
+	if q.Status != nil {
+		where = append(where, "status = ?")
+		args = append(args, *q.Status)
+	}
+	if q.VehicleID != nil {
+		where = append(where, "vehicle_id = ?")
+		args = append(args, *q.VehicleID)
+	}
+	if q.RouteID != nil {
+		where = append(where, "route_id = ?")
+		args = append(args, *q.RouteID)
+	}
+	if q.StartsFrom != nil {
+		where = append(where, "starts_at >= ?")
+		args = append(args, *q.StartsFrom)
+	}
+	if q.StartsTo != nil {
+		where = append(where, "starts_at < ?")
+		args = append(args, *q.StartsTo)
 	}
-	rows, err := r.db.QueryContext(ctx, q, args)
 
-	if err != nil {
-		return nil, err
+	dir, cmp := "ASC", ">"
+	if q.Order == models.SortDescending {
+		dir, cmp = "DESC", "<"
+	}
+	if q.After != nil {
+		where = append(where, "(starts_at, id) "+cmp+" (?, ?)")
+		args = append(args, q.After.StartsAt, q.After.ID)
 	}
+
+	query := `
+		SELECT ` + assignmentColumns + `
+		FROM assignments
+		WHERE ` + strings.Join(where, " AND ")
+	query += " ORDER BY starts_at " + dir + ", id " + dir
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit+1)
+	}
+	return query, args
+}
+
+func scanAssignmentPage(rows *sql.Rows, limit int) (models.AssignmentPage, error) {
 	defer rows.Close()
 
 	var assignments []models.Assignment
 	for rows.Next() {
-		var a models.Assignment
-		if err := rows.Scan(&a.ID, &a.VehicleID, &a.RouteID, &a.StartsAt, &a.Status); err != nil {
-			return nil, err
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return models.AssignmentPage{}, mapSQLError(err, "list assignments")
 		}
 		assignments = append(assignments, a)
 	}
-	return assignments, rows.Err()
+	if err := rows.Err(); err != nil {
+		return models.AssignmentPage{}, mapSQLError(err, "list assignments")
+	}
+
+	return pageOf(assignments, limit), nil
+}
+
+// pageOf trims the look-ahead row fetched by FindAll and derives the cursor.
+func pageOf(items []models.Assignment, limit int) models.AssignmentPage {
+	if limit <= 0 || len(items) <= limit {
+		return models.AssignmentPage{Items: items}
+	}
+	items = items[:limit]
+	next := models.CursorOf(items[len(items)-1])
+	return models.AssignmentPage{Items: items, Next: &next}
+}
+
+func (r *sqlAssignmentRepository) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	return auditHistory(ctx, r.db, bindMySQL, id)
+}
+
+func (r *sqlAssignmentRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	return auditSince(ctx, r.db, bindMySQL, afterID, limit)
+}
+
+func (r *sqlAssignmentRepository) LatestAuditID(ctx context.Context) (int64, error) {
+	return latestAuditID(ctx, r.db)
+}
+
+func (r *sqlAssignmentRepository) UpdateStatus(ctx context.Context, t models.AssignmentTransition) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "update assignment status")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	before, err := readAuditImage(ctx, tx, bindMySQL, t.AssignmentID, true)
+	if err != nil {
+		return err
+	}
+
+	// Compare-and-set on the current status makes concurrent transitions safe:
+	// only one of two racing writers can still see the expected status.
+	query := `
+		UPDATE assignments SET status = ?, version = version + 1
+		WHERE tenant_id = ? AND id = ? AND status = ?`
+	args := []any{t.To, requestctx.Tenant(ctx), t.AssignmentID, t.From}
+	if t.Version > 0 {
+		query += " AND version = ?"
+		args = append(args, t.Version)
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return mapSQLError(err, "update assignment status")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		var (
+			current string
+			version int64
+		)
+		err := tx.QueryRowContext(ctx, `SELECT status, version FROM assignments WHERE tenant_id = ? AND id = ?`, requestctx.Tenant(ctx), t.AssignmentID).Scan(&current, &version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.NewNotFoundError("assignment %s not found", t.AssignmentID)
+		}
+		if err != nil {
+			return mapSQLError(err, "update assignment status")
+		}
+		if t.Version > 0 && version != t.Version {
+			return models.NewPreconditionFailedError("assignment %s is at version %d, not %d", t.AssignmentID, version, t.Version)
+		}
+		return models.NewConflictError("assignment %s is %s, not %s", t.AssignmentID, current, t.From)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO assignment_transitions (tenant_id, assignment_id, from_status, to_status, actor, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		requestctx.Tenant(ctx), t.AssignmentID, t.From, t.To, t.Actor, t.Reason, t.ChangedAt,
+	)
+	if err != nil {
+		return mapSQLError(err, "record assignment transition")
+	}
+
+	event, err := models.NewAssignmentStatusChangedEvent(t)
+	if err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, bindMySQL, transitionAuditEntry(ctx, t, before)); err != nil {
+		return err
+	}
+
+	return mapSQLError(tx.Commit(), "update assignment status")
 }
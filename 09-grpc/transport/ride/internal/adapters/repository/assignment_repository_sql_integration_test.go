@@ -5,19 +5,25 @@ package repository_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/adapters/repository/repositorytest"
 	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/migrations"
 	"github.com/yourname/transport/ride/test_containers"
 )
 
-func TestSQLAssignmentRepository_SaveAndFind(t *testing.T) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-	defer cancel()
+// openTestDB connects to the shared MySQL container and makes sure the
+// schema exists.
+func openTestDB(ctx context.Context, t *testing.T) *sql.DB {
+	t.Helper()
 	host, port := test_containers.GetMySqlContainer(ctx, "testdb", "testuser", "testpass", nil)
 
 	dsn := fmt.Sprintf("testuser:testpass@tcp(%s:%s)/testdb?parseTime=true", host, port)
@@ -30,30 +36,35 @@ func TestSQLAssignmentRepository_SaveAndFind(t *testing.T) {
 		_ = db.Close()
 	})
 
-	// Schema setup
-	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS assignments (
-	    id VARCHAR(50) PRIMARY KEY,
-	    vehicle_id VARCHAR(50),
-	    route_id VARCHAR(50),
-	    starts_at DATETIME,
-	    status VARCHAR(20)
-	);`)
-
+	// The schema comes from the same embedded migrations production runs.
+	migrator, err := repository.NewMigrator(db, migrations.MySQL)
 	if err != nil {
-		t.Fatalf("failed to create table: %v", err)
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
 	}
 
+	return db
+}
+
+func TestSQLAssignmentRepository_SaveAndFind(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openTestDB(ctx, t)
+
 	repo := repository.NewSQLAssignmentRepository(db)
 
 	assignment := models.Assignment{
 		ID:        "A1",
-		VehicleID: "V1",
+		VehicleID: "V-A1",
 		RouteID:   "R1",
 		StartsAt:  time.Now(),
+		EndsAt:    time.Now().Add(time.Hour),
 		Status:    "pending",
 	}
 
-	_, err = repo.Save(context.Background(), assignment)
+	_, err := repo.Save(context.Background(), assignment)
 	if err != nil {
 		t.Fatalf("Save failed: %v", err)
 	}
@@ -66,4 +77,155 @@ func TestSQLAssignmentRepository_SaveAndFind(t *testing.T) {
 	if got.ID != assignment.ID {
 		t.Errorf("expected ID %s, got %s", assignment.ID, got.ID)
 	}
+
+	// A stale transition (wrong expected status) must be rejected.
+	err = repo.UpdateStatus(context.Background(), models.AssignmentTransition{
+		AssignmentID: "A1",
+		From:         models.AssignmentStatusActive,
+		To:           models.AssignmentStatusCompleted,
+		Actor:        "tester",
+		ChangedAt:    time.Now(),
+	})
+	if !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected conflict for stale transition, got %v", err)
+	}
+
+	err = repo.UpdateStatus(context.Background(), models.AssignmentTransition{
+		AssignmentID: "A1",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "tester",
+		ChangedAt:    time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	var actor string
+	if err := db.QueryRowContext(ctx, `SELECT actor FROM assignment_transitions WHERE assignment_id = ?`, "A1").Scan(&actor); err != nil {
+		t.Fatalf("transition not recorded: %v", err)
+	}
+	if actor != "tester" {
+		t.Errorf("expected actor tester, got %s", actor)
+	}
+
+	if _, err := repo.FindByID(context.Background(), "missing"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestSQLAssignmentRepository_OptimisticLocking(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	repo := repository.NewSQLAssignmentRepository(openTestDB(ctx, t))
+
+	a := models.Assignment{ID: "OL1", VehicleID: "V-OL1", RouteID: "R1", StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour), Status: "pending"}
+	if _, err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	got, err := repo.FindByID(ctx, "OL1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if got.Version != 1 || got.UpdatedAt.IsZero() {
+		t.Fatalf("expected version 1 with updated_at, got %+v", got)
+	}
+
+	// Two writers read version 1; only the first update wins.
+	first, second := got, got
+	first.RouteID, second.RouteID = "R2", "R3"
+	if _, err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	if _, err := repo.Save(ctx, second); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale update, got %v", err)
+	}
+
+	err = repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: "OL1",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "tester",
+		ChangedAt:    time.Now(),
+		Version:      1,
+	})
+	if !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale transition, got %v", err)
+	}
+
+	got, err = repo.FindByID(ctx, "OL1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if got.Version != 2 || got.RouteID != "R2" {
+		t.Fatalf("expected version 2 on route R2, got %+v", got)
+	}
+
+	missing := models.Assignment{ID: "missing", VehicleID: "V-missing", RouteID: "R1", StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour), Version: 1}
+	if _, err := repo.Save(ctx, missing); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found for versioned save of missing row, got %v", err)
+	}
+}
+
+func TestSQLAssignmentRepository_FindAllKeyset(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openTestDB(ctx, t)
+
+	repo := repository.NewSQLAssignmentRepository(db)
+	base := time.Date(2030, 5, 1, 6, 0, 0, 0, time.UTC)
+	for i, id := range []string{"K1", "K2", "K3", "K4", "K5"} {
+		_, err := repo.Save(ctx, models.Assignment{
+			ID:        id,
+			VehicleID: "V-" + id, // one vehicle cannot be booked twice at once
+			RouteID:   "R-keyset",
+			StartsAt:  base.Add(time.Duration(i/2) * time.Hour), // pairs share a start time
+			EndsAt:    base.Add(time.Duration(i/2)*time.Hour + 30*time.Minute),
+			Status:    "pending",
+		})
+		if err != nil {
+			t.Fatalf("Save %s failed: %v", id, err)
+		}
+	}
+
+	route := "R-keyset"
+	q := models.AssignmentQuery{RouteID: &route, Order: models.SortAscending, Limit: 2}
+	var got []string
+	for {
+		page, err := repo.FindAll(ctx, q)
+		if err != nil {
+			t.Fatalf("FindAll failed: %v", err)
+		}
+		for _, a := range page.Items {
+			got = append(got, a.ID)
+		}
+		if page.Next == nil {
+			break
+		}
+		q.After = page.Next
+	}
+
+	if want := "K1,K2,K3,K4,K5"; strings.Join(got, ",") != want {
+		t.Fatalf("expected %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestSQLAssignmentRepository_Conformance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openTestDB(ctx, t)
+
+	repositorytest.Run(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewSQLAssignmentRepository(db)
+	})
+}
+
+func TestSQLAssignmentRepository_SagaResume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openTestDB(ctx, t)
+
+	repositorytest.RunSagaResume(t, func(t *testing.T) ports.AssignmentRepository {
+		return repository.NewSQLAssignmentRepository(db)
+	}, repository.NewSQLAssignmentSagaStore(db))
 }
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+type memoryAssignmentSagaStore struct {
+	mu    sync.RWMutex
+	sagas map[string]models.AssignmentSaga
+}
+
+// NewMemoryAssignmentSagaStore keeps assignment sagas in process memory,
+// for unit tests and local runs.
+func NewMemoryAssignmentSagaStore() ports.AssignmentSagaStore {
+	return &memoryAssignmentSagaStore{sagas: map[string]models.AssignmentSaga{}}
+}
+
+func (st *memoryAssignmentSagaStore) Create(ctx context.Context, s models.AssignmentSaga) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if _, exists := st.sagas[s.ID]; exists {
+		return models.NewConflictError("create assignment saga: duplicate entry")
+	}
+	now := time.Now().UTC()
+	s.Version = 1
+	s.CreatedAt, s.UpdatedAt = now, now
+	st.sagas[s.ID] = s
+	return nil
+}
+
+func (st *memoryAssignmentSagaStore) Update(ctx context.Context, s models.AssignmentSaga) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	current, exists := st.sagas[s.ID]
+	if !exists {
+		return models.NewNotFoundError("assignment saga %s not found", s.ID)
+	}
+	if current.Version != s.Version {
+		return models.NewPreconditionFailedError("assignment saga %s is at version %d, not %d", s.ID, current.Version, s.Version)
+	}
+	current.State = s.State
+	current.Attempts = s.Attempts
+	current.LastError = s.LastError
+	current.Version++
+	current.UpdatedAt = time.Now().UTC()
+	st.sagas[s.ID] = current
+	return nil
+}
+
+func (st *memoryAssignmentSagaStore) FindByID(ctx context.Context, id string) (models.AssignmentSaga, error) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	s, ok := st.sagas[id]
+	if !ok {
+		return models.AssignmentSaga{}, models.NewNotFoundError("assignment saga %s not found", id)
+	}
+	return s, nil
+}
+
+func (st *memoryAssignmentSagaStore) ListUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]models.AssignmentSaga, error) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	var out []models.AssignmentSaga
+	for _, s := range st.sagas {
+		if !s.State.IsFinal() && s.UpdatedAt.Before(updatedBefore) {
+			out = append(out, s)
+		}
+	}
+	slices.SortFunc(out, func(a, b models.AssignmentSaga) int {
+		if c := a.UpdatedAt.Compare(b.UpdatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// sqlAssignmentSagaStore serves MySQL and PostgreSQL; bind adapts the "?"
+// placeholders to the driver.
+type sqlAssignmentSagaStore struct {
+	db   *sql.DB
+	bind func(string) string
+}
+
+// NewSQLAssignmentSagaStore keeps assignment sagas in MySQL.
+func NewSQLAssignmentSagaStore(db *sql.DB) ports.AssignmentSagaStore {
+	return &sqlAssignmentSagaStore{db: db, bind: bindMySQL}
+}
+
+func NewPostgresAssignmentSagaStore(db *sql.DB) ports.AssignmentSagaStore {
+	return &sqlAssignmentSagaStore{db: db, bind: rebindPostgres}
+}
+
+// maxSagaErrorLength keeps last_error within its column.
+const maxSagaErrorLength = 1024
+
+func truncateSagaError(s string) string {
+	if len(s) > maxSagaErrorLength {
+		return s[:maxSagaErrorLength]
+	}
+	return s
+}
+
+const assignmentSagaColumns = `id, tenant_id, kind, assignment_id, vehicle_id, vehicle_auto_selected, route_id, starts_at, ends_at, actor, request_id, state, attempts, last_error, version, created_at, updated_at`
+
+func scanAssignmentSaga(row rowScanner) (models.AssignmentSaga, error) {
+	var s models.AssignmentSaga
+	a := &s.Assignment
+	err := row.Scan(&s.ID, &s.TenantID, &s.Kind, &a.ID, &a.VehicleID, &a.VehicleAutoSelected, &a.RouteID, &a.StartsAt, &a.EndsAt,
+		&s.Actor, &s.RequestID, &s.State, &s.Attempts, &s.LastError, &s.Version, &s.CreatedAt, &s.UpdatedAt)
+	return s, err
+}
+
+func (st *sqlAssignmentSagaStore) Create(ctx context.Context, s models.AssignmentSaga) error {
+	now := time.Now().UTC()
+	a := s.Assignment
+	_, err := st.db.ExecContext(ctx, st.bind(`
+		INSERT INTO assignment_sagas (`+assignmentSagaColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?)`),
+		s.ID, s.TenantID, s.Kind, a.ID, a.VehicleID, a.VehicleAutoSelected, a.RouteID, a.StartsAt.UTC(), a.EndsAt.UTC(),
+		s.Actor, s.RequestID, s.State, s.Attempts, truncateSagaError(s.LastError), now, now,
+	)
+	return mapSQLError(err, "create assignment saga")
+}
+
+func (st *sqlAssignmentSagaStore) Update(ctx context.Context, s models.AssignmentSaga) error {
+	res, err := st.db.ExecContext(ctx, st.bind(`
+		UPDATE assignment_sagas
+		SET state = ?, attempts = ?, last_error = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`),
+		s.State, s.Attempts, truncateSagaError(s.LastError), time.Now().UTC(), s.ID, s.Version,
+	)
+	if err != nil {
+		return mapSQLError(err, "update assignment saga")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		current, err := st.FindByID(ctx, s.ID)
+		if err != nil {
+			return err
+		}
+		return models.NewPreconditionFailedError("assignment saga %s is at version %d, not %d", s.ID, current.Version, s.Version)
+	}
+	return nil
+}
+
+func (st *sqlAssignmentSagaStore) FindByID(ctx context.Context, id string) (models.AssignmentSaga, error) {
+	row := st.db.QueryRowContext(ctx, st.bind(`SELECT `+assignmentSagaColumns+` FROM assignment_sagas WHERE id = ?`), id)
+	s, err := scanAssignmentSaga(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AssignmentSaga{}, models.NewNotFoundError("assignment saga %s not found", id)
+		}
+		return models.AssignmentSaga{}, mapSQLError(err, "find assignment saga")
+	}
+	return s, nil
+}
+
+func (st *sqlAssignmentSagaStore) ListUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]models.AssignmentSaga, error) {
+	rows, err := st.db.QueryContext(ctx, st.bind(`
+		SELECT `+assignmentSagaColumns+`
+		FROM assignment_sagas
+		WHERE state NOT IN (?, ?) AND updated_at < ?
+		ORDER BY updated_at, id
+		LIMIT ?`),
+		models.AssignmentSagaCompleted, models.AssignmentSagaAborted, updatedBefore, limit,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "list unfinished assignment sagas")
+	}
+	defer rows.Close()
+
+	var out []models.AssignmentSaga
+	for rows.Next() {
+		s, err := scanAssignmentSaga(rows)
+		if err != nil {
+			return nil, mapSQLError(err, "list unfinished assignment sagas")
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapSQLError(err, "list unfinished assignment sagas")
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestSQLAssignmentSagaStore(t *testing.T) {
+	ctx := context.Background()
+	testAssignmentSagaStore(t, repository.NewSQLAssignmentSagaStore(openTestDB(ctx, t)))
+}
+
+func TestPostgresAssignmentSagaStore(t *testing.T) {
+	ctx := context.Background()
+	testAssignmentSagaStore(t, repository.NewPostgresAssignmentSagaStore(openPostgresTestDB(ctx, t)))
+}
+
+func testAssignmentSagaStore(t *testing.T, store ports.AssignmentSagaStore) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	saga := models.AssignmentSaga{
+		ID:       uuid.NewString(),
+		TenantID: "acme",
+		Kind:     models.AssignmentSagaMove,
+		Assignment: models.Assignment{
+			ID:        uuid.NewString(),
+			VehicleID: "V1",
+			RouteID:   "R1",
+			StartsAt:  start,
+			EndsAt:    start.Add(time.Hour),
+		},
+		Actor:     "alice",
+		RequestID: "req-1",
+		State:     models.AssignmentSagaStarted,
+	}
+	if err := store.Create(ctx, saga); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := store.Create(ctx, saga); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected a duplicate create to conflict, got %v", err)
+	}
+
+	got, err := store.FindByID(ctx, saga.ID)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if got.Version != 1 || got.TenantID != "acme" || got.Kind != models.AssignmentSagaMove || got.Actor != "alice" || got.State != models.AssignmentSagaStarted ||
+		got.Assignment.ID != saga.Assignment.ID || !got.Assignment.EndsAt.Equal(saga.Assignment.EndsAt) {
+		t.Fatalf("unexpected saga: %+v", got)
+	}
+
+	got.State = models.AssignmentSagaVehicleReserved
+	if err := store.Update(ctx, got); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := store.Update(ctx, got); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected a stale update to fail the precondition, got %v", err)
+	}
+
+	unfinished, err := store.ListUnfinished(ctx, time.Now().Add(time.Minute), 1000)
+	if err != nil {
+		t.Fatalf("list unfinished: %v", err)
+	}
+	if !containsSaga(unfinished, saga.ID) {
+		t.Fatalf("expected saga %s to be unfinished", saga.ID)
+	}
+	if unfinished, _ := store.ListUnfinished(ctx, time.Now().Add(-time.Minute), 1000); containsSaga(unfinished, saga.ID) {
+		t.Fatalf("expected a recently updated saga to be skipped")
+	}
+
+	got, _ = store.FindByID(ctx, saga.ID)
+	got.State = models.AssignmentSagaCompleted
+	if err := store.Update(ctx, got); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if unfinished, _ := store.ListUnfinished(ctx, time.Now().Add(time.Minute), 1000); containsSaga(unfinished, saga.ID) {
+		t.Fatalf("expected a completed saga to be skipped")
+	}
+}
+
+func containsSaga(sagas []models.AssignmentSaga, id string) bool {
+	for _, s := range sagas {
+		if s.ID == id {
+			return true
+		}
+	}
+	return false
+}
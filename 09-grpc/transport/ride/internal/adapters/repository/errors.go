@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+const (
+	mysqlErrDuplicateEntry = 1062
+
+	postgresErrUniqueViolation = "23505"
+	postgresErrClassConnection = "08"
+)
+
+// mapSQLError translates driver errors into domain errors so that services
+// and adapters never have to inspect MySQL error numbers or PostgreSQL
+// SQLSTATE codes themselves.
+func mapSQLError(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) && myErr.Number == mysqlErrDuplicateEntry {
+		return &models.DomainError{Kind: models.ErrConflict, Message: op + ": duplicate entry", Cause: err}
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == postgresErrUniqueViolation:
+			return &models.DomainError{Kind: models.ErrConflict, Message: op + ": duplicate entry", Cause: err}
+		case pqErr.Code.Class() == postgresErrClassConnection:
+			return models.NewUnavailableError(err, "%s: database unavailable", op)
+		}
+	}
+
+	// A cancelled query means the caller went away, not the database; it
+	// passes through so that callers can tell the two apart.
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr) {
+		return models.NewUnavailableError(err, "%s: database unavailable", op)
+	}
+
+	return err
+}
+
+// isItemError reports whether err is the fault of the item being written
+// rather than of the database, so a batch write can go on checking the
+// items after it.
+func isItemError(err error) bool {
+	var domainErr *models.DomainError
+	return errors.As(err, &domainErr) && !errors.Is(err, models.ErrUnavailable)
+}
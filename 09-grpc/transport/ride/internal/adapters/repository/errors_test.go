@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+func TestMapSQLError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "mysql duplicate", err: &mysql.MySQLError{Number: mysqlErrDuplicateEntry}, want: models.ErrConflict},
+		{name: "postgres unique violation", err: &pq.Error{Code: postgresErrUniqueViolation}, want: models.ErrConflict},
+		{name: "postgres connection failure", err: &pq.Error{Code: "08006"}, want: models.ErrUnavailable},
+		{name: "bad connection", err: driver.ErrBadConn, want: models.ErrUnavailable},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: models.ErrUnavailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapSQLError(tc.err, "find assignment")
+			if !errors.Is(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			if !errors.Is(got, tc.err) {
+				t.Fatalf("expected cause %v to be kept, got %v", tc.err, got)
+			}
+		})
+	}
+
+	for _, err := range []error{errors.New("syntax error"), context.Canceled} {
+		if got := mapSQLError(err, "find assignment"); got != err {
+			t.Fatalf("expected %v to pass through, got %v", err, got)
+		}
+	}
+}
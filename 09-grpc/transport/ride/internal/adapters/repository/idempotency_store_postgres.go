@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type postgresIdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyStore is NewSQLIdempotencyStore for PostgreSQL.
+func NewPostgresIdempotencyStore(db *sql.DB) ports.IdempotencyStore {
+	return &postgresIdempotencyStore{db: db}
+}
+
+func (s *postgresIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	now, tenant := time.Now().UTC(), requestctx.Tenant(ctx)
+
+	// An expired key behaves as if it had never been used.
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE tenant_id = $1 AND idempotency_key = $2 AND expires_at <= $3`, tenant, key, now,
+	); err != nil {
+		return models.IdempotencyRecord{}, false, mapSQLError(err, "reserve idempotency key")
+	}
+
+	// ON CONFLICT DO NOTHING instead of catching the unique violation: the
+	// statement does not fail, so it works inside a caller's transaction too.
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (tenant_id, idempotency_key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, idempotency_key) DO NOTHING`,
+		tenant, key, fingerprint, now, now.Add(ttl),
+	)
+	if err != nil {
+		return models.IdempotencyRecord{}, false, mapSQLError(err, "reserve idempotency key")
+	}
+	if rows, _ := res.RowsAffected(); rows == 1 {
+		return models.IdempotencyRecord{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(ttl)}, true, nil
+	}
+
+	rec, err := scanIdempotencyRecord(s.db.QueryRowContext(ctx, `
+		SELECT idempotency_key, fingerprint, status_code, response_headers, response_body, expires_at
+		FROM idempotency_keys WHERE tenant_id = $1 AND idempotency_key = $2`, tenant, key,
+	), key)
+	return rec, false, err
+}
+
+func (s *postgresIdempotencyStore) Complete(ctx context.Context, key string, resp models.StoredResponse) error {
+	headers, err := json.Marshal(resp.Header)
+	if err != nil {
+		return err
+	}
+	// Headers go in as text for the JSONB column; see insertPostgresOutboxEvent.
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = $1, response_headers = $2, response_body = $3
+		WHERE tenant_id = $4 AND idempotency_key = $5`,
+		resp.StatusCode, string(headers), resp.Body, requestctx.Tenant(ctx), key,
+	)
+	return mapSQLError(err, "complete idempotency key")
+}
+
+func (s *postgresIdempotencyStore) Release(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE tenant_id = $1 AND idempotency_key = $2 AND status_code IS NULL`, requestctx.Tenant(ctx), key,
+	)
+	return mapSQLError(err, "release idempotency key")
+}
+
+func (s *postgresIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, mapSQLError(err, "delete expired idempotency keys")
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type sqlIdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewSQLIdempotencyStore keeps idempotency keys in the idempotency_keys table
+// of the ride database, next to the assignments they protect. Keys are
+// scoped to the tenant in ctx, so tenants cannot replay each other's.
+func NewSQLIdempotencyStore(db *sql.DB) ports.IdempotencyStore {
+	return &sqlIdempotencyStore{db: db}
+}
+
+func (s *sqlIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	now, tenant := time.Now().UTC(), requestctx.Tenant(ctx)
+
+	// An expired key behaves as if it had never been used.
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ? AND expires_at <= ?`, tenant, key, now,
+	); err != nil {
+		return models.IdempotencyRecord{}, false, mapSQLError(err, "reserve idempotency key")
+	}
+
+	// The primary key arbitrates between concurrent requests: exactly one
+	// INSERT succeeds, every other caller reads the winner's record.
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (tenant_id, idempotency_key, fingerprint, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		tenant, key, fingerprint, now, now.Add(ttl),
+	)
+	if err == nil {
+		return models.IdempotencyRecord{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(ttl)}, true, nil
+	}
+	if err = mapSQLError(err, "reserve idempotency key"); !errors.Is(err, models.ErrConflict) {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	rec, err := s.find(ctx, key)
+	return rec, false, err
+}
+
+func (s *sqlIdempotencyStore) find(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	return scanIdempotencyRecord(s.db.QueryRowContext(ctx, `
+		SELECT idempotency_key, fingerprint, status_code, response_headers, response_body, expires_at
+		FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ?`, requestctx.Tenant(ctx), key,
+	), key)
+}
+
+func scanIdempotencyRecord(row rowScanner, key string) (models.IdempotencyRecord, error) {
+	var (
+		rec     models.IdempotencyRecord
+		status  sql.NullInt64
+		headers []byte
+		body    []byte
+	)
+	err := row.Scan(&rec.Key, &rec.Fingerprint, &status, &headers, &body, &rec.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between our INSERT and SELECT; the client may simply retry.
+		return models.IdempotencyRecord{}, models.NewConflictError("idempotency key %s is being released, retry", key)
+	}
+	if err != nil {
+		return models.IdempotencyRecord{}, mapSQLError(err, "find idempotency key")
+	}
+
+	if status.Valid {
+		rec.Completed = true
+		rec.Response.StatusCode = int(status.Int64)
+		rec.Response.Body = body
+		if len(headers) > 0 {
+			if err := json.Unmarshal(headers, &rec.Response.Header); err != nil {
+				return models.IdempotencyRecord{}, err
+			}
+		}
+	}
+	return rec, nil
+}
+
+func (s *sqlIdempotencyStore) Complete(ctx context.Context, key string, resp models.StoredResponse) error {
+	headers, err := json.Marshal(resp.Header)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = ?, response_headers = ?, response_body = ?
+		WHERE tenant_id = ? AND idempotency_key = ?`,
+		resp.StatusCode, headers, resp.Body, requestctx.Tenant(ctx), key,
+	)
+	return mapSQLError(err, "complete idempotency key")
+}
+
+func (s *sqlIdempotencyStore) Release(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE tenant_id = ? AND idempotency_key = ? AND status_code IS NULL`, requestctx.Tenant(ctx), key,
+	)
+	return mapSQLError(err, "release idempotency key")
+}
+
+func (s *sqlIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now)
+	if err != nil {
+		return 0, mapSQLError(err, "delete expired idempotency keys")
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,62 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+func TestSQLIdempotencyStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	store := repository.NewSQLIdempotencyStore(openTestDB(ctx, t))
+
+	_, reserved, err := store.Reserve(ctx, "K1", "fp-1", time.Hour)
+	if err != nil || !reserved {
+		t.Fatalf("expected first reserve to succeed, got reserved=%v err=%v", reserved, err)
+	}
+
+	rec, reserved, err := store.Reserve(ctx, "K1", "fp-1", time.Hour)
+	if err != nil || reserved || rec.Completed {
+		t.Fatalf("expected in-flight record, got %+v reserved=%v err=%v", rec, reserved, err)
+	}
+
+	resp := models.StoredResponse{StatusCode: 201, Header: map[string]string{"Location": "/assignments/A1"}, Body: []byte(`{"id":"A1"}`)}
+	if err := store.Complete(ctx, "K1", resp); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	rec, _, err = store.Reserve(ctx, "K1", "fp-1", time.Hour)
+	if err != nil || !rec.Completed || rec.Response.StatusCode != 201 ||
+		rec.Response.Header["Location"] != "/assignments/A1" || string(rec.Response.Body) != `{"id":"A1"}` {
+		t.Fatalf("unexpected stored record: %+v err=%v", rec, err)
+	}
+
+	// Released keys can be reserved again.
+	if _, _, err := store.Reserve(ctx, "K2", "fp-2", time.Hour); err != nil {
+		t.Fatalf("reserve K2: %v", err)
+	}
+	if err := store.Release(ctx, "K2"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, reserved, err := store.Reserve(ctx, "K2", "fp-3", time.Hour); err != nil || !reserved {
+		t.Fatalf("expected released key to be reservable, got reserved=%v err=%v", reserved, err)
+	}
+
+	// Expired keys are treated as unused and purged by DeleteExpired.
+	if _, _, err := store.Reserve(ctx, "K3", "fp-4", time.Millisecond); err != nil {
+		t.Fatalf("reserve K3: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, reserved, err := store.Reserve(ctx, "K3", "fp-5", time.Millisecond); err != nil || !reserved {
+		t.Fatalf("expected expired key to be reservable, got reserved=%v err=%v", reserved, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n, err := store.DeleteExpired(ctx, time.Now().UTC()); err != nil || n < 1 {
+		t.Fatalf("expected expired keys to be deleted, got n=%d err=%v", n, err)
+	}
+}
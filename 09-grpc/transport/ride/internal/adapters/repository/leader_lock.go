@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// postgresLeaderLockClass namespaces leader locks among PostgreSQL advisory
+// locks; postgresVehicleLockClass uses the same key space.
+const postgresLeaderLockClass int32 = 2
+
+// sessionLeaderLock holds a session-level database lock on a connection
+// reserved for it. The database releases such a lock when the session ends,
+// so a crashed leader hands over as soon as its connection is dropped.
+type sessionLeaderLock struct {
+	db      *sql.DB
+	acquire string
+	release string
+	args    []any
+
+	mu   sync.Mutex
+	conn *sql.Conn // non-nil while the lock is held
+}
+
+// NewMySQLLeaderLock elects a leader with GET_LOCK(name).
+func NewMySQLLeaderLock(db *sql.DB, name string) ports.LeaderLock {
+	return &sessionLeaderLock{
+		db:      db,
+		acquire: `SELECT GET_LOCK(?, 0)`,
+		release: `SELECT RELEASE_LOCK(?)`,
+		args:    []any{name},
+	}
+}
+
+// NewPostgresLeaderLock elects a leader with a session advisory lock keyed
+// by the hash of name.
+func NewPostgresLeaderLock(db *sql.DB, name string) ports.LeaderLock {
+	return &sessionLeaderLock{
+		db:      db,
+		acquire: `SELECT pg_try_advisory_lock($1, hashtext($2))`,
+		release: `SELECT pg_advisory_unlock($1, hashtext($2))`,
+		args:    []any{postgresLeaderLockClass, name},
+	}
+}
+
+func (l *sessionLeaderLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// The lock lives exactly as long as the session: if the connection
+		// still answers, leadership is still ours.
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		discard(l.conn)
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, mapSQLError(err, "acquire leader lock")
+	}
+	var acquired sql.NullBool
+	if err := conn.QueryRowContext(ctx, l.acquire, l.args...).Scan(&acquired); err != nil {
+		discard(conn) // the lock may have been granted before the error
+		return false, mapSQLError(err, "acquire leader lock")
+	}
+	if !acquired.Bool {
+		_ = conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *sessionLeaderLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	var ignored sql.NullBool
+	err := l.conn.QueryRowContext(ctx, l.release, l.args...).Scan(&ignored)
+	// Ending the session releases the lock even if the statement failed.
+	discard(l.conn)
+	l.conn = nil
+	return mapSQLError(err, "release leader lock")
+}
+
+// discard closes the session behind conn instead of returning it to the
+// pool, where it would keep holding its session-level locks.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -0,0 +1,52 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestMySQLLeaderLock(t *testing.T) {
+	db := openTestDB(context.Background(), t)
+	testLeaderLock(t, db, repository.NewMySQLLeaderLock)
+}
+
+func TestPostgresLeaderLock(t *testing.T) {
+	db := openPostgresTestDB(context.Background(), t)
+	testLeaderLock(t, db, repository.NewPostgresLeaderLock)
+}
+
+func testLeaderLock(t *testing.T, db *sql.DB, newLock func(*sql.DB, string) ports.LeaderLock) {
+	ctx := context.Background()
+	name := "leader-" + uuid.NewString()
+	first, second := newLock(db, name), newLock(db, name)
+
+	if ok, err := first.Acquire(ctx); err != nil || !ok {
+		t.Fatalf("first Acquire: ok=%v err=%v", ok, err)
+	}
+	if ok, err := first.Acquire(ctx); err != nil || !ok {
+		t.Fatalf("the leader must keep the lock: ok=%v err=%v", ok, err)
+	}
+	if ok, err := second.Acquire(ctx); err != nil || ok {
+		t.Fatalf("second Acquire while held: ok=%v err=%v", ok, err)
+	}
+
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if ok, err := second.Acquire(ctx); err != nil || !ok {
+		t.Fatalf("second Acquire after release: ok=%v err=%v", ok, err)
+	}
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("Release when not held: %v", err)
+	}
+}
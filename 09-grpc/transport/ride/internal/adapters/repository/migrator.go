@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration is one versioned schema change with its inverse.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a known migration has been applied.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Dirty marks a migration that started but did not finish; MySQL cannot
+	// roll back DDL, so it has to be repaired by hand.
+	Dirty bool
+}
+
+var migrationFileRE = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// LoadMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs from the
+// root of fsys, sorted by version. Every version needs both files.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := migrationFileRE.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_description.up.sql", e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		if version == 0 {
+			return nil, fmt.Errorf("migration %s: version must be > 0", e.Name())
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", e.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" || strings.TrimSpace(mig.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s needs non-empty up and down files", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+const (
+	migrationLockName    = "ride_schema_migrations"
+	migrationLockTimeout = 60 * time.Second
+)
+
+// Migrator applies embedded migrations to the ride database and records
+// them in schema_migrations. A database-wide named lock keeps concurrent
+// runners, e.g. several replicas starting at once, from applying the same
+// migration twice.
+type Migrator struct {
+	db         *sql.DB
+	dialect    migrationDialect
+	migrations []Migration
+}
+
+// migrationDialect holds what differs between databases: the lock and the
+// placeholder style. Queries are written with "?" and rebound.
+type migrationDialect struct {
+	timestampType string
+	lock          func(ctx context.Context, conn *sql.Conn) error
+	unlock        func(ctx context.Context, conn *sql.Conn)
+	rebind        func(query string) string
+}
+
+// NewMigrator returns a Migrator for MySQL, locking with GET_LOCK.
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, migrationDialect{
+		timestampType: "DATETIME(6)",
+		lock:          mysqlMigrationLock,
+		unlock: func(ctx context.Context, conn *sql.Conn) {
+			_, _ = conn.ExecContext(ctx, `SELECT RELEASE_LOCK(?)`, migrationLockName)
+		},
+		rebind: func(q string) string { return q },
+	})
+}
+
+// NewPostgresMigrator returns a Migrator for PostgreSQL, locking with a
+// session-level advisory lock.
+func NewPostgresMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, migrationDialect{
+		timestampType: "TIMESTAMPTZ",
+		lock:          postgresMigrationLock,
+		unlock: func(ctx context.Context, conn *sql.Conn) {
+			_, _ = conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, postgresMigrationLockKey)
+		},
+		rebind: rebindPostgres,
+	})
+}
+
+func newMigrator(db *sql.DB, fsys fs.FS, dialect migrationDialect) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+func mysqlMigrationLock(ctx context.Context, conn *sql.Conn) error {
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, migrationLockName, int(migrationLockTimeout.Seconds())).Scan(&got); err != nil {
+		return mapSQLError(err, "acquire migration lock")
+	}
+	if !got.Valid || got.Int64 != 1 {
+		return fmt.Errorf("another migration is running: could not acquire lock %q within %s", migrationLockName, migrationLockTimeout)
+	}
+	return nil
+}
+
+// postgresMigrationLockKey is an arbitrary constant identifying the ride
+// migration lock among the advisory locks of the database.
+const postgresMigrationLockKey int64 = 0x72696465 // "ride"
+
+func postgresMigrationLock(ctx context.Context, conn *sql.Conn) error {
+	deadline := time.Now().Add(migrationLockTimeout)
+	for {
+		var got bool
+		if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, postgresMigrationLockKey).Scan(&got); err != nil {
+			return mapSQLError(err, "acquire migration lock")
+		}
+		if got {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("another migration is running: could not acquire advisory lock within %s", migrationLockTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("down: steps must be >= 1")
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]MigrationStatus) error {
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, conn, m.migrations[i]); err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// To migrates up or down until exactly the migrations with a version <=
+// version are applied. Version 0 reverts everything.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]MigrationStatus) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; ok && mig.Version > version {
+				if err := m.revert(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; !ok && mig.Version <= version {
+				if err := m.apply(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists every known migration and whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, mapSQLError(err, "migration status")
+	}
+	defer conn.Close()
+
+	if err := m.ensureMigrationsTable(ctx, conn); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st, ok := applied[mig.Version]
+		st.Version, st.Name, st.Applied = mig.Version, mig.Name, ok
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock runs fn on a single connection holding the migration lock, after
+// refusing to continue from a dirty state.
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn, map[int64]MigrationStatus) error) error {
+	// Both locks are bound to the session, so everything must run on one connection.
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return mapSQLError(err, "migrate")
+	}
+	defer conn.Close()
+
+	if err := m.dialect.lock(ctx, conn); err != nil {
+		return err
+	}
+	defer m.dialect.unlock(context.WithoutCancel(ctx), conn)
+
+	if err := m.ensureMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for _, st := range applied {
+		if st.Dirty {
+			return fmt.Errorf("migration %d is dirty: a previous run failed half-way; repair the schema by hand and delete its row from schema_migrations", st.Version)
+		}
+	}
+	return fn(conn, applied)
+}
+
+func (m *Migrator) ensureMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    version BIGINT PRIMARY KEY,
+		    name VARCHAR(255) NOT NULL,
+		    dirty BOOLEAN NOT NULL,
+		    applied_at `+m.dialect.timestampType+` NOT NULL
+		)`)
+	return mapSQLError(err, "create schema_migrations")
+}
+
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]MigrationStatus, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, dirty, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, mapSQLError(err, "read schema_migrations")
+	}
+	defer rows.Close()
+
+	applied := map[int64]MigrationStatus{}
+	for rows.Next() {
+		var st MigrationStatus
+		if err := rows.Scan(&st.Version, &st.Name, &st.Dirty, &st.AppliedAt); err != nil {
+			return nil, mapSQLError(err, "read schema_migrations")
+		}
+		st.Applied = true
+		applied[st.Version] = st
+	}
+	return applied, mapSQLError(rows.Err(), "read schema_migrations")
+}
+
+// apply runs mig.Up. The row is written dirty first and cleaned afterwards,
+// so a crash in between is detected by the next run.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx, m.dialect.rebind(`
+		INSERT INTO schema_migrations (version, name, dirty, applied_at) VALUES (?, ?, TRUE, ?)`),
+		mig.Version, mig.Name, time.Now().UTC(),
+	); err != nil {
+		return mapSQLError(err, "record migration")
+	}
+	if err := execStatements(ctx, conn, mig.Up); err != nil {
+		return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+	}
+	_, err := conn.ExecContext(ctx, m.dialect.rebind(`UPDATE schema_migrations SET dirty = FALSE WHERE version = ?`), mig.Version)
+	return mapSQLError(err, "record migration")
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx, m.dialect.rebind(`UPDATE schema_migrations SET dirty = TRUE WHERE version = ?`), mig.Version); err != nil {
+		return mapSQLError(err, "record migration")
+	}
+	if err := execStatements(ctx, conn, mig.Down); err != nil {
+		return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+	}
+	_, err := conn.ExecContext(ctx, m.dialect.rebind(`DELETE FROM schema_migrations WHERE version = ?`), mig.Version)
+	return mapSQLError(err, "record migration")
+}
+
+// execStatements runs a migration file one statement at a time, so the DSN
+// does not need multiStatements. Statements end with ";" at the end of a
+// line; lines starting with "--" are comments.
+func execStatements(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, stmt := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitStatements(script string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(cur.String()), ";"))
+			cur.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(cur.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
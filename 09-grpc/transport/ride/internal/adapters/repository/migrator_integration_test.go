@@ -0,0 +1,120 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/migrations"
+	"github.com/yourname/transport/ride/test_containers"
+)
+
+// openScratchDB creates an empty database so that migrating down does not
+// disturb the schema the other tests share.
+func openScratchDB(ctx context.Context, t *testing.T, name string) *sql.DB {
+	t.Helper()
+	host, port := test_containers.GetMySqlContainer(ctx, "testdb", "testuser", "testpass", nil)
+
+	root, err := sql.Open("mysql", fmt.Sprintf("root:testpass@tcp(%s:%s)/?parseTime=true", host, port))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer root.Close()
+	if _, err := root.ExecContext(ctx, "DROP DATABASE IF EXISTS "+name); err != nil {
+		t.Fatalf("drop database: %v", err)
+	}
+	if _, err := root.ExecContext(ctx, "CREATE DATABASE "+name); err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+
+	db, err := sql.Open("mysql", fmt.Sprintf("root:testpass@tcp(%s:%s)/%s?parseTime=true", host, port, name))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestMigrator_UpDownTo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openScratchDB(ctx, t, "migrate_test")
+
+	m, err := repository.NewMigrator(db, migrations.MySQL)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	all, err := repository.LoadMigrations(migrations.MySQL)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	latest := all[len(all)-1].Version
+
+	// Several runners at once must not apply anything twice.
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for range 3 {
+		wg.Go(func() { errs <- m.Up(ctx) })
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Up: %v", err)
+		}
+	}
+	assertApplied(ctx, t, m, latest)
+
+	if err := m.Down(ctx, 1); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	assertApplied(ctx, t, m, latest-1)
+
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	assertApplied(ctx, t, m, 0)
+	var tables int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name <> 'schema_migrations'`).Scan(&tables); err != nil {
+		t.Fatalf("count tables: %v", err)
+	}
+	if tables != 0 {
+		t.Fatalf("expected every table to be dropped, %d left", tables)
+	}
+
+	if err := m.To(ctx, 2); err != nil {
+		t.Fatalf("To(2): %v", err)
+	}
+	assertApplied(ctx, t, m, 2)
+	if err := m.To(ctx, latest+1); err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+
+	// A half-applied migration blocks further runs until repaired.
+	if _, err := db.ExecContext(ctx, `UPDATE schema_migrations SET dirty = TRUE WHERE version = 2`); err != nil {
+		t.Fatalf("mark dirty: %v", err)
+	}
+	if err := m.Up(ctx); err == nil {
+		t.Fatal("expected Up to refuse a dirty schema")
+	}
+}
+
+// assertApplied checks that exactly the migrations up to version are applied.
+func assertApplied(ctx context.Context, t *testing.T, m *repository.Migrator, version int64) {
+	t.Helper()
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, st := range statuses {
+		if want := st.Version <= version; st.Applied != want || st.Dirty {
+			t.Fatalf("migration %d: applied=%v dirty=%v, want applied=%v", st.Version, st.Applied, st.Dirty, want)
+		}
+	}
+}
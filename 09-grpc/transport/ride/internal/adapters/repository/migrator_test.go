@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+	testCases := []struct {
+		name      string
+		fsys      fstest.MapFS
+		want      []int64
+		expectErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"0010_b.up.sql":   file("CREATE TABLE b (id INT);"),
+				"0010_b.down.sql": file("DROP TABLE b;"),
+				"0002_a.up.sql":   file("CREATE TABLE a (id INT);"),
+				"0002_a.down.sql": file("DROP TABLE a;"),
+			},
+			want: []int64{2, 10},
+		},
+		{
+			name:      "missing down file",
+			fsys:      fstest.MapFS{"0001_a.up.sql": file("CREATE TABLE a (id INT);")},
+			expectErr: true,
+		},
+		{
+			name:      "bad file name",
+			fsys:      fstest.MapFS{"create_a.sql": file("CREATE TABLE a (id INT);")},
+			expectErr: true,
+		},
+		{
+			name: "conflicting names for one version",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql":   file("CREATE TABLE a (id INT);"),
+				"0001_b.down.sql": file("DROP TABLE a;"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := repository.LoadMigrations(tc.fsys)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d migrations, got %d", len(tc.want), len(got))
+			}
+			for i, v := range tc.want {
+				if got[i].Version != v {
+					t.Errorf("migration %d: expected version %d, got %d", i, v, got[i].Version)
+				}
+			}
+		})
+	}
+}
+
+// The shipped migrations must always load; a broken file name would
+// otherwise only surface when the service is deployed.
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	mysql, err := repository.LoadMigrations(migrations.MySQL)
+	if err != nil {
+		t.Fatalf("LoadMigrations(mysql): %v", err)
+	}
+	postgres, err := repository.LoadMigrations(migrations.Postgres)
+	if err != nil {
+		t.Fatalf("LoadMigrations(postgres): %v", err)
+	}
+	for i, m := range mysql {
+		if m.Version != int64(i+1) {
+			t.Fatalf("expected contiguous versions, got %d at position %d", m.Version, i)
+		}
+	}
+	// Both dialects must describe the same schema history.
+	if len(postgres) != len(mysql) {
+		t.Fatalf("expected %d postgres migrations, got %d", len(mysql), len(postgres))
+	}
+	for i := range mysql {
+		if postgres[i].Version != mysql[i].Version || postgres[i].Name != mysql[i].Name {
+			t.Fatalf("migration %d differs: mysql %04d_%s, postgres %04d_%s",
+				i, mysql[i].Version, mysql[i].Name, postgres[i].Version, postgres[i].Name)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type postgresOutboxStore struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxStore(db *sql.DB) ports.OutboxStore {
+	return &postgresOutboxStore{db: db}
+}
+
+// insertPostgresOutboxEvent is insertOutboxEvent for PostgreSQL. The payload
+// is sent as text: lib/pq encodes []byte as bytea, which JSONB rejects.
+func insertPostgresOutboxEvent(ctx context.Context, tx *sql.Tx, e models.OutboxEvent) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox (tenant_id, aggregate_id, event_type, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		requestctx.Tenant(ctx), e.AggregateID, e.Type, string(e.Payload), e.CreatedAt, e.CreatedAt,
+	)
+	return mapSQLError(err, "insert outbox event")
+}
+
+func (s *postgresOutboxStore) FetchPending(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, tenant_id, aggregate_id, event_type, payload, created_at, attempts
+		FROM outbox
+		WHERE sent_at IS NULL AND next_attempt_at <= $1
+		ORDER BY id
+		LIMIT $2`, now, limit,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "fetch outbox events")
+	}
+	defer rows.Close()
+
+	var events []models.OutboxEvent
+	for rows.Next() {
+		var e models.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.AggregateID, &e.Type, &e.Payload, &e.CreatedAt, &e.Attempts); err != nil {
+			return nil, mapSQLError(err, "fetch outbox events")
+		}
+		events = append(events, e)
+	}
+	return events, mapSQLError(rows.Err(), "fetch outbox events")
+}
+
+func (s *postgresOutboxStore) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE outbox SET sent_at = $1 WHERE id = $2`, at, id)
+	return mapSQLError(err, "mark outbox event sent")
+}
+
+func (s *postgresOutboxStore) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	if len(reason) > maxOutboxErrorLength {
+		reason = reason[:maxOutboxErrorLength]
+	}
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2
+		WHERE id = $3`,
+		retryAt, reason, id,
+	)
+	return mapSQLError(err, "mark outbox event failed")
+}
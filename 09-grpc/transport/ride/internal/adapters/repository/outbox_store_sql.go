@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// maxOutboxErrorLength keeps last_error within its column.
+const maxOutboxErrorLength = 1024
+
+type sqlOutboxStore struct {
+	db *sql.DB
+}
+
+func NewSQLOutboxStore(db *sql.DB) ports.OutboxStore {
+	return &sqlOutboxStore{db: db}
+}
+
+// insertOutboxEvent adds e to the outbox inside tx, so the event exists if
+// and only if the change that caused it was committed. The event belongs to
+// the tenant in ctx.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, e models.OutboxEvent) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox (tenant_id, aggregate_id, event_type, payload, created_at, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		requestctx.Tenant(ctx), e.AggregateID, e.Type, e.Payload, e.CreatedAt, e.CreatedAt,
+	)
+	return mapSQLError(err, "insert outbox event")
+}
+
+func (s *sqlOutboxStore) FetchPending(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, tenant_id, aggregate_id, event_type, payload, created_at, attempts
+		FROM outbox
+		WHERE sent_at IS NULL AND next_attempt_at <= ?
+		ORDER BY id
+		LIMIT ?`, now, limit,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "fetch outbox events")
+	}
+	defer rows.Close()
+
+	var events []models.OutboxEvent
+	for rows.Next() {
+		var e models.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.AggregateID, &e.Type, &e.Payload, &e.CreatedAt, &e.Attempts); err != nil {
+			return nil, mapSQLError(err, "fetch outbox events")
+		}
+		events = append(events, e)
+	}
+	return events, mapSQLError(rows.Err(), "fetch outbox events")
+}
+
+func (s *sqlOutboxStore) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE outbox SET sent_at = ? WHERE id = ?`, at, id)
+	return mapSQLError(err, "mark outbox event sent")
+}
+
+func (s *sqlOutboxStore) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	if len(reason) > maxOutboxErrorLength {
+		reason = reason[:maxOutboxErrorLength]
+	}
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, last_error = ?
+		WHERE id = ?`,
+		retryAt, reason, id,
+	)
+	return mapSQLError(err, "mark outbox event failed")
+}
@@ -0,0 +1,70 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestSQLOutboxStore_SaveWritesEvent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	db := openTestDB(ctx, t)
+	repo := repository.NewSQLAssignmentRepository(db)
+	outbox := repository.NewSQLOutboxStore(db)
+
+	a := models.Assignment{ID: "OB1", VehicleID: "V-OB1", RouteID: "R1", StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour), Status: "pending"}
+	if _, err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	// Updating the assignment must not emit another AssignmentCreated.
+	a.RouteID = "R2"
+	if _, err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("second Save failed: %v", err)
+	}
+
+	events := pendingFor(ctx, t, outbox, "OB1", time.Now().UTC())
+	if len(events) != 1 || events[0].Type != models.EventAssignmentCreated {
+		t.Fatalf("expected one AssignmentCreated event, got %+v", events)
+	}
+
+	if err := outbox.MarkFailed(ctx, events[0].ID, time.Now().UTC().Add(time.Hour), "broker down"); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	if got := pendingFor(ctx, t, outbox, "OB1", time.Now().UTC()); len(got) != 0 {
+		t.Fatalf("expected deferred event to be hidden until retry time, got %+v", got)
+	}
+	later := time.Now().UTC().Add(2 * time.Hour)
+	if got := pendingFor(ctx, t, outbox, "OB1", later); len(got) != 1 || got[0].Attempts != 1 {
+		t.Fatalf("expected event due after backoff with 1 attempt, got %+v", got)
+	}
+
+	if err := outbox.MarkSent(ctx, events[0].ID, time.Now().UTC()); err != nil {
+		t.Fatalf("MarkSent: %v", err)
+	}
+	if got := pendingFor(ctx, t, outbox, "OB1", later); len(got) != 0 {
+		t.Fatalf("sent event is still pending: %+v", got)
+	}
+}
+
+// pendingFor returns the events of one aggregate that are due at now.
+func pendingFor(ctx context.Context, t *testing.T, outbox ports.OutboxStore, aggregateID string, now time.Time) []models.OutboxEvent {
+	t.Helper()
+	events, err := outbox.FetchPending(ctx, now, 1000)
+	if err != nil {
+		t.Fatalf("FetchPending: %v", err)
+	}
+	var out []models.OutboxEvent
+	for _, e := range events {
+		if e.AggregateID == aggregateID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rebindPostgres rewrites the "?" placeholders used throughout this package
+// into PostgreSQL's numbered "$n" form. Queries must not contain a literal
+// question mark.
+func rebindPostgres(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
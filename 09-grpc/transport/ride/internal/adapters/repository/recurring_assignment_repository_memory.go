@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type memoryRecurringAssignmentRepository struct {
+	mu    sync.RWMutex
+	items map[tenantKey]models.RecurringAssignment
+}
+
+// NewMemoryRecurringAssignmentRepository keeps recurring assignments in
+// process memory, for unit tests and local runs.
+func NewMemoryRecurringAssignmentRepository() ports.RecurringAssignmentRepository {
+	return &memoryRecurringAssignmentRepository{items: map[tenantKey]models.RecurringAssignment{}}
+}
+
+func (r *memoryRecurringAssignmentRepository) Save(ctx context.Context, ra models.RecurringAssignment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := keyOf(ctx, ra.ID)
+	current, exists := r.items[key]
+	if ra.Version == 0 && exists {
+		return false, models.NewConflictError("save recurring assignment: duplicate entry")
+	}
+	if ra.Version > 0 {
+		if err := r.checkVersion(key, ra.Version); err != nil {
+			return false, err
+		}
+	}
+	ra.Version = current.Version + 1
+	ra.UpdatedAt = time.Now().UTC()
+	ra.Exceptions = slices.Clone(ra.Exceptions)
+	r.items[key] = ra
+	return !exists, nil
+}
+
+// checkVersion mirrors versionMismatch; the caller holds r.mu.
+func (r *memoryRecurringAssignmentRepository) checkVersion(key tenantKey, version int64) error {
+	current, exists := r.items[key]
+	if !exists {
+		return models.NewNotFoundError("recurring assignment %s not found", key.id)
+	}
+	if version > 0 && current.Version != version {
+		return models.NewPreconditionFailedError("recurring assignment %s is at version %d, not %d", key.id, current.Version, version)
+	}
+	return nil
+}
+
+func (r *memoryRecurringAssignmentRepository) FindByID(ctx context.Context, id string) (models.RecurringAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ra, ok := r.items[keyOf(ctx, id)]
+	if !ok {
+		return models.RecurringAssignment{}, models.NewNotFoundError("recurring assignment %s not found", id)
+	}
+	ra.Exceptions = slices.Clone(ra.Exceptions)
+	return ra, nil
+}
+
+func (r *memoryRecurringAssignmentRepository) FindAll(ctx context.Context) ([]models.RecurringAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenant := requestctx.Tenant(ctx)
+	out := []models.RecurringAssignment{}
+	for key, ra := range r.items {
+		if key.tenant != tenant {
+			continue
+		}
+		ra.Exceptions = slices.Clone(ra.Exceptions)
+		out = append(out, ra)
+	}
+	slices.SortFunc(out, func(a, b models.RecurringAssignment) int { return strings.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+func (r *memoryRecurringAssignmentRepository) Delete(ctx context.Context, id string, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := keyOf(ctx, id)
+	if err := r.checkVersion(key, version); err != nil {
+		return err
+	}
+	delete(r.items, key)
+	return nil
+}
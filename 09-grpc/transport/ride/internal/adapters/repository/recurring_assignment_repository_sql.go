@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// sqlRecurringAssignmentRepository only uses SQL that MySQL and PostgreSQL
+// share; bind adapts the "?" placeholders to the driver. Rules are scoped to
+// the tenant in ctx.
+type sqlRecurringAssignmentRepository struct {
+	db   *sql.DB
+	bind func(string) string
+}
+
+func NewSQLRecurringAssignmentRepository(db *sql.DB) ports.RecurringAssignmentRepository {
+	return &sqlRecurringAssignmentRepository{db: db, bind: func(q string) string { return q }}
+}
+
+func NewPostgresRecurringAssignmentRepository(db *sql.DB) ports.RecurringAssignmentRepository {
+	return &sqlRecurringAssignmentRepository{db: db, bind: rebindPostgres}
+}
+
+const recurringAssignmentColumns = `id, vehicle_id, route_id, rrule, timezone, starts_at, duration_seconds, ends_on, exceptions, version, updated_at`
+
+// dateLayout is how ends_on and exceptions are written. Dates are sent as
+// strings so the database never converts them through a session time zone.
+const dateLayout = time.DateOnly
+
+func scanRecurringAssignment(row rowScanner) (models.RecurringAssignment, error) {
+	var (
+		r          models.RecurringAssignment
+		seconds    int64
+		endsOn     sql.NullTime
+		exceptions string
+	)
+	err := row.Scan(&r.ID, &r.VehicleID, &r.RouteID, &r.RRule, &r.Timezone, &r.StartsAt, &seconds, &endsOn, &exceptions, &r.Version, &r.UpdatedAt)
+	if err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	r.StartsAt = r.StartsAt.UTC()
+	r.Duration = time.Duration(seconds) * time.Second
+	if endsOn.Valid {
+		d := models.DateOf(endsOn.Time)
+		r.EndsOn = &d
+	}
+	if r.Exceptions, err = parseDates(exceptions); err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	return r, nil
+}
+
+func formatDates(dates []time.Time) string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format(dateLayout)
+	}
+	return strings.Join(out, ",")
+}
+
+func parseDates(s string) ([]time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	dates := make([]time.Time, len(parts))
+	for i, p := range parts {
+		d, err := time.Parse(dateLayout, p)
+		if err != nil {
+			return nil, err
+		}
+		dates[i] = d
+	}
+	return dates, nil
+}
+
+func (r *sqlRecurringAssignmentRepository) Save(ctx context.Context, ra models.RecurringAssignment) (bool, error) {
+	var endsOn any
+	if ra.EndsOn != nil {
+		endsOn = ra.EndsOn.Format(dateLayout)
+	}
+	args := []any{
+		ra.VehicleID, ra.RouteID, ra.RRule, ra.Timezone, ra.StartsAt.UTC(),
+		int64(ra.Duration / time.Second), endsOn, formatDates(ra.Exceptions), time.Now().UTC(),
+	}
+
+	if ra.Version == 0 {
+		_, err := r.db.ExecContext(ctx, r.bind(`
+			INSERT INTO recurring_assignments (vehicle_id, route_id, rrule, timezone, starts_at, duration_seconds, ends_on, exceptions, updated_at, version, tenant_id, id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?)`),
+			append(args, requestctx.Tenant(ctx), ra.ID)...,
+		)
+		return err == nil, mapSQLError(err, "save recurring assignment")
+	}
+
+	res, err := r.db.ExecContext(ctx, r.bind(`
+		UPDATE recurring_assignments
+		SET vehicle_id = ?, route_id = ?, rrule = ?, timezone = ?, starts_at = ?,
+		    duration_seconds = ?, ends_on = ?, exceptions = ?, updated_at = ?, version = version + 1
+		WHERE tenant_id = ? AND id = ? AND version = ?`),
+		append(args, requestctx.Tenant(ctx), ra.ID, ra.Version)...,
+	)
+	if err != nil {
+		return false, mapSQLError(err, "update recurring assignment")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return false, r.versionMismatch(ctx, ra.ID, ra.Version)
+	}
+	return false, nil
+}
+
+// versionMismatch explains why a conditional write touched no row.
+func (r *sqlRecurringAssignmentRepository) versionMismatch(ctx context.Context, id string, version int64) error {
+	var current int64
+	err := r.db.QueryRowContext(ctx, r.bind(`SELECT version FROM recurring_assignments WHERE tenant_id = ? AND id = ?`), requestctx.Tenant(ctx), id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.NewNotFoundError("recurring assignment %s not found", id)
+	}
+	if err != nil {
+		return mapSQLError(err, "find recurring assignment")
+	}
+	return models.NewPreconditionFailedError("recurring assignment %s is at version %d, not %d", id, current, version)
+}
+
+func (r *sqlRecurringAssignmentRepository) FindByID(ctx context.Context, id string) (models.RecurringAssignment, error) {
+	row := r.db.QueryRowContext(ctx, r.bind(`
+		SELECT `+recurringAssignmentColumns+`
+		FROM recurring_assignments WHERE tenant_id = ? AND id = ?`), requestctx.Tenant(ctx), id,
+	)
+	ra, err := scanRecurringAssignment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RecurringAssignment{}, models.NewNotFoundError("recurring assignment %s not found", id)
+		}
+		return models.RecurringAssignment{}, mapSQLError(err, "find recurring assignment")
+	}
+	return ra, nil
+}
+
+func (r *sqlRecurringAssignmentRepository) FindAll(ctx context.Context) ([]models.RecurringAssignment, error) {
+	rows, err := r.db.QueryContext(ctx, r.bind(`
+		SELECT `+recurringAssignmentColumns+`
+		FROM recurring_assignments WHERE tenant_id = ? ORDER BY id`), requestctx.Tenant(ctx),
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "list recurring assignments")
+	}
+	defer rows.Close()
+
+	var out []models.RecurringAssignment
+	for rows.Next() {
+		ra, err := scanRecurringAssignment(rows)
+		if err != nil {
+			return nil, mapSQLError(err, "list recurring assignments")
+		}
+		out = append(out, ra)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapSQLError(err, "list recurring assignments")
+	}
+	return out, nil
+}
+
+func (r *sqlRecurringAssignmentRepository) Delete(ctx context.Context, id string, version int64) error {
+	query := `DELETE FROM recurring_assignments WHERE tenant_id = ? AND id = ?`
+	args := []any{requestctx.Tenant(ctx), id}
+	if version > 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	res, err := r.db.ExecContext(ctx, r.bind(query), args...)
+	if err != nil {
+		return mapSQLError(err, "delete recurring assignment")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return r.versionMismatch(ctx, id, version)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestSQLRecurringAssignmentRepository(t *testing.T) {
+	ctx := context.Background()
+	testRecurringAssignmentRepository(t, repository.NewSQLRecurringAssignmentRepository(openTestDB(ctx, t)))
+}
+
+func TestPostgresRecurringAssignmentRepository(t *testing.T) {
+	ctx := context.Background()
+	testRecurringAssignmentRepository(t, repository.NewPostgresRecurringAssignmentRepository(openPostgresTestDB(ctx, t)))
+}
+
+func testRecurringAssignmentRepository(t *testing.T, repo ports.RecurringAssignmentRepository) {
+	ctx := context.Background()
+	endsOn := time.Date(2031, 12, 19, 0, 0, 0, 0, time.UTC)
+	r := models.RecurringAssignment{
+		ID:         uuid.NewString(),
+		VehicleID:  "V-RA1",
+		RouteID:    "R-RA1",
+		RRule:      "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		Timezone:   "Europe/Berlin",
+		StartsAt:   time.Date(2031, 3, 3, 6, 30, 0, 0, time.UTC),
+		Duration:   45 * time.Minute,
+		EndsOn:     &endsOn,
+		Exceptions: []time.Time{time.Date(2031, 4, 18, 0, 0, 0, 0, time.UTC), time.Date(2031, 4, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	isNew, err := repo.Save(ctx, r)
+	if err != nil || !isNew {
+		t.Fatalf("Save: isNew=%v err=%v", isNew, err)
+	}
+	if _, err := repo.Save(ctx, r); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected conflict for a duplicate insert, got %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.RRule != r.RRule || got.Duration != r.Duration || !got.StartsAt.Equal(r.StartsAt) ||
+		got.EndsOn == nil || !got.EndsOn.Equal(endsOn) || len(got.Exceptions) != 2 || !got.Exceptions[1].Equal(r.Exceptions[1]) || got.Version != 1 {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+
+	got.EndsOn, got.Exceptions = nil, nil
+	if _, err := repo.Save(ctx, got); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := repo.Save(ctx, got); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for a stale version, got %v", err)
+	}
+	updated, err := repo.FindByID(ctx, r.ID)
+	if err != nil || updated.EndsOn != nil || len(updated.Exceptions) != 0 || updated.Version != 2 {
+		t.Fatalf("unexpected rule after update: %+v (err %v)", updated, err)
+	}
+
+	all, err := repo.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	found := false
+	for _, ra := range all {
+		found = found || ra.ID == r.ID
+	}
+	if !found {
+		t.Fatalf("FindAll did not return %s", r.ID)
+	}
+
+	if err := repo.Delete(ctx, r.ID, 1); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for a stale delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, r.ID, 2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, r.ID); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
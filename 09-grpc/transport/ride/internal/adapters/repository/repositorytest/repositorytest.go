@@ -0,0 +1,632 @@
+// Package repositorytest is a conformance suite for ports.AssignmentRepository.
+// Every adapter runs it, so the in-memory repository used in unit tests
+// cannot drift from the SQL ones.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// Factory returns the repository under test. It may return the same
+// database for every call: the suite only reads rows it wrote itself.
+type Factory func(t *testing.T) ports.AssignmentRepository
+
+// Run executes the conformance suite against the repositories made by newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	testCases := []struct {
+		name string
+		run  func(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string)
+	}{
+		{name: "save reports isNew", run: testSaveIsNew},
+		{name: "find missing", run: testFindMissing},
+		{name: "versioned save", run: testVersionedSave},
+		{name: "concurrent inserts", run: testConcurrentInserts},
+		{name: "update status", run: testUpdateStatus},
+		{name: "audit trail", run: testAuditTrail},
+		{name: "audit since", run: testAuditSince},
+		{name: "find all filters by status", run: testFindAllStatusFilter},
+		{name: "find all time range", run: testFindAllTimeRange},
+		{name: "find all keyset pages", run: testFindAllKeyset},
+		{name: "vehicle schedule conflicts", run: testScheduleConflicts},
+		{name: "concurrent overlapping creates", run: testConcurrentOverlappingCreates},
+		{name: "save all", run: testSaveAll},
+		{name: "tenant isolation", run: testTenantIsolation},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			// A fresh prefix keeps runs apart when adapters share a database.
+			tc.run(ctx, t, newRepo(t), uuid.NewString()[:8])
+		})
+	}
+}
+
+// base is a whole second so that every adapter stores it exactly.
+var base = time.Date(2031, 3, 4, 8, 0, 0, 0, time.UTC)
+
+func newAssignment(prefix, id string, startsAt time.Time) models.Assignment {
+	return models.Assignment{
+		ID:        prefix + "-" + id,
+		VehicleID: prefix + "-V1",
+		RouteID:   prefix + "-R1",
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(30 * time.Minute),
+		Status:    string(models.AssignmentStatusPending),
+	}
+}
+
+func mustSave(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, a models.Assignment) {
+	t.Helper()
+	if _, err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("Save %s: %v", a.ID, err)
+	}
+}
+
+func mustFind(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, id string) models.Assignment {
+	t.Helper()
+	a, err := repo.FindByID(ctx, id)
+	if err != nil {
+		t.Fatalf("FindByID %s: %v", id, err)
+	}
+	return a
+}
+
+func testSaveIsNew(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	a := newAssignment(prefix, "A", base)
+	isNew, err := repo.Save(ctx, a)
+	if err != nil || !isNew {
+		t.Fatalf("first Save: expected isNew, got isNew=%v err=%v", isNew, err)
+	}
+
+	got := mustFind(ctx, t, repo, a.ID)
+	if got.VehicleID != a.VehicleID || got.RouteID != a.RouteID || !got.StartsAt.Equal(a.StartsAt) || got.Status != a.Status {
+		t.Fatalf("stored %+v, want %+v", got, a)
+	}
+	if got.Version != 1 || got.UpdatedAt.IsZero() {
+		t.Fatalf("expected version 1 with updated_at, got %+v", got)
+	}
+
+	// An upsert changes the fields but never the status.
+	a.RouteID = prefix + "-R2"
+	a.StartsAt = base.Add(time.Hour)
+	a.Status = string(models.AssignmentStatusCompleted)
+	isNew, err = repo.Save(ctx, a)
+	if err != nil || isNew {
+		t.Fatalf("second Save: expected update, got isNew=%v err=%v", isNew, err)
+	}
+
+	got = mustFind(ctx, t, repo, a.ID)
+	if got.RouteID != a.RouteID || !got.StartsAt.Equal(a.StartsAt) {
+		t.Fatalf("update not stored: %+v", got)
+	}
+	if got.Status != string(models.AssignmentStatusPending) {
+		t.Fatalf("Save changed status to %s", got.Status)
+	}
+	if got.Version != 2 {
+		t.Fatalf("expected version 2, got %d", got.Version)
+	}
+}
+
+func testFindMissing(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	if _, err := repo.FindByID(ctx, prefix+"-missing"); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func testVersionedSave(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	mustSave(ctx, t, repo, newAssignment(prefix, "A", base))
+	current := mustFind(ctx, t, repo, prefix+"-A")
+
+	first, second := current, current
+	first.RouteID, second.RouteID = prefix+"-R2", prefix+"-R3"
+	if _, err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Save at current version: %v", err)
+	}
+	if _, err := repo.Save(ctx, second); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale version, got %v", err)
+	}
+	if got := mustFind(ctx, t, repo, prefix+"-A"); got.RouteID != first.RouteID || got.Version != 2 {
+		t.Fatalf("expected first write at version 2, got %+v", got)
+	}
+
+	missing := newAssignment(prefix, "missing", base)
+	missing.Version = 1
+	if _, err := repo.Save(ctx, missing); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found for versioned save of a missing assignment, got %v", err)
+	}
+}
+
+func testConcurrentInserts(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	const writers = 4
+	a := newAssignment(prefix, "A", base)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		inserts int
+	)
+	for range writers {
+		wg.Go(func() {
+			isNew, err := repo.Save(ctx, a)
+			if err != nil {
+				t.Errorf("Save: %v", err)
+				return
+			}
+			if isNew {
+				mu.Lock()
+				inserts++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if inserts != 1 {
+		t.Fatalf("expected exactly one Save to report isNew, got %d", inserts)
+	}
+	if got := mustFind(ctx, t, repo, a.ID); got.Version != writers {
+		t.Fatalf("expected version %d after %d writes, got %d", writers, writers, got.Version)
+	}
+}
+
+func testAuditTrail(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	ctx = requestctx.WithRequestID(requestctx.WithActor(ctx, "dispatcher-7"), prefix+"-req")
+	a := newAssignment(prefix, "A", base)
+	a.VehicleAutoSelected = true
+	mustSave(ctx, t, repo, a)
+	if got := mustFind(ctx, t, repo, a.ID); !got.VehicleAutoSelected {
+		t.Fatalf("expected the auto-selected vehicle to be stored, got %+v", got)
+	}
+	a.Version, a.RouteID, a.VehicleAutoSelected = 1, prefix+"-R2", false
+	mustSave(ctx, t, repo, a)
+	if _, err := repo.Save(ctx, a); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale version, got %v", err)
+	}
+	err := repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: a.ID,
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "scheduler",
+		ChangedAt:    base,
+		Version:      2,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	entries, err := repo.History(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	wantActions := []models.AuditAction{models.AuditActionCreated, models.AuditActionUpdated, models.AuditActionStatusChanged}
+	if len(entries) != len(wantActions) {
+		t.Fatalf("expected %d entries (failed writes leave none), got %+v", len(wantActions), entries)
+	}
+	for i, e := range entries {
+		if e.Action != wantActions[i] || e.AssignmentID != a.ID || e.RequestID != prefix+"-req" {
+			t.Errorf("entry %d: unexpected %+v", i, e)
+		}
+		if i > 0 && e.ID <= entries[i-1].ID {
+			t.Errorf("entry %d: ids not ascending", i)
+		}
+		if e.After.Version != int64(i+1) {
+			t.Errorf("entry %d: expected after at version %d, got %d", i, i+1, e.After.Version)
+		}
+		if i == 0 {
+			if e.Before != nil {
+				t.Errorf("created entry has a before snapshot: %+v", e.Before)
+			}
+			continue
+		}
+		if e.Before == nil || e.Before.Version != int64(i) {
+			t.Errorf("entry %d: expected before at version %d, got %+v", i, i, e.Before)
+		}
+	}
+	if got := entries[0].Actor; got != "dispatcher-7" {
+		t.Errorf("expected actor from context, got %q", got)
+	}
+	if !entries[0].After.VehicleAutoSelected {
+		t.Errorf("created entry does not show the auto-selected vehicle: %+v", entries[0].After)
+	}
+	if got := entries[1]; got.Before.RouteID != prefix+"-R1" || got.After.RouteID != prefix+"-R2" || !got.Before.VehicleAutoSelected || got.After.VehicleAutoSelected {
+		t.Errorf("update entry does not show the route change: %+v -> %+v", got.Before, got.After)
+	}
+	if got := entries[2]; got.Actor != "scheduler" || !got.At.Equal(base) ||
+		got.Before.Status != string(models.AssignmentStatusPending) || got.After.Status != string(models.AssignmentStatusActive) {
+		t.Errorf("unexpected status change entry: %+v", got)
+	}
+
+	if missing, err := repo.History(ctx, prefix+"-missing"); err != nil || len(missing) != 0 {
+		t.Fatalf("expected empty history for unknown id, got %v, %v", missing, err)
+	}
+}
+
+func testAuditSince(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	start, err := repo.LatestAuditID(ctx)
+	if err != nil {
+		t.Fatalf("LatestAuditID: %v", err)
+	}
+	a := newAssignment(prefix, "A", base)
+	mustSave(ctx, t, repo, a)
+	mustSave(ctx, t, repo, newAssignment(prefix, "B", base.Add(time.Hour)))
+	a.Version, a.RouteID = 1, prefix+"-R2"
+	mustSave(ctx, t, repo, a)
+
+	latest, err := repo.LatestAuditID(ctx)
+	if err != nil || latest < start+3 {
+		t.Fatalf("LatestAuditID: expected at least %d, got %d (err %v)", start+3, latest, err)
+	}
+	first, err := repo.AuditSince(ctx, start, 2)
+	if err != nil {
+		t.Fatalf("AuditSince: %v", err)
+	}
+	if len(first) != 2 || first[0].AssignmentID != a.ID || first[1].AssignmentID != prefix+"-B" ||
+		first[0].ID <= start || first[1].ID <= first[0].ID {
+		t.Fatalf("expected the two creations oldest first, got %+v", first)
+	}
+	rest, err := repo.AuditSince(ctx, first[1].ID, 10)
+	if err != nil {
+		t.Fatalf("AuditSince: %v", err)
+	}
+	if len(rest) != 1 || rest[0].Action != models.AuditActionUpdated || rest[0].After.RouteID != prefix+"-R2" || rest[0].ID != latest {
+		t.Fatalf("expected only the update after %d, got %+v", first[1].ID, rest)
+	}
+	if none, err := repo.AuditSince(ctx, latest, 10); err != nil || len(none) != 0 {
+		t.Fatalf("expected nothing after the latest entry, got %+v (err %v)", none, err)
+	}
+}
+
+func testUpdateStatus(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	mustSave(ctx, t, repo, newAssignment(prefix, "A", base))
+	id := prefix + "-A"
+	transition := func(from, to models.AssignmentStatus, version int64) error {
+		return repo.UpdateStatus(ctx, models.AssignmentTransition{
+			AssignmentID: id,
+			From:         from,
+			To:           to,
+			Actor:        "conformance",
+			ChangedAt:    time.Now().UTC(),
+			Version:      version,
+		})
+	}
+
+	if err := transition(models.AssignmentStatusActive, models.AssignmentStatusCompleted, 0); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected conflict for stale status, got %v", err)
+	}
+	if err := transition(models.AssignmentStatusPending, models.AssignmentStatusActive, 7); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for stale version, got %v", err)
+	}
+	if err := transition(models.AssignmentStatusPending, models.AssignmentStatusActive, 1); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if got := mustFind(ctx, t, repo, id); got.Status != string(models.AssignmentStatusActive) || got.Version != 2 {
+		t.Fatalf("expected active at version 2, got %+v", got)
+	}
+
+	// Of two racing writers that both saw "active", only one may win.
+	var (
+		wg        sync.WaitGroup
+		conflicts = make(chan error, 2)
+	)
+	for _, to := range []models.AssignmentStatus{models.AssignmentStatusCompleted, models.AssignmentStatusCancelled} {
+		wg.Go(func() { conflicts <- transition(models.AssignmentStatusActive, to, 0) })
+	}
+	wg.Wait()
+	close(conflicts)
+	var won int
+	for err := range conflicts {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, models.ErrConflict):
+			t.Fatalf("expected conflict for the losing writer, got %v", err)
+		}
+	}
+	if won != 1 {
+		t.Fatalf("expected exactly one winning transition, got %d", won)
+	}
+
+	err := repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: prefix + "-missing",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "conformance",
+		ChangedAt:    time.Now().UTC(),
+	})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func testFindAllStatusFilter(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	for i, id := range []string{"A", "B", "C"} {
+		mustSave(ctx, t, repo, newAssignment(prefix, id, base.Add(time.Duration(i)*time.Hour)))
+	}
+	err := repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: prefix + "-B",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "conformance",
+		ChangedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	vehicle := prefix + "-V1"
+	active, pending := string(models.AssignmentStatusActive), string(models.AssignmentStatusPending)
+	testCases := []struct {
+		name  string
+		query models.AssignmentQuery
+		want  []string
+	}{
+		{name: "all", query: models.AssignmentQuery{VehicleID: &vehicle}, want: []string{"A", "B", "C"}},
+		{name: "active", query: models.AssignmentQuery{VehicleID: &vehicle, Status: &active}, want: []string{"B"}},
+		{name: "pending", query: models.AssignmentQuery{VehicleID: &vehicle, Status: &pending}, want: []string{"A", "C"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.query.Order = models.SortAscending
+			assertIDs(t, prefix, collect(ctx, t, repo, tc.query), tc.want)
+		})
+	}
+}
+
+func testFindAllTimeRange(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	for i, id := range []string{"A", "B", "C", "D"} {
+		mustSave(ctx, t, repo, newAssignment(prefix, id, base.Add(time.Duration(i)*time.Hour)))
+	}
+	route := prefix + "-R1"
+	from, to := base.Add(time.Hour), base.Add(3*time.Hour)
+	q := models.AssignmentQuery{RouteID: &route, StartsFrom: &from, StartsTo: &to, Order: models.SortAscending}
+	// StartsFrom is inclusive, StartsTo exclusive.
+	assertIDs(t, prefix, collect(ctx, t, repo, q), []string{"B", "C"})
+}
+
+func testFindAllKeyset(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	for i, id := range []string{"A", "B", "C", "D", "E"} {
+		// Pairs share a start time, so the ID has to break the tie. Each
+		// needs its own vehicle to be bookable at the same time.
+		a := newAssignment(prefix, id, base.Add(time.Duration(i/2)*time.Hour))
+		a.VehicleID = prefix + "-V-" + id
+		mustSave(ctx, t, repo, a)
+	}
+	route := prefix + "-R1"
+
+	testCases := []struct {
+		order models.SortOrder
+		want  []string
+	}{
+		{order: models.SortAscending, want: []string{"A", "B", "C", "D", "E"}},
+		{order: models.SortDescending, want: []string{"E", "D", "C", "B", "A"}},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.order), func(t *testing.T) {
+			q := models.AssignmentQuery{RouteID: &route, Order: tc.order, Limit: 2}
+			var got []models.Assignment
+			for pages := 0; ; pages++ {
+				if pages > len(tc.want) {
+					t.Fatal("pagination did not terminate")
+				}
+				page, err := repo.FindAll(ctx, q)
+				if err != nil {
+					t.Fatalf("FindAll: %v", err)
+				}
+				if len(page.Items) > q.Limit {
+					t.Fatalf("page has %d items, limit is %d", len(page.Items), q.Limit)
+				}
+				got = append(got, page.Items...)
+				if page.Next == nil {
+					break
+				}
+				q.After = page.Next
+			}
+			assertIDs(t, prefix, got, tc.want)
+		})
+	}
+}
+
+func testScheduleConflicts(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	window := func(id, vehicle string, from, to time.Duration) models.Assignment {
+		a := newAssignment(prefix, id, base.Add(from))
+		a.VehicleID = prefix + "-" + vehicle
+		a.EndsAt = base.Add(to)
+		return a
+	}
+	expectClash := func(t *testing.T, a models.Assignment, clashing string) {
+		t.Helper()
+		_, err := repo.Save(ctx, a)
+		if !errors.Is(err, models.ErrConflict) {
+			t.Fatalf("Save %s: expected conflict, got %v", a.ID, err)
+		}
+		if msg := models.ErrorMessage(err, ""); !strings.Contains(msg, prefix+"-"+clashing) {
+			t.Fatalf("Save %s: expected the conflict to name %s, got %q", a.ID, clashing, msg)
+		}
+	}
+
+	mustSave(ctx, t, repo, window("A", "V1", 0, time.Hour))
+	expectClash(t, window("B", "V1", 30*time.Minute, 90*time.Minute), "A")
+	// Back-to-back windows and other vehicles are fine.
+	mustSave(ctx, t, repo, window("C", "V1", time.Hour, 2*time.Hour))
+	mustSave(ctx, t, repo, window("D", "V2", 30*time.Minute, 90*time.Minute))
+
+	// Moving an assignment is checked too, but never against itself.
+	current := mustFind(ctx, t, repo, prefix+"-A")
+	moved := window("A", "V1", 90*time.Minute, 150*time.Minute)
+	moved.Version = current.Version
+	expectClash(t, moved, "C")
+	shifted := window("A", "V1", -30*time.Minute, 45*time.Minute)
+	shifted.Version = current.Version
+	mustSave(ctx, t, repo, shifted)
+
+	// A cancelled assignment frees its vehicle.
+	err := repo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: prefix + "-C",
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusCancelled,
+		Actor:        "conformance",
+		ChangedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	mustSave(ctx, t, repo, window("E", "V1", time.Hour, 2*time.Hour))
+}
+
+func testConcurrentOverlappingCreates(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	const writers = 4
+	var (
+		wg      sync.WaitGroup
+		results = make(chan error, writers)
+	)
+	for i := range writers {
+		a := newAssignment(prefix, string(rune('A'+i)), base)
+		wg.Go(func() {
+			_, err := repo.Save(ctx, a)
+			results <- err
+		})
+	}
+	wg.Wait()
+	close(results)
+
+	var booked int
+	for err := range results {
+		switch {
+		case err == nil:
+			booked++
+		case !errors.Is(err, models.ErrConflict):
+			t.Fatalf("expected conflict for the losing writers, got %v", err)
+		}
+	}
+	if booked != 1 {
+		t.Fatalf("expected exactly one of %d overlapping creates to succeed, got %d", writers, booked)
+	}
+}
+
+func testSaveAll(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	a := newAssignment(prefix, "A", base)
+	b := newAssignment(prefix, "B", base.Add(time.Hour))
+	c := newAssignment(prefix, "C", base)
+	c.VehicleID = prefix + "-V2"
+	if err := repo.SaveAll(ctx, []models.Assignment{a, b, c}); err != nil {
+		t.Fatalf("SaveAll: %v", err)
+	}
+	assertIDs(t, prefix, collect(ctx, t, repo, models.AssignmentQuery{RouteID: &a.RouteID, Order: models.SortAscending}), []string{"A", "C", "B"})
+	if entries, err := repo.History(ctx, b.ID); err != nil || len(entries) != 1 || entries[0].Action != models.AuditActionCreated {
+		t.Fatalf("expected B to be audited as created, got %+v (err %v)", entries, err)
+	}
+
+	// E overlaps D, which is in the same batch, and G overlaps A, which is
+	// stored: both are reported and nothing is stored.
+	d := newAssignment(prefix, "D", base.Add(2*time.Hour))
+	e := newAssignment(prefix, "E", base.Add(2*time.Hour+15*time.Minute))
+	f := newAssignment(prefix, "F", base.Add(3*time.Hour))
+	g := newAssignment(prefix, "G", base.Add(15*time.Minute))
+	err := repo.SaveAll(ctx, []models.Assignment{d, e, f, g})
+	var batchErr *models.BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, models.ErrConflict) || len(batchErr.Items) != 2 ||
+		batchErr.Items[0].Index != 1 || batchErr.Items[1].Index != 3 {
+		t.Fatalf("expected conflicts for items 1 and 3, got %v", err)
+	}
+	for _, id := range []string{d.ID, f.ID} {
+		if _, err := repo.FindByID(ctx, id); !errors.Is(err, models.ErrNotFound) {
+			t.Fatalf("expected %s to be rolled back, got %v", id, err)
+		}
+	}
+	if entries, err := repo.History(ctx, d.ID); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no audit entries for %s, got %+v (err %v)", d.ID, entries, err)
+	}
+
+	// CheckAll reports the same, and stores nothing even when all pass.
+	if err := repo.CheckAll(ctx, []models.Assignment{d, e, f, g}); !errors.As(err, &batchErr) || len(batchErr.Items) != 2 {
+		t.Fatalf("expected CheckAll to report items 1 and 3, got %v", err)
+	}
+	if err := repo.CheckAll(ctx, []models.Assignment{d, f}); err != nil {
+		t.Fatalf("CheckAll: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, d.ID); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected CheckAll to store nothing, got %v", err)
+	}
+}
+
+func testTenantIsolation(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, prefix string) {
+	ctxA := requestctx.WithTenant(ctx, prefix+"-berlin")
+	ctxB := requestctx.WithTenant(ctx, prefix+"-paris")
+	a := newAssignment(prefix, "A", base)
+	mustSave(ctxA, t, repo, a)
+	vehicle := a.VehicleID
+
+	if _, err := repo.FindByID(ctxB, a.ID); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found for another tenant, got %v", err)
+	}
+	if got := collect(ctxB, t, repo, models.AssignmentQuery{VehicleID: &vehicle, Order: models.SortAscending}); len(got) != 0 {
+		t.Fatalf("expected no assignments for another tenant, got %+v", got)
+	}
+	if entries, err := repo.History(ctxB, a.ID); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no history for another tenant, got %d entries, err %v", len(entries), err)
+	}
+	err := repo.UpdateStatus(ctxB, models.AssignmentTransition{
+		AssignmentID: a.ID,
+		From:         models.AssignmentStatusPending,
+		To:           models.AssignmentStatusActive,
+		Actor:        "conformance",
+		ChangedAt:    time.Now().UTC(),
+	})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found for a transition of another tenant, got %v", err)
+	}
+	stale := a
+	stale.Version = 1
+	if _, err := repo.Save(ctxB, stale); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found for an update of another tenant, got %v", err)
+	}
+
+	// The same ID and vehicle slot are free in another tenant.
+	other := a
+	other.RouteID = prefix + "-R2"
+	if isNew, err := repo.Save(ctxB, other); err != nil || !isNew {
+		t.Fatalf("expected a new assignment in the other tenant, got isNew=%t err=%v", isNew, err)
+	}
+	if got := mustFind(ctxA, t, repo, a.ID); got.RouteID != a.RouteID || got.Version != 1 {
+		t.Fatalf("expected the first tenant's assignment untouched, got %+v", got)
+	}
+	if got := mustFind(ctxB, t, repo, a.ID); got.RouteID != other.RouteID {
+		t.Fatalf("expected the second tenant's assignment, got %+v", got)
+	}
+}
+
+// collect returns every assignment matching q, following cursors.
+func collect(ctx context.Context, t *testing.T, repo ports.AssignmentRepository, q models.AssignmentQuery) []models.Assignment {
+	t.Helper()
+	page, err := repo.FindAll(ctx, q)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if page.Next != nil {
+		t.Fatalf("unexpected next page for an unlimited query")
+	}
+	return page.Items
+}
+
+func assertIDs(t *testing.T, prefix string, got []models.Assignment, want []string) {
+	t.Helper()
+	ids := make([]string, len(got))
+	for i, a := range got {
+		ids[i] = strings.TrimPrefix(a.ID, prefix+"-")
+	}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+}
@@ -0,0 +1,119 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// RunSagaResume checks that an AssignmentSagaOrchestrator started afresh,
+// like one in a replica that comes up after a crash, settles the sagas it
+// finds interrupted in sagas against the repository made by newRepo. The
+// store may hold sagas of other tests; they are resumed along the way.
+func RunSagaResume(t *testing.T, newRepo Factory, sagas ports.AssignmentSagaStore) {
+	testCases := []struct {
+		name      string
+		kind      models.AssignmentSagaKind
+		state     models.AssignmentSagaState
+		stored    bool // whether the assignment, or the move, was stored before the crash
+		wantState models.AssignmentSagaState
+		wantHeld  bool // whether a reservation is held afterwards
+		wantMoved bool // whether the reservation holds the moved window
+	}{
+		{name: "create started, not stored", kind: models.AssignmentSagaCreate, state: models.AssignmentSagaStarted, wantState: models.AssignmentSagaAborted},
+		{name: "create reserved, not stored", kind: models.AssignmentSagaCreate, state: models.AssignmentSagaVehicleReserved, wantState: models.AssignmentSagaAborted},
+		{name: "create reserved, stored", kind: models.AssignmentSagaCreate, state: models.AssignmentSagaVehicleReserved, stored: true, wantState: models.AssignmentSagaCompleted, wantHeld: true},
+		{name: "move started, not stored", kind: models.AssignmentSagaMove, state: models.AssignmentSagaStarted, wantState: models.AssignmentSagaAborted, wantHeld: true},
+		{name: "move reserved, not stored", kind: models.AssignmentSagaMove, state: models.AssignmentSagaVehicleReserved, wantState: models.AssignmentSagaAborted, wantHeld: true},
+		{name: "move reserved, stored", kind: models.AssignmentSagaMove, state: models.AssignmentSagaVehicleReserved, stored: true, wantState: models.AssignmentSagaCompleted, wantHeld: true, wantMoved: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			prefix := uuid.NewString()[:8]
+			tenant := prefix + "-acme"
+			tenantCtx := requestctx.WithTenant(ctx, tenant)
+			repo := newRepo(t)
+			reserver := &heldReservations{held: map[string]models.Assignment{}}
+
+			a := newAssignment(prefix, "A", base)
+			moved := a
+			moved.StartsAt, moved.EndsAt = base.Add(2*time.Hour), base.Add(3*time.Hour)
+			target := a
+			if tc.kind == models.AssignmentSagaMove {
+				mustSave(tenantCtx, t, repo, a)
+				target = moved
+				target.Version = 1
+			}
+			if tc.stored {
+				mustSave(tenantCtx, t, repo, target)
+			}
+			// The request that started the saga reserved, or moved, the
+			// vehicle for target, then died.
+			reserver.held[a.ID] = target
+			saga := models.AssignmentSaga{ID: uuid.NewString(), TenantID: tenant, Kind: tc.kind, Assignment: target, Actor: "conformance", RequestID: prefix, State: tc.state}
+			if err := sagas.Create(ctx, saga); err != nil {
+				t.Fatalf("create saga: %v", err)
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			orchestrator := service.NewAssignmentSagaOrchestrator(repo, sagas, reserver, service.AssignmentSagaOptions{StaleAfter: time.Millisecond})
+			got := resumeUntilFinal(ctx, t, orchestrator, sagas, saga.ID)
+			if got.State != tc.wantState || got.Attempts != 1 {
+				t.Fatalf("expected %s after one attempt, got %s after %d", tc.wantState, got.State, got.Attempts)
+			}
+			held, ok := reserver.held[a.ID]
+			if ok != tc.wantHeld {
+				t.Fatalf("expected held=%v, got %v", tc.wantHeld, ok)
+			}
+			if ok && held.StartsAt.Equal(moved.StartsAt) != tc.wantMoved {
+				t.Fatalf("expected moved=%v, got a reservation from %s", tc.wantMoved, held.StartsAt)
+			}
+		})
+	}
+}
+
+// resumeUntilFinal runs o until the saga id is final and returns it. The
+// saga may not be in the first batch when the store holds older ones.
+func resumeUntilFinal(ctx context.Context, t *testing.T, o *service.AssignmentSagaOrchestrator, sagas ports.AssignmentSagaStore, id string) models.AssignmentSaga {
+	t.Helper()
+	for range 20 {
+		if _, err := o.ResumeOnce(ctx); err != nil {
+			t.Fatalf("ResumeOnce: %v", err)
+		}
+		saga, err := sagas.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("find saga: %v", err)
+		}
+		if saga.State.IsFinal() {
+			return saga
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("saga %s was not settled", id)
+	return models.AssignmentSaga{}
+}
+
+// heldReservations stands in for the vehicle service: it holds one
+// reservation per ID and never refuses one.
+type heldReservations struct {
+	held map[string]models.Assignment
+}
+
+func (r *heldReservations) Reserve(ctx context.Context, reservationID string, a models.Assignment) error {
+	r.held[reservationID] = a
+	return nil
+}
+
+func (r *heldReservations) Release(ctx context.Context, reservationID string) error {
+	delete(r.held, reservationID)
+	return nil
+}
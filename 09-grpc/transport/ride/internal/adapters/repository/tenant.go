@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// tenantKey identifies a row of the in-memory adapters the way the
+// (tenant_id, id) primary keys do in SQL.
+type tenantKey struct {
+	tenant string
+	id     string
+}
+
+// keyOf returns the key of id for the tenant in ctx.
+func keyOf(ctx context.Context, id string) tenantKey {
+	return tenantKey{tenant: requestctx.Tenant(ctx), id: id}
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type memoryWebhookStore struct {
+	mu         sync.RWMutex
+	webhooks   map[tenantKey]models.Webhook
+	deliveries []models.WebhookDelivery // in ID order
+	lastID     int64
+}
+
+// NewMemoryWebhookStore keeps webhooks and deliveries in process memory,
+// for unit tests and local runs.
+func NewMemoryWebhookStore() ports.WebhookStore {
+	return &memoryWebhookStore{webhooks: map[tenantKey]models.Webhook{}}
+}
+
+func (s *memoryWebhookStore) Save(ctx context.Context, w models.Webhook) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(ctx, w.ID)
+	current, exists := s.webhooks[key]
+	if w.Version == 0 && exists {
+		return false, models.NewConflictError("save webhook: duplicate entry")
+	}
+	if w.Version > 0 {
+		if err := s.checkVersion(key, w.Version); err != nil {
+			return false, err
+		}
+	}
+	now := time.Now().UTC()
+	w.Version = current.Version + 1
+	w.CreatedAt, w.UpdatedAt = current.CreatedAt, now
+	if !exists {
+		w.CreatedAt = now
+	}
+	w.EventTypes = slices.Clone(w.EventTypes)
+	s.webhooks[key] = w
+	return !exists, nil
+}
+
+// checkVersion mirrors versionMismatch; the caller holds s.mu.
+func (s *memoryWebhookStore) checkVersion(key tenantKey, version int64) error {
+	current, exists := s.webhooks[key]
+	if !exists {
+		return models.NewNotFoundError("webhook %s not found", key.id)
+	}
+	if version > 0 && current.Version != version {
+		return models.NewPreconditionFailedError("webhook %s is at version %d, not %d", key.id, current.Version, version)
+	}
+	return nil
+}
+
+func (s *memoryWebhookStore) FindByID(ctx context.Context, id string) (models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w, ok := s.webhooks[keyOf(ctx, id)]
+	if !ok {
+		return models.Webhook{}, models.NewNotFoundError("webhook %s not found", id)
+	}
+	w.EventTypes = slices.Clone(w.EventTypes)
+	return w, nil
+}
+
+func (s *memoryWebhookStore) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := requestctx.Tenant(ctx)
+	out := []models.Webhook{}
+	for key, w := range s.webhooks {
+		if key.tenant != tenant {
+			continue
+		}
+		w.EventTypes = slices.Clone(w.EventTypes)
+		out = append(out, w)
+	}
+	slices.SortFunc(out, func(a, b models.Webhook) int { return strings.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+func (s *memoryWebhookStore) Delete(ctx context.Context, id string, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(ctx, id)
+	if err := s.checkVersion(key, version); err != nil {
+		return err
+	}
+	delete(s.webhooks, key)
+	s.deliveries = slices.DeleteFunc(s.deliveries, func(d models.WebhookDelivery) bool {
+		return d.TenantID == key.tenant && d.WebhookID == id
+	})
+	return nil
+}
+
+func (s *memoryWebhookStore) RecordOutcome(ctx context.Context, id string, succeeded bool, disableAfter int, reason string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := keyOf(ctx, id)
+	w, ok := s.webhooks[key]
+	if !ok {
+		return false, nil // like an UPDATE that matches no row
+	}
+	if succeeded {
+		w.ConsecutiveFailures = 0
+		s.webhooks[key] = w
+		return false, nil
+	}
+	w.ConsecutiveFailures++
+	disabled := disableAfter > 0 && w.Enabled && w.ConsecutiveFailures >= disableAfter
+	if disabled {
+		w.Enabled, w.DisabledReason = false, reason
+		w.Version++
+		w.UpdatedAt = time.Now().UTC()
+	}
+	s.webhooks[key] = w
+	return disabled, nil
+}
+
+func (s *memoryWebhookStore) Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant := requestctx.Tenant(ctx)
+	for _, d := range deliveries {
+		if slices.ContainsFunc(s.deliveries, func(o models.WebhookDelivery) bool {
+			return o.TenantID == tenant && o.WebhookID == d.WebhookID && o.EventID == d.EventID
+		}) {
+			continue
+		}
+		s.lastID++
+		d.ID, d.TenantID = s.lastID, tenant
+		d.Status, d.Attempts = models.WebhookDeliveryPending, 0
+		d.Payload = slices.Clone(d.Payload)
+		s.deliveries = append(s.deliveries, d)
+	}
+	return nil
+}
+
+func (s *memoryWebhookStore) FetchDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []models.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == models.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			out = append(out, d)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b models.WebhookDelivery) int { return a.NextAttemptAt.Compare(b.NextAttemptAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *memoryWebhookStore) Claim(ctx context.Context, d models.WebhookDelivery, until time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.deliveries {
+		if o.ID == d.ID {
+			if o.Status != models.WebhookDeliveryPending || !o.NextAttemptAt.Equal(d.NextAttemptAt) {
+				return false, nil
+			}
+			s.deliveries[i].NextAttemptAt = until
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *memoryWebhookStore) RecordAttempt(ctx context.Context, d models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.deliveries {
+		if o.ID == d.ID {
+			o.Status, o.Attempts, o.NextAttemptAt = d.Status, d.Attempts, d.NextAttemptAt
+			o.LastStatusCode, o.LastError, o.DeliveredAt = d.LastStatusCode, d.LastError, d.DeliveredAt
+			s.deliveries[i] = o
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *memoryWebhookStore) List(ctx context.Context, q models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := requestctx.Tenant(ctx)
+	var out []models.WebhookDelivery
+	for i := len(s.deliveries) - 1; i >= 0; i-- {
+		d := s.deliveries[i]
+		if d.TenantID != tenant || d.WebhookID != q.WebhookID || (q.Status != nil && d.Status != *q.Status) {
+			continue
+		}
+		out = append(out, d)
+		if q.Limit > 0 && len(out) == q.Limit {
+			break
+		}
+	}
+	return out, nil
+}
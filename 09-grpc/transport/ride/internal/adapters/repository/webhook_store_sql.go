@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// sqlWebhookStore serves MySQL and PostgreSQL. bind adapts the "?"
+// placeholders to the driver and skipDuplicate is the driver's clause for
+// an insert that ignores an existing (tenant_id, webhook_id, event_id).
+// Webhooks and their delivery logs are scoped to the tenant in ctx; FetchDue,
+// Claim and RecordAttempt serve the dispatcher across tenants.
+type sqlWebhookStore struct {
+	db            *sql.DB
+	bind          func(string) string
+	skipDuplicate string
+}
+
+// NewSQLWebhookStore keeps webhooks and their deliveries in MySQL. Secrets
+// are stored as given: they are needed in clear to sign deliveries.
+func NewSQLWebhookStore(db *sql.DB) ports.WebhookStore {
+	return &sqlWebhookStore{db: db, bind: bindMySQL, skipDuplicate: ` ON DUPLICATE KEY UPDATE id = id`}
+}
+
+func NewPostgresWebhookStore(db *sql.DB) ports.WebhookStore {
+	return &sqlWebhookStore{db: db, bind: rebindPostgres, skipDuplicate: ` ON CONFLICT (tenant_id, webhook_id, event_id) DO NOTHING`}
+}
+
+const webhookColumns = `id, url, event_types, secret, enabled, disabled_reason, consecutive_failures, version, created_at, updated_at`
+
+// maxWebhookErrorLength keeps disabled_reason and last_error within their columns.
+const maxWebhookErrorLength = 1024
+
+func truncateWebhookError(s string) string {
+	if len(s) > maxWebhookErrorLength {
+		return s[:maxWebhookErrorLength]
+	}
+	return s
+}
+
+func scanWebhook(row rowScanner) (models.Webhook, error) {
+	var (
+		w          models.Webhook
+		eventTypes string
+	)
+	err := row.Scan(&w.ID, &w.URL, &eventTypes, &w.Secret, &w.Enabled, &w.DisabledReason, &w.ConsecutiveFailures, &w.Version, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	if eventTypes != "" {
+		w.EventTypes = strings.Split(eventTypes, ",")
+	}
+	return w, nil
+}
+
+func (s *sqlWebhookStore) Save(ctx context.Context, w models.Webhook) (bool, error) {
+	now := time.Now().UTC()
+	args := []any{w.URL, strings.Join(w.EventTypes, ","), w.Secret, w.Enabled, truncateWebhookError(w.DisabledReason), w.ConsecutiveFailures, now}
+
+	if w.Version == 0 {
+		_, err := s.db.ExecContext(ctx, s.bind(`
+			INSERT INTO webhooks (url, event_types, secret, enabled, disabled_reason, consecutive_failures, updated_at, created_at, version, tenant_id, id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?)`),
+			append(args, now, requestctx.Tenant(ctx), w.ID)...,
+		)
+		return err == nil, mapSQLError(err, "save webhook")
+	}
+
+	res, err := s.db.ExecContext(ctx, s.bind(`
+		UPDATE webhooks
+		SET url = ?, event_types = ?, secret = ?, enabled = ?, disabled_reason = ?,
+		    consecutive_failures = ?, updated_at = ?, version = version + 1
+		WHERE tenant_id = ? AND id = ? AND version = ?`),
+		append(args, requestctx.Tenant(ctx), w.ID, w.Version)...,
+	)
+	if err != nil {
+		return false, mapSQLError(err, "update webhook")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return false, s.versionMismatch(ctx, w.ID, w.Version)
+	}
+	return false, nil
+}
+
+// versionMismatch explains why a conditional write touched no row.
+func (s *sqlWebhookStore) versionMismatch(ctx context.Context, id string, version int64) error {
+	var current int64
+	err := s.db.QueryRowContext(ctx, s.bind(`SELECT version FROM webhooks WHERE tenant_id = ? AND id = ?`), requestctx.Tenant(ctx), id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.NewNotFoundError("webhook %s not found", id)
+	}
+	if err != nil {
+		return mapSQLError(err, "find webhook")
+	}
+	return models.NewPreconditionFailedError("webhook %s is at version %d, not %d", id, current, version)
+}
+
+func (s *sqlWebhookStore) FindByID(ctx context.Context, id string) (models.Webhook, error) {
+	row := s.db.QueryRowContext(ctx, s.bind(`SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = ? AND id = ?`), requestctx.Tenant(ctx), id)
+	w, err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, models.NewNotFoundError("webhook %s not found", id)
+		}
+		return models.Webhook{}, mapSQLError(err, "find webhook")
+	}
+	return w, nil
+}
+
+func (s *sqlWebhookStore) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := s.db.QueryContext(ctx, s.bind(`SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = ? ORDER BY id`), requestctx.Tenant(ctx))
+	if err != nil {
+		return nil, mapSQLError(err, "list webhooks")
+	}
+	defer rows.Close()
+
+	var out []models.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, mapSQLError(err, "list webhooks")
+		}
+		out = append(out, w)
+	}
+	return out, mapSQLError(rows.Err(), "list webhooks")
+}
+
+func (s *sqlWebhookStore) Delete(ctx context.Context, id string, version int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "delete webhook")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	tenant := requestctx.Tenant(ctx)
+	query := `DELETE FROM webhooks WHERE tenant_id = ? AND id = ?`
+	args := []any{tenant, id}
+	if version > 0 {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	res, err := tx.ExecContext(ctx, s.bind(query), args...)
+	if err != nil {
+		return mapSQLError(err, "delete webhook")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return s.versionMismatch(ctx, id, version)
+	}
+	if _, err := tx.ExecContext(ctx, s.bind(`DELETE FROM webhook_deliveries WHERE tenant_id = ? AND webhook_id = ?`), tenant, id); err != nil {
+		return mapSQLError(err, "delete webhook deliveries")
+	}
+	return mapSQLError(tx.Commit(), "delete webhook")
+}
+
+// RecordOutcome does not bump the version on its own: counting failures
+// must not make a concurrent edit of the webhook fail. Disabling it does.
+func (s *sqlWebhookStore) RecordOutcome(ctx context.Context, id string, succeeded bool, disableAfter int, reason string) (bool, error) {
+	tenant := requestctx.Tenant(ctx)
+	if succeeded {
+		_, err := s.db.ExecContext(ctx, s.bind(`UPDATE webhooks SET consecutive_failures = 0 WHERE tenant_id = ? AND id = ?`), tenant, id)
+		return false, mapSQLError(err, "record webhook outcome")
+	}
+
+	if _, err := s.db.ExecContext(ctx, s.bind(`UPDATE webhooks SET consecutive_failures = consecutive_failures + 1 WHERE tenant_id = ? AND id = ?`), tenant, id); err != nil {
+		return false, mapSQLError(err, "record webhook outcome")
+	}
+	if disableAfter <= 0 {
+		return false, nil
+	}
+	// Only one of several concurrent failures sees the webhook still enabled.
+	res, err := s.db.ExecContext(ctx, s.bind(`
+		UPDATE webhooks
+		SET enabled = ?, disabled_reason = ?, updated_at = ?, version = version + 1
+		WHERE tenant_id = ? AND id = ? AND enabled = ? AND consecutive_failures >= ?`),
+		false, truncateWebhookError(reason), time.Now().UTC(), tenant, id, true, disableAfter,
+	)
+	if err != nil {
+		return false, mapSQLError(err, "disable webhook")
+	}
+	rows, _ := res.RowsAffected()
+	return rows == 1, nil
+}
+
+const webhookDeliveryColumns = `id, tenant_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+func scanWebhookDelivery(row rowScanner) (models.WebhookDelivery, error) {
+	var (
+		d           models.WebhookDelivery
+		payload     string
+		deliveredAt sql.NullTime
+	)
+	err := row.Scan(&d.ID, &d.TenantID, &d.WebhookID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &deliveredAt)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	d.Payload = []byte(payload)
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return d, nil
+}
+
+func (s *sqlWebhookStore) Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "enqueue webhook deliveries")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	tenant := requestctx.Tenant(ctx)
+	for _, d := range deliveries {
+		_, err := tx.ExecContext(ctx, s.bind(`
+			INSERT INTO webhook_deliveries (tenant_id, webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`+s.skipDuplicate),
+			tenant, d.WebhookID, d.EventID, d.EventType, string(d.Payload), models.WebhookDeliveryPending, d.NextAttemptAt, d.CreatedAt,
+		)
+		if err != nil {
+			return mapSQLError(err, "enqueue webhook delivery")
+		}
+	}
+	return mapSQLError(tx.Commit(), "enqueue webhook deliveries")
+}
+
+func (s *sqlWebhookStore) FetchDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, s.bind(`
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?`),
+		models.WebhookDeliveryPending, now, limit,
+	)
+	if err != nil {
+		return nil, mapSQLError(err, "fetch webhook deliveries")
+	}
+	return scanWebhookDeliveries(rows, "fetch webhook deliveries")
+}
+
+func (s *sqlWebhookStore) Claim(ctx context.Context, d models.WebhookDelivery, until time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx, s.bind(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id = ? AND status = ? AND next_attempt_at = ?`),
+		until, d.ID, models.WebhookDeliveryPending, d.NextAttemptAt,
+	)
+	if err != nil {
+		return false, mapSQLError(err, "claim webhook delivery")
+	}
+	rows, _ := res.RowsAffected()
+	return rows == 1, nil
+}
+
+func (s *sqlWebhookStore) RecordAttempt(ctx context.Context, d models.WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx, s.bind(`
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ?
+		WHERE id = ?`),
+		d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, truncateWebhookError(d.LastError), d.DeliveredAt, d.ID,
+	)
+	return mapSQLError(err, "record webhook delivery attempt")
+}
+
+func (s *sqlWebhookStore) List(ctx context.Context, q models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE tenant_id = ? AND webhook_id = ?`
+	args := []any{requestctx.Tenant(ctx), q.WebhookID}
+	if q.Status != nil {
+		query += " AND status = ?"
+		args = append(args, *q.Status)
+	}
+	query += " ORDER BY id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	rows, err := s.db.QueryContext(ctx, s.bind(query), args...)
+	if err != nil {
+		return nil, mapSQLError(err, "list webhook deliveries")
+	}
+	return scanWebhookDeliveries(rows, "list webhook deliveries")
+}
+
+func scanWebhookDeliveries(rows *sql.Rows, op string) ([]models.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var out []models.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, mapSQLError(err, op)
+		}
+		out = append(out, d)
+	}
+	return out, mapSQLError(rows.Err(), op)
+}
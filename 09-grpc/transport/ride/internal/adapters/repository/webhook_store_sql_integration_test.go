@@ -0,0 +1,137 @@
+//go:build integration_test
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+func TestSQLWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	testWebhookStore(t, repository.NewSQLWebhookStore(openTestDB(ctx, t)))
+}
+
+func TestPostgresWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	testWebhookStore(t, repository.NewPostgresWebhookStore(openPostgresTestDB(ctx, t)))
+}
+
+func testWebhookStore(t *testing.T, store ports.WebhookStore) {
+	ctx := context.Background()
+	w := models.Webhook{
+		ID:         uuid.NewString(),
+		URL:        "https://partner.example/hooks",
+		EventTypes: []string{models.EventAssignmentCreated},
+		Secret:     "0123456789abcdef",
+		Enabled:    true,
+	}
+
+	isNew, err := store.Save(ctx, w)
+	if err != nil || !isNew {
+		t.Fatalf("Save: isNew=%v err=%v", isNew, err)
+	}
+	if _, err := store.Save(ctx, w); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected conflict for a duplicate insert, got %v", err)
+	}
+	got, err := store.FindByID(ctx, w.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.URL != w.URL || got.Secret != w.Secret || len(got.EventTypes) != 1 || !got.Enabled || got.Version != 1 {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+
+	// Deliveries are queued once per webhook and event.
+	now := time.Now().UTC().Truncate(time.Second)
+	queued := models.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventID:       1,
+		EventType:     models.EventAssignmentCreated,
+		Payload:       []byte(`{"eventId":1}`),
+		NextAttemptAt: now.Add(-time.Second),
+		CreatedAt:     now,
+	}
+	second := queued
+	second.EventID, second.NextAttemptAt = 2, now.Add(time.Hour)
+	for range 2 {
+		if err := store.Enqueue(ctx, []models.WebhookDelivery{queued, second}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	log, err := store.List(ctx, models.WebhookDeliveryQuery{WebhookID: w.ID})
+	if err != nil || len(log) != 2 || log[0].EventID != 2 || log[1].Status != models.WebhookDeliveryPending {
+		t.Fatalf("expected 2 pending deliveries, newest first, got %+v (err %v)", log, err)
+	}
+
+	due, err := store.FetchDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FetchDue: %v", err)
+	}
+	var d models.WebhookDelivery
+	for _, c := range due {
+		if c.WebhookID == w.ID {
+			d = c
+		}
+	}
+	if d.EventID != 1 || string(d.Payload) != `{"eventId":1}` {
+		t.Fatalf("expected the first delivery to be due, got %+v", due)
+	}
+
+	// Only one of two concurrent claims wins.
+	if ok, err := store.Claim(ctx, d, now.Add(time.Minute)); err != nil || !ok {
+		t.Fatalf("Claim: ok=%v err=%v", ok, err)
+	}
+	if ok, err := store.Claim(ctx, d, now.Add(time.Minute)); err != nil || ok {
+		t.Fatalf("expected a second claim to lose, got ok=%v err=%v", ok, err)
+	}
+
+	delivered := now
+	d.Status, d.Attempts, d.LastStatusCode, d.DeliveredAt = models.WebhookDeliverySucceeded, 1, 204, &delivered
+	if err := store.RecordAttempt(ctx, d); err != nil {
+		t.Fatalf("RecordAttempt: %v", err)
+	}
+	succeeded := models.WebhookDeliverySucceeded
+	log, err = store.List(ctx, models.WebhookDeliveryQuery{WebhookID: w.ID, Status: &succeeded, Limit: 1})
+	if err != nil || len(log) != 1 || log[0].Attempts != 1 || log[0].LastStatusCode != 204 || log[0].DeliveredAt == nil {
+		t.Fatalf("unexpected delivery log: %+v (err %v)", log, err)
+	}
+
+	// The webhook is disabled exactly once, on the failure reaching the limit.
+	for i, want := range []bool{false, true, false} {
+		disabled, err := store.RecordOutcome(ctx, w.ID, false, 2, "too many failures")
+		if err != nil || disabled != want {
+			t.Fatalf("RecordOutcome #%d: disabled=%v err=%v, want %v", i+1, disabled, err, want)
+		}
+	}
+	got, err = store.FindByID(ctx, w.ID)
+	if err != nil || got.Enabled || got.DisabledReason != "too many failures" || got.ConsecutiveFailures != 3 || got.Version != 2 {
+		t.Fatalf("unexpected webhook after failures: %+v (err %v)", got, err)
+	}
+	if _, err := store.RecordOutcome(ctx, w.ID, true, 2, ""); err != nil {
+		t.Fatalf("RecordOutcome: %v", err)
+	}
+	if got, _ := store.FindByID(ctx, w.ID); got.ConsecutiveFailures != 0 {
+		t.Fatalf("expected failures reset, got %d", got.ConsecutiveFailures)
+	}
+
+	if err := store.Delete(ctx, w.ID, 1); !errors.Is(err, models.ErrPreconditionFailed) {
+		t.Fatalf("expected precondition failed for a stale version, got %v", err)
+	}
+	if err := store.Delete(ctx, w.ID, 0); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.FindByID(ctx, w.ID); !errors.Is(err, models.ErrNotFound) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+	if log, err := store.List(ctx, models.WebhookDeliveryQuery{WebhookID: w.ID}); err != nil || len(log) != 0 {
+		t.Fatalf("expected the delivery log to be deleted, got %+v (err %v)", log, err)
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import "fmt"
+
+// ImportMode decides what a bulk import does when some rows fail.
+type ImportMode string
+
+const (
+	// ImportAtomic creates every row or, if any row fails, none.
+	ImportAtomic ImportMode = "atomic"
+	// ImportPartial creates the rows that pass and reports the others.
+	ImportPartial ImportMode = "partial"
+)
+
+// MaxImportRows bounds a single bulk import; larger schedules are split over
+// several requests.
+const MaxImportRows = 10_000
+
+// ImportRow is one row of a bulk import. Row is its 1-based position among
+// the data rows of the source; Err is set when the row could not be parsed.
+type ImportRow struct {
+	Row        int
+	Assignment Assignment
+	Err        error
+}
+
+// ImportRowStatus is the outcome of one row of a bulk import.
+type ImportRowStatus string
+
+const (
+	ImportRowCreated ImportRowStatus = "created"
+	ImportRowFailed  ImportRowStatus = "failed"
+	// ImportRowSkipped marks a valid row that an atomic import did not
+	// create because another row failed.
+	ImportRowSkipped ImportRowStatus = "skipped"
+)
+
+// ImportRowResult reports a row: the ID it was created with, or why it failed.
+type ImportRowResult struct {
+	Row    int
+	Status ImportRowStatus
+	ID     string
+	Err    error
+}
+
+// ImportReport is the outcome of a bulk import, with one result per row in
+// source order.
+type ImportReport struct {
+	Mode     ImportMode
+	Imported int
+	Failed   int
+	Rows     []ImportRowResult
+}
+
+// BatchError reports every item of a batch write that failed, in batch
+// order; the whole batch was rolled back. It matches the errors of all its
+// items in errors.Is and errors.As.
+type BatchError struct {
+	Items []ItemError
+}
+
+// ItemError is the error of the item at Index of a batch.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("item %d: %v", e.Items[0].Index, e.Items[0].Err)
+	if len(e.Items) > 1 {
+		msg += fmt.Sprintf(" (and %d more failed items)", len(e.Items)-1)
+	}
+	return msg
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item.Err
+	}
+	return errs
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// SortOrder is the direction in which assignments are ordered by StartsAt.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// AssignmentCursor is the keyset position just after the last item of a
+// page. ID breaks ties between assignments that start at the same instant.
+type AssignmentCursor struct {
+	StartsAt time.Time
+	ID       string
+}
+
+// AssignmentQuery filters and pages a listing of assignments. Nil filters
+// are ignored; StartsFrom is inclusive and StartsTo exclusive.
+type AssignmentQuery struct {
+	Status     *string
+	VehicleID  *string
+	RouteID    *string
+	StartsFrom *time.Time
+	StartsTo   *time.Time
+	Order      SortOrder
+	Limit      int // 0 means no limit
+	After      *AssignmentCursor
+}
+
+// AssignmentPage is one page of a listing. Next is nil on the last page.
+type AssignmentPage struct {
+	Items []Assignment
+	Next  *AssignmentCursor
+}
+
+// Less reports whether a sorts before b in the given order, using the same
+// (starts_at, id) key as the keyset queries.
+func (o SortOrder) Less(a, b AssignmentCursor) bool {
+	if o == SortDescending {
+		a, b = b, a
+	}
+	if !a.StartsAt.Equal(b.StartsAt) {
+		return a.StartsAt.Before(b.StartsAt)
+	}
+	return a.ID < b.ID
+}
+
+// CursorOf returns the keyset position of a.
+func CursorOf(a Assignment) AssignmentCursor {
+	return AssignmentCursor{StartsAt: a.StartsAt, ID: a.ID}
+}
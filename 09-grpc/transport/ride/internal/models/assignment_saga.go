@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// AssignmentSagaKind is what an AssignmentSaga does to its assignment.
+type AssignmentSagaKind string
+
+const (
+	// AssignmentSagaCreate reserves a vehicle for a new assignment; rolling
+	// it back releases the reservation.
+	AssignmentSagaCreate AssignmentSagaKind = "create"
+	// AssignmentSagaMove moves the reservation of a stored assignment to
+	// another vehicle or window; rolling it back puts the reservation back
+	// to what the stored assignment holds.
+	AssignmentSagaMove AssignmentSagaKind = "move"
+)
+
+// AssignmentSagaState is the step an AssignmentSaga has reached.
+type AssignmentSagaState string
+
+const (
+	// AssignmentSagaStarted: the vehicle may or may not be reserved yet.
+	AssignmentSagaStarted AssignmentSagaState = "started"
+	// AssignmentSagaVehicleReserved: the vehicle is held and the assignment
+	// may or may not be stored yet.
+	AssignmentSagaVehicleReserved AssignmentSagaState = "vehicle_reserved"
+	// AssignmentSagaCompensating: the saga failed and the reservation is
+	// being released, or put back for a move.
+	AssignmentSagaCompensating AssignmentSagaState = "compensating"
+	// AssignmentSagaCompleted is final: the assignment is stored as the
+	// saga meant it, with its AssignmentCreated event in the outbox for a
+	// creation.
+	AssignmentSagaCompleted AssignmentSagaState = "completed"
+	// AssignmentSagaAborted is final: the assignment is stored as it was
+	// before the saga, or not at all, and the reservation matches it.
+	AssignmentSagaAborted AssignmentSagaState = "aborted"
+)
+
+// IsFinal reports whether a saga in s has nothing left to do.
+func (s AssignmentSagaState) IsFinal() bool {
+	return s == AssignmentSagaCompleted || s == AssignmentSagaAborted
+}
+
+// AssignmentSaga tracks the creation or move of one assignment across the
+// vehicle service and the repository, so that one interrupted by a crash
+// can be finished or rolled back later.
+type AssignmentSaga struct {
+	ID       string
+	TenantID string
+	Kind     AssignmentSagaKind
+	// Assignment is the assignment being created, or the update moving it,
+	// ID included.
+	Assignment Assignment
+	// Actor and RequestID of the request that started the saga; resumed steps are
+	// audited under them.
+	Actor     string
+	RequestID string
+	State     AssignmentSagaState
+	// Attempts counts the resumptions of the saga.
+	Attempts  int
+	LastError string
+	Version   int64
+	CreatedAt time.Time // set by the store
+	UpdatedAt time.Time // set by the store
+}
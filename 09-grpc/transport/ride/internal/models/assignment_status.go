@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// allowedTransitions is the assignment lifecycle:
+//
+//	pending -> active -> completed
+//	pending -> cancelled
+//	active  -> cancelled
+//
+// completed and cancelled are terminal.
+var allowedTransitions = map[AssignmentStatus][]AssignmentStatus{
+	AssignmentStatusPending: {AssignmentStatusActive, AssignmentStatusCancelled},
+	AssignmentStatusActive:  {AssignmentStatusCompleted, AssignmentStatusCancelled},
+}
+
+// IsValid reports whether s is one of the known lifecycle states.
+func (s AssignmentStatus) IsValid() bool {
+	switch s {
+	case AssignmentStatusPending, AssignmentStatusActive, AssignmentStatusCompleted, AssignmentStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether no further transition is possible from s.
+func (s AssignmentStatus) IsTerminal() bool {
+	return s.IsValid() && len(allowedTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next.
+func (s AssignmentStatus) CanTransitionTo(next AssignmentStatus) bool {
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignmentTransition records a single status change and who made it.
+type AssignmentTransition struct {
+	AssignmentID string
+	From         AssignmentStatus
+	To           AssignmentStatus
+	Actor        string
+	Reason       string
+	ChangedAt    time.Time
+	// Version, when non-zero, is the assignment version the change expects
+	// to find; any other version fails the precondition.
+	Version int64
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// AuditAction is the kind of change an AuditEntry records.
+type AuditAction string
+
+const (
+	AuditActionCreated       AuditAction = "created"
+	AuditActionUpdated       AuditAction = "updated"
+	AuditActionStatusChanged AuditAction = "status_changed"
+)
+
+// AuditEntry records one change to an assignment. Entries are written in
+// the same transaction as the change and never updated or deleted.
+type AuditEntry struct {
+	ID           int64
+	TenantID     string
+	AssignmentID string
+	Action       AuditAction
+	Actor        string
+	RequestID    string      // empty for changes made outside an HTTP request
+	Before       *Assignment // nil for AuditActionCreated
+	After        Assignment
+	At           time.Time
+}
+
+// AssignmentChangeFilter selects the audit entries a change feed subscriber
+// receives, with the same fields as AssignmentQuery; nil fields match
+// everything. An entry matches when the assignment matched before or after
+// the change, so subscribers also learn that it left their selection.
+// Entries of other tenants than TenantID never match.
+type AssignmentChangeFilter struct {
+	TenantID  string
+	Status    *string
+	VehicleID *string
+	RouteID   *string
+}
+
+// Matches reports whether e is delivered to a subscriber with filter f.
+func (f AssignmentChangeFilter) Matches(e AuditEntry) bool {
+	if e.TenantID != f.TenantID {
+		return false
+	}
+	return f.matches(e.After) || (e.Before != nil && f.matches(*e.Before))
+}
+
+func (f AssignmentChangeFilter) matches(a Assignment) bool {
+	return (f.Status == nil || a.Status == *f.Status) &&
+		(f.VehicleID == nil || a.VehicleID == *f.VehicleID) &&
+		(f.RouteID == nil || a.RouteID == *f.RouteID)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is what is remembered about a request sent with an
+// Idempotency-Key: a fingerprint of the request and, once the request has
+// finished, the response to replay for identical retries.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	Completed   bool
+	Response    StoredResponse
+	ExpiresAt   time.Time
+}
+
+// StoredResponse is the subset of an HTTP response needed to replay it.
+type StoredResponse struct {
+	StatusCode int
+	Header     map[string]string
+	Body       []byte
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Outbox event types.
+const (
+	EventAssignmentCreated       = "AssignmentCreated"
+	EventAssignmentStatusChanged = "AssignmentStatusChanged"
+)
+
+// OutboxEvent is a domain event stored in the same transaction as the change
+// that caused it and published later by the outbox relay.
+type OutboxEvent struct {
+	ID          int64
+	TenantID    string // set by the repository from the writing context
+	AggregateID string
+	Type        string
+	Payload     []byte // JSON; shape depends on Type
+	CreatedAt   time.Time
+	Attempts    int
+}
+
+// AssignmentCreatedPayload is the outbox payload of EventAssignmentCreated.
+type AssignmentCreatedPayload struct {
+	AssignmentID string    `json:"assignmentId"`
+	VehicleID    string    `json:"vehicleId"`
+	RouteID      string    `json:"routeId"`
+	StartsAt     time.Time `json:"startsAt"`
+	EndsAt       time.Time `json:"endsAt"`
+	OccurredAt   time.Time `json:"occurredAt"`
+}
+
+// NewAssignmentCreatedEvent builds the outbox event for a newly inserted assignment.
+func NewAssignmentCreatedEvent(a Assignment, at time.Time) (OutboxEvent, error) {
+	payload, err := json.Marshal(AssignmentCreatedPayload{
+		AssignmentID: a.ID,
+		VehicleID:    a.VehicleID,
+		RouteID:      a.RouteID,
+		StartsAt:     a.StartsAt,
+		EndsAt:       a.EndsAt,
+		OccurredAt:   at,
+	})
+	if err != nil {
+		return OutboxEvent{}, err
+	}
+	return OutboxEvent{AggregateID: a.ID, Type: EventAssignmentCreated, Payload: payload, CreatedAt: at}, nil
+}
+
+// AssignmentStatusChangedPayload is the outbox payload of
+// EventAssignmentStatusChanged.
+type AssignmentStatusChangedPayload struct {
+	AssignmentID string           `json:"assignmentId"`
+	From         AssignmentStatus `json:"from"`
+	To           AssignmentStatus `json:"to"`
+	Actor        string           `json:"actor"`
+	Reason       string           `json:"reason"`
+	OccurredAt   time.Time        `json:"occurredAt"`
+}
+
+// NewAssignmentStatusChangedEvent builds the outbox event for an applied
+// status transition.
+func NewAssignmentStatusChangedEvent(t AssignmentTransition) (OutboxEvent, error) {
+	payload, err := json.Marshal(AssignmentStatusChangedPayload{
+		AssignmentID: t.AssignmentID,
+		From:         t.From,
+		To:           t.To,
+		Actor:        t.Actor,
+		Reason:       t.Reason,
+		OccurredAt:   t.ChangedAt,
+	})
+	if err != nil {
+		return OutboxEvent{}, err
+	}
+	return OutboxEvent{AggregateID: t.AssignmentID, Type: EventAssignmentStatusChanged, Payload: payload, CreatedAt: t.ChangedAt}, nil
+}
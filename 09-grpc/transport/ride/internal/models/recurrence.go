@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// RecurringAssignment books a vehicle on a route at every occurrence of an
+// RFC 5545 recurrence rule. Occurrences are expanded in Timezone, so a rule
+// that starts at 07:30 Europe/Berlin keeps starting at 07:30 local time on
+// both sides of a DST change.
+type RecurringAssignment struct {
+	ID        string
+	VehicleID string
+	RouteID   string
+	// RRule is the RRULE value without DTSTART, e.g.
+	// "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR".
+	RRule string
+	// Timezone is the IANA zone the rule is expanded in.
+	Timezone string
+	// StartsAt is the first occurrence (DTSTART). Its wall-clock time in
+	// Timezone is the start time of every occurrence.
+	StartsAt time.Time
+	Duration time.Duration
+	// EndsOn is the last local date an occurrence may start on; nil means
+	// the rule runs until its RRULE ends it, if ever.
+	EndsOn *time.Time
+	// Exceptions are local dates on which no occurrence is materialized.
+	Exceptions []time.Time
+	// Version is incremented on every change. On Save it is the version the
+	// caller expects to replace; 0 inserts a new rule.
+	Version   int64
+	UpdatedAt time.Time // set by the repository
+}
+
+// DateOf truncates t to its calendar date in t's location, expressed as
+// midnight UTC. EndsOn and Exceptions are stored in this form.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
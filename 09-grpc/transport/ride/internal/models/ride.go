@@ -7,6 +7,7 @@ import (
 // Defines values for AssignmentStatus.
 const (
 	AssignmentStatusActive    AssignmentStatus = "active"
+	AssignmentStatusCancelled AssignmentStatus = "cancelled"
 	AssignmentStatusCompleted AssignmentStatus = "completed"
 	AssignmentStatusPending   AssignmentStatus = "pending"
 )
@@ -14,6 +15,7 @@ const (
 // Defines values for ListAssignmentsParamsStatus.
 const (
 	ListAssignmentsParamsStatusActive    ListAssignmentsParamsStatus = "active"
+	ListAssignmentsParamsStatusCancelled ListAssignmentsParamsStatus = "cancelled"
 	ListAssignmentsParamsStatusCompleted ListAssignmentsParamsStatus = "completed"
 	ListAssignmentsParamsStatusPending   ListAssignmentsParamsStatus = "pending"
 )
@@ -21,9 +23,19 @@ const (
 type Assignment struct {
 	ID        string
 	VehicleID string
-	RouteID   string
-	StartsAt  time.Time
-	Status    string
+	// VehicleAutoSelected is set when the vehicle service chose VehicleID
+	// because the assignment was created without one.
+	VehicleAutoSelected bool
+	RouteID             string
+	StartsAt            time.Time
+	// EndsAt closes the half-open window [StartsAt, EndsAt) during which the
+	// vehicle is booked.
+	EndsAt time.Time
+	Status string
+	// Version is incremented on every change. On Save it is the version the
+	// caller expects to replace; 0 means "don't check".
+	Version   int64
+	UpdatedAt time.Time // set by the repository
 }
 
 // AssignmentStatus defines model for Assignment.Status.
@@ -0,0 +1,21 @@
+package models
+
+import "strings"
+
+// Overlaps reports whether the windows of a and b intersect. Windows are
+// half-open, so back-to-back assignments do not overlap.
+func (a Assignment) Overlaps(b Assignment) bool {
+	return a.StartsAt.Before(b.EndsAt) && b.StartsAt.Before(a.EndsAt)
+}
+
+// BlocksVehicle reports whether an assignment in status s still occupies its
+// vehicle. Completed and cancelled assignments free it.
+func (s AssignmentStatus) BlocksVehicle() bool {
+	return !s.IsTerminal()
+}
+
+// NewScheduleConflictError reports that vehicleID is already booked by the
+// clashing assignments.
+func NewScheduleConflictError(vehicleID string, clashing []string) error {
+	return NewConflictError("vehicle %s is already booked in that window by assignment(s) %s", vehicleID, strings.Join(clashing, ", "))
+}
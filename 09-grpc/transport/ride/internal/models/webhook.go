@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook is a partner endpoint that receives assignment events over HTTP.
+type Webhook struct {
+	ID  string
+	URL string
+	// EventTypes lists the outbox event types delivered to the endpoint;
+	// empty means all of them.
+	EventTypes []string
+	// Secret keys the HMAC-SHA256 signature of every delivery. It is never
+	// returned by the API.
+	Secret  string
+	Enabled bool
+	// DisabledReason explains why the endpoint was disabled automatically.
+	DisabledReason string
+	// ConsecutiveFailures counts failed attempts since the last success.
+	ConsecutiveFailures int
+	Version             int64
+	CreatedAt           time.Time // set by the repository
+	UpdatedAt           time.Time // set by the repository
+}
+
+// WebhookEventTypes are the event types a webhook can subscribe to.
+var WebhookEventTypes = []string{EventAssignmentCreated, EventAssignmentStatusChanged}
+
+// Subscribes reports whether events of eventType are delivered to w.
+func (w Webhook) Subscribes(eventType string) bool {
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookDeliveryStatus is the state of a WebhookDelivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed is final: the attempts ran out, or the endpoint
+	// was disabled or deleted.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event queued for one webhook, together with the
+// outcome of its latest attempt. The rows double as the delivery log.
+type WebhookDelivery struct {
+	ID             int64
+	TenantID       string // of the webhook; set by Enqueue
+	WebhookID      string
+	EventID        int64 // outbox event ID; a webhook gets each event once
+	EventType      string
+	Payload        []byte // request body, identical on every attempt
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int // 0 when no response was received
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// WebhookDeliveryQuery selects the delivery log of one webhook, newest first.
+type WebhookDeliveryQuery struct {
+	WebhookID string
+	Status    *WebhookDeliveryStatus
+	Limit     int
+}
+
+// WebhookEvent is the JSON body of every webhook delivery. Data is the
+// event payload as stored in the outbox.
+type WebhookEvent struct {
+	EventID    int64           `json:"eventId"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
@@ -0,0 +1,26 @@
+package ports
+
+import "github.com/yourname/transport/ride/internal/models"
+
+// AssignmentFeed pushes assignment changes, as recorded in the audit trail,
+// to live subscribers. Event IDs are audit entry IDs, so they are the same
+// on every replica.
+type AssignmentFeed interface {
+	// Subscribe registers a subscriber for the changes matching filter. A
+	// non-zero lastEventID resumes after that change.
+	Subscribe(filter models.AssignmentChangeFilter, lastEventID int64) AssignmentSubscription
+}
+
+type AssignmentSubscription interface {
+	// Replay returns the buffered changes after the last event ID, oldest
+	// first. They precede everything sent on Events.
+	Replay() []models.AuditEntry
+	// Resumed is false when changes after the last event ID are no longer
+	// buffered; the subscriber should reload what it shows.
+	Resumed() bool
+	// Events delivers new changes. It is closed when the subscriber falls
+	// too far behind, when the feed stops, or after Close.
+	Events() <-chan models.AuditEntry
+	// Close unsubscribes; it may be called more than once.
+	Close()
+}
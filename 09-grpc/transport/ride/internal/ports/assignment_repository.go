@@ -6,8 +6,46 @@ import (
 	"github.com/yourname/transport/ride/internal/models"
 )
 
+// AssignmentRepository scopes every method to the tenant in ctx
+// (requestctx.Tenant): assignments of other tenants are neither found nor
+// listed, and IDs may repeat across tenants. AuditSince and LatestAuditID
+// are the exception; they feed the change stream of all tenants, and every
+// entry names its tenant.
 type AssignmentRepository interface {
+	// Save inserts or updates an assignment and reports whether it was new.
+	// The status of an existing assignment is left untouched; use UpdateStatus.
+	// When a.Version is non-zero the assignment must exist at that version,
+	// otherwise a precondition-failed error is returned. Every write bumps
+	// the version.
 	Save(ctx context.Context, a models.Assignment) (bool, error)
+	// SaveAll saves every assignment of as like Save, in one transaction:
+	// either all are stored or none. Each one is checked against the
+	// earlier ones that passed as well as the stored assignments. When
+	// some of them fail, every one is still checked and the error is a
+	// *models.BatchError naming all that failed; other errors, such as the
+	// database being down, are returned as they occur.
+	SaveAll(ctx context.Context, as []models.Assignment) error
+	// CheckAll reports what SaveAll would for as, storing nothing.
+	CheckAll(ctx context.Context, as []models.Assignment) error
 	FindByID(ctx context.Context, id string) (models.Assignment, error)
-	FindAll(ctx context.Context, status *string) ([]models.Assignment, error)
+	// FindAll returns the page of assignments matching q, ordered by
+	// (StartsAt, ID) in q.Order, starting after q.After.
+	FindAll(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error)
+	// UpdateStatus applies t only if the assignment is still in t.From and
+	// records it in the transition log and, where the store has one, as an
+	// AssignmentStatusChanged outbox event; otherwise it returns a conflict.
+	// A non-zero t.Version is checked like a.Version in Save.
+	UpdateStatus(ctx context.Context, t models.AssignmentTransition) error
+	// History returns the audit trail of assignment id, oldest first. Save
+	// and UpdateStatus append to it in the same transaction as the change,
+	// taking the actor and request ID from ctx. An unknown id has an empty
+	// history.
+	History(ctx context.Context, id string) ([]models.AuditEntry, error)
+	// AuditSince returns up to limit audit entries of all tenants with IDs
+	// above afterID, oldest first. IDs grow with every entry, but an
+	// entry whose transaction is still open shows up after later ones, and
+	// a rolled-back transaction may leave a gap for good.
+	AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error)
+	// LatestAuditID returns the ID of the newest audit entry, or 0.
+	LatestAuditID(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// AssignmentSagaStore persists the state of assignment sagas. It is not
+// scoped by tenant: sagas of every tenant are resumed by one loop, and each
+// saga names its tenant.
+type AssignmentSagaStore interface {
+	// Create stores a new saga at version 1.
+	Create(ctx context.Context, s models.AssignmentSaga) error
+	// Update stores the state, attempts and last error of s only if the
+	// saga is still at s.Version, and bumps the version. Otherwise it
+	// returns a precondition-failed error: another process has moved the
+	// saga on.
+	Update(ctx context.Context, s models.AssignmentSaga) error
+	FindByID(ctx context.Context, id string) (models.AssignmentSaga, error)
+	// ListUnfinished returns up to limit sagas in a non-final state that
+	// were last updated before updatedBefore, oldest first.
+	ListUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]models.AssignmentSaga, error)
+}
@@ -18,3 +18,13 @@ type NotificationIssued struct {
 	EventType   string `avro:"eventType"`
 	Timestamp   string `avro:"timestamp"`
 }
+
+// AssignmentStatusChanged is a generated struct.
+type AssignmentStatusChanged struct {
+	AssignmentID string `avro:"assignmentId"`
+	FromStatus   string `avro:"fromStatus"`
+	ToStatus     string `avro:"toStatus"`
+	Actor        string `avro:"actor"`
+	Reason       string `avro:"reason"`
+	Timestamp    string `avro:"timestamp"`
+}
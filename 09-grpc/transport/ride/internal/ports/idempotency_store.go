@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+type IdempotencyStore interface {
+	// Reserve claims key for a request with the given fingerprint until ttl
+	// elapses. When the key is already held, reserved is false and the
+	// existing record is returned so the caller can replay or reject.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (rec models.IdempotencyRecord, reserved bool, err error)
+	// Complete stores the response for a reserved key.
+	Complete(ctx context.Context, key string, resp models.StoredResponse) error
+	// Release forgets a reserved key so that the request may be retried.
+	Release(ctx context.Context, key string) error
+	// DeleteExpired removes records that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// LeaderLock elects a single replica to run a job that must not run
+// concurrently.
+type LeaderLock interface {
+	// Acquire takes the lock if it is free, or confirms that this process
+	// still holds it, and reports whether it is the leader. It never blocks
+	// waiting for another holder.
+	Acquire(ctx context.Context) (bool, error)
+	// Release gives up leadership; it is a no-op when the lock is not held.
+	Release(ctx context.Context) error
+}
@@ -16,7 +16,22 @@ type Message[T any] struct {
 	Nack     func() error // request redelivery
 }
 
+// TenantProperty is the message property naming the tenant an event belongs
+// to. Producers set it from the tenant of the sending context.
+const TenantProperty = "tenant"
+
 // Outbound port for publishing events (incl. DLQ).
 type EventProducer[T any] interface {
 	Send(ctx context.Context, value T) (string, error)
 }
+
+// Processor handles one decoded message; returning an error nacks it.
+type Processor[T any] interface {
+	Process(ctx context.Context, msg Message[T]) error
+}
+
+// Inbound port: the consumer owns receive/ack/nack plumbing and delegates to Processor.
+type EventConsumer[T any] interface {
+	// Start registers the Processor and begins consuming until ctx is done.
+	Start(ctx context.Context, processor Processor[T]) error
+}
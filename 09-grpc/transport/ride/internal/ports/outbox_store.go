@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// OutboxStore is the relay's view of the outbox table. Rows are written by
+// the repositories in the same transaction as the change they describe.
+type OutboxStore interface {
+	// FetchPending returns up to limit unsent events that are due at now, oldest first.
+	FetchPending(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error)
+	MarkSent(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed records a failed attempt and defers the event until retryAt.
+	MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+type RecurringAssignmentRepository interface {
+	// Save inserts r when r.Version is 0 and reports true; otherwise it
+	// updates the rule only if it is still at r.Version. Every write bumps
+	// the version.
+	Save(ctx context.Context, r models.RecurringAssignment) (bool, error)
+	FindByID(ctx context.Context, id string) (models.RecurringAssignment, error)
+	// FindAll returns every rule ordered by ID.
+	FindAll(ctx context.Context) ([]models.RecurringAssignment, error)
+	// Delete removes a rule; a non-zero version must match the stored one.
+	Delete(ctx context.Context, id string, version int64) error
+}
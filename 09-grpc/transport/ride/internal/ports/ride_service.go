@@ -9,5 +9,46 @@ import (
 type AssignmentService interface {
 	Save(ctx context.Context, a models.Assignment) (models.Assignment, error)
 	GetByID(ctx context.Context, id string) (models.Assignment, error)
-	List(ctx context.Context, status *string) ([]models.Assignment, error)
+	List(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error)
+	// Update replaces the editable fields of an existing assignment. A non-zero
+	// a.Version must match the stored version.
+	Update(ctx context.Context, a models.Assignment) (models.Assignment, error)
+	// Transition changes the status; a non-zero version must match the stored one.
+	Transition(ctx context.Context, id string, to models.AssignmentStatus, reason string, version int64) (models.Assignment, error)
+	// History returns the audit trail of an existing assignment, oldest first.
+	History(ctx context.Context, id string) ([]models.AuditEntry, error)
+	// Import creates one assignment per row, like Save, and reports the
+	// outcome of every row. Rows that fail are reported, not returned as
+	// an error; the error is for requests that cannot be processed at all.
+	Import(ctx context.Context, rows []models.ImportRow, mode models.ImportMode) (models.ImportReport, error)
+	// Export calls fn with every assignment matching q in q.Order, reading
+	// one page at a time, and stops at the first error fn returns. q.Limit
+	// and q.After are ignored.
+	Export(ctx context.Context, q models.AssignmentQuery, fn func(models.Assignment) error) error
+}
+
+type RecurringAssignmentService interface {
+	// Save creates a rule and materializes its first occurrences.
+	Save(ctx context.Context, r models.RecurringAssignment) (models.RecurringAssignment, error)
+	GetByID(ctx context.Context, id string) (models.RecurringAssignment, error)
+	List(ctx context.Context) ([]models.RecurringAssignment, error)
+	// Update replaces a rule. Pending occurrences of the old rule that have
+	// not started yet are cancelled and the new rule is materialized.
+	Update(ctx context.Context, r models.RecurringAssignment) (models.RecurringAssignment, error)
+	// Delete removes a rule and cancels its pending future occurrences.
+	Delete(ctx context.Context, id string) error
+}
+
+type WebhookService interface {
+	// Save registers a webhook; new webhooks start enabled.
+	Save(ctx context.Context, w models.Webhook) (models.Webhook, error)
+	GetByID(ctx context.Context, id string) (models.Webhook, error)
+	List(ctx context.Context) ([]models.Webhook, error)
+	// Update replaces the URL, event filter, secret and enabled flag of a
+	// webhook. Enabling a disabled webhook resets its failure count.
+	Update(ctx context.Context, w models.Webhook) (models.Webhook, error)
+	// Delete removes a webhook and its delivery log.
+	Delete(ctx context.Context, id string) error
+	// Deliveries returns the delivery log of a webhook, newest first.
+	Deliveries(ctx context.Context, q models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error)
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+type TokenVerifier interface {
+	// Verify checks the signature, issuer, audience and lifetime of a bearer
+	// token and returns its claims. Any error means the token must not be
+	// trusted.
+	Verify(ctx context.Context, token string) (requestctx.Claims, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// VehicleFinder asks the vehicle service which vehicle should serve a route.
+type VehicleFinder interface {
+	// FindAvailableVehicle returns the ID of a vehicle available on the
+	// route for the whole window [startsAt, endsAt). It fails with
+	// models.ErrConflict when none is available and with
+	// models.ErrUnavailable when the vehicle service cannot be reached or is
+	// being skipped after repeated failures.
+	FindAvailableVehicle(ctx context.Context, routeID string, startsAt, endsAt time.Time) (string, error)
+}
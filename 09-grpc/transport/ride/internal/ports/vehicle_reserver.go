@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// VehicleReserver holds vehicles in the vehicle service for the windows of
+// assignments. Both calls are idempotent by reservation ID, so a caller
+// that did not get an answer may simply repeat them.
+type VehicleReserver interface {
+	// Reserve holds a.VehicleID for [a.StartsAt, a.EndsAt); reserving an
+	// existing reservation ID again moves it to the vehicle and window of a.
+	// It fails with models.ErrConflict or models.ErrValidation when the
+	// vehicle service refuses, which leaves the reservation as it was, and
+	// with models.ErrUnavailable when the outcome is unknown.
+	Reserve(ctx context.Context, reservationID string, a models.Assignment) error
+	// Release drops the reservation; an unknown one is already released.
+	Release(ctx context.Context, reservationID string) error
+}
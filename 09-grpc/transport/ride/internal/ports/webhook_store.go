@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+type WebhookRepository interface {
+	// Save inserts w when w.Version is 0 and reports true; otherwise it
+	// updates the webhook only if it is still at w.Version. Every write
+	// bumps the version.
+	Save(ctx context.Context, w models.Webhook) (bool, error)
+	FindByID(ctx context.Context, id string) (models.Webhook, error)
+	// FindAll returns every webhook ordered by ID.
+	FindAll(ctx context.Context) ([]models.Webhook, error)
+	// Delete removes a webhook and its delivery log; a non-zero version
+	// must match the stored one.
+	Delete(ctx context.Context, id string, version int64) error
+	// RecordOutcome updates the failure count of webhook id after a
+	// delivery attempt: a success resets it, a failure increments it and
+	// disables the webhook with reason once it reaches disableAfter. It
+	// reports whether this call disabled the webhook.
+	RecordOutcome(ctx context.Context, id string, succeeded bool, disableAfter int, reason string) (bool, error)
+}
+
+// WebhookDeliveryStore queues webhook deliveries and keeps their log.
+type WebhookDeliveryStore interface {
+	// Enqueue stores pending deliveries. A delivery of the same event to
+	// the same webhook is only stored once, so enqueueing is safe to retry.
+	Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// FetchDue returns up to limit pending deliveries due at now, oldest first.
+	FetchDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// Claim defers d to until if it is still pending at d.NextAttemptAt, so
+	// that other dispatchers skip it while this one sends it. It reports
+	// false if another dispatcher claimed d first.
+	Claim(ctx context.Context, d models.WebhookDelivery, until time.Time) (bool, error)
+	// RecordAttempt stores the status, attempt count, next attempt and
+	// last outcome of d.
+	RecordAttempt(ctx context.Context, d models.WebhookDelivery) error
+	// List returns the deliveries matching q, newest first.
+	List(ctx context.Context, q models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error)
+}
+
+// WebhookStore keeps webhooks and their deliveries together, so that
+// deleting a webhook can remove its delivery log in the same write.
+type WebhookStore interface {
+	WebhookRepository
+	WebhookDeliveryStore
+}
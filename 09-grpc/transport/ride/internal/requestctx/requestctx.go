@@ -0,0 +1,101 @@
+// Package requestctx carries per-request metadata (such as the acting user)
+// through context.Context, so services can read it without depending on
+// any transport.
+package requestctx
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+type ctxKey int
+
+const (
+	actorKey ctxKey = iota
+	requestIDKey
+	tenantKey
+	claimsKey
+)
+
+// AnonymousActor is reported when a request did not identify its caller.
+const AnonymousActor = "anonymous"
+
+// WithActor returns a copy of ctx that carries the acting user or system.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns the actor stored in ctx, or AnonymousActor when none is set.
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// WithRequestID returns a copy of ctx that carries the ID of the request
+// being served.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, or "" when there is none,
+// e.g. in background jobs.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// DefaultTenant owns the data of single-tenant deployments and every row
+// written before tenants existed.
+const DefaultTenant = "default"
+
+// WithTenant returns a copy of ctx that acts on behalf of tenant. Storage
+// adapters scope every read and write to it.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// Tenant returns the tenant stored in ctx, or DefaultTenant when none is
+// set.
+func Tenant(ctx context.Context) string {
+	if tenant, ok := LookupTenant(ctx); ok {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+// LookupTenant returns the tenant stored in ctx and whether one was set.
+func LookupTenant(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey).(string)
+	return tenant, ok && tenant != ""
+}
+
+// Claims are the verified claims of the access token a request came with.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	Scopes    []string
+	Tenant    string // empty when the token is not pinned to a tenant
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token grants scope.
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// WithClaims returns a copy of ctx that carries the claims of the caller's
+// token.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// LookupClaims returns the claims stored in ctx and whether the request was
+// authenticated.
+func LookupClaims(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(Claims)
+	return claims, ok
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// AssignmentBroadcasterOptions tunes an AssignmentBroadcaster; zero values
+// fall back to defaults.
+type AssignmentBroadcasterOptions struct {
+	PollInterval time.Duration // how often new audit entries are read
+	BatchSize    int           // audit entries read per query
+	ReplaySize   int           // recent changes kept for resuming subscribers
+	BufferSize   int           // changes queued per subscriber before it is evicted
+	GapTimeout   time.Duration // how long a missing audit ID is waited for
+}
+
+const (
+	defaultBroadcastPollInterval = 500 * time.Millisecond
+	defaultBroadcastBatchSize    = 500
+	defaultBroadcastReplaySize   = 1000
+	defaultBroadcastBufferSize   = 256
+	defaultBroadcastGapTimeout   = 5 * time.Second
+)
+
+// AssignmentBroadcaster implements ports.AssignmentFeed by polling the
+// audit trail, so every replica streams every change no matter which
+// replica made it, and writes never wait for subscribers.
+//
+// Changes are published in audit ID order. An ID that is missing while
+// later ones are visible usually belongs to a transaction that has not
+// committed yet, so publishing stops there for up to GapTimeout; after that
+// the ID is assumed rolled back and skipped.
+//
+// A subscriber that does not drain its queue of BufferSize changes is
+// evicted: its Events channel is closed and it may resubscribe with the
+// last ID it handled.
+type AssignmentBroadcaster struct {
+	repo ports.AssignmentRepository
+	opts AssignmentBroadcasterOptions
+
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+	cursor   int64               // ID of the last published change
+	floor    int64               // every change after floor is in replay
+	replay   []models.AuditEntry // the latest changes, oldest first
+	subs     map[*assignmentSubscription]struct{}
+	gapSince time.Time // when the change after cursor was first found missing
+}
+
+func NewAssignmentBroadcaster(repo ports.AssignmentRepository, opts AssignmentBroadcasterOptions) *AssignmentBroadcaster {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultBroadcastPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBroadcastBatchSize
+	}
+	if opts.ReplaySize <= 0 {
+		opts.ReplaySize = defaultBroadcastReplaySize
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBroadcastBufferSize
+	}
+	if opts.GapTimeout <= 0 {
+		opts.GapTimeout = defaultBroadcastGapTimeout
+	}
+	return &AssignmentBroadcaster{repo: repo, opts: opts, subs: map[*assignmentSubscription]struct{}{}}
+}
+
+// Run publishes new changes once per poll interval until ctx is cancelled,
+// then closes every subscription.
+func (b *AssignmentBroadcaster) Run(ctx context.Context) {
+	defer b.stop()
+
+	ticker := time.NewTicker(b.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		n, err := b.PollOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("assignment broadcaster: %v", err)
+		}
+		if n == b.opts.BatchSize {
+			continue // more are waiting
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollOnce publishes the changes recorded since the last call and reports
+// how many it published. The first call only notes where the audit trail
+// ends.
+func (b *AssignmentBroadcaster) PollOnce(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	started, cursor := b.started, b.cursor
+	b.mu.Unlock()
+
+	if !started {
+		latest, err := b.repo.LatestAuditID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("read latest audit id: %w", err)
+		}
+		b.mu.Lock()
+		b.started, b.cursor, b.floor = true, latest, latest
+		b.mu.Unlock()
+		return 0, nil
+	}
+
+	entries, err := b.repo.AuditSince(ctx, cursor, b.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("read audit entries: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	published := 0
+	for _, e := range entries {
+		if e.ID != b.cursor+1 {
+			if b.gapSince.IsZero() {
+				b.gapSince = time.Now()
+			}
+			if time.Since(b.gapSince) < b.opts.GapTimeout {
+				break
+			}
+		}
+		b.gapSince = time.Time{}
+		b.publishLocked(e)
+		published++
+	}
+	return published, nil
+}
+
+func (b *AssignmentBroadcaster) publishLocked(e models.AuditEntry) {
+	b.cursor = e.ID
+	b.replay = append(b.replay, e)
+	if len(b.replay) > b.opts.ReplaySize {
+		b.floor = b.replay[0].ID
+		b.replay = b.replay[1:]
+	}
+	for s := range b.subs {
+		if e.ID <= s.after || !s.filter.Matches(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// Never wait for a slow subscriber; it resumes from its last ID.
+			b.unsubscribeLocked(s)
+		}
+	}
+}
+
+func (b *AssignmentBroadcaster) Subscribe(filter models.AssignmentChangeFilter, lastEventID int64) ports.AssignmentSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &assignmentSubscription{
+		broadcaster: b,
+		filter:      filter,
+		after:       lastEventID,
+		events:      make(chan models.AuditEntry, b.opts.BufferSize),
+	}
+	switch {
+	case lastEventID == 0:
+		s.resumed = true
+	case !b.started:
+		// Where the trail ended before this process started is unknown.
+	case lastEventID >= b.floor:
+		s.resumed = true
+		for _, e := range b.replay {
+			if e.ID > lastEventID && filter.Matches(e) {
+				s.replay = append(s.replay, e)
+			}
+		}
+	}
+	if b.stopped {
+		close(s.events)
+		return s
+	}
+	b.subs[s] = struct{}{}
+	return s
+}
+
+func (b *AssignmentBroadcaster) unsubscribeLocked(s *assignmentSubscription) {
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.events)
+	}
+}
+
+func (b *AssignmentBroadcaster) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopped = true
+	for s := range b.subs {
+		b.unsubscribeLocked(s)
+	}
+}
+
+type assignmentSubscription struct {
+	broadcaster *AssignmentBroadcaster
+	filter      models.AssignmentChangeFilter
+	after       int64 // changes up to this ID were seen before subscribing
+	events      chan models.AuditEntry
+	replay      []models.AuditEntry
+	resumed     bool
+}
+
+func (s *assignmentSubscription) Replay() []models.AuditEntry      { return s.replay }
+func (s *assignmentSubscription) Resumed() bool                    { return s.resumed }
+func (s *assignmentSubscription) Events() <-chan models.AuditEntry { return s.events }
+
+func (s *assignmentSubscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+	s.broadcaster.unsubscribeLocked(s)
+}
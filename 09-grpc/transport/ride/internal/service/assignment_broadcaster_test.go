@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+func pollOnce(t *testing.T, b *service.AssignmentBroadcaster, want int) {
+	t.Helper()
+	n, err := b.PollOnce(context.Background())
+	if err != nil || n != want {
+		t.Fatalf("PollOnce: expected %d changes, got %d (err %v)", want, n, err)
+	}
+}
+
+func receive(t *testing.T, sub ports.AssignmentSubscription) (models.AuditEntry, bool) {
+	t.Helper()
+	select {
+	case e, ok := <-sub.Events():
+		return e, ok
+	default:
+		t.Fatal("expected a change to be queued")
+		return models.AuditEntry{}, false
+	}
+}
+
+func TestAssignmentBroadcasterFiltersAndResumes(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAssignmentRepository()
+	now := time.Now().UTC()
+	a1 := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: now, EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := repo.Save(ctx, a1); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	b := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{ReplaySize: 2})
+	pollOnce(t, b, 0) // starts after the existing change
+	v1 := "V1"
+	sub := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant, VehicleID: &v1}, 0)
+	defer sub.Close()
+	elsewhere := b.Subscribe(models.AssignmentChangeFilter{TenantID: "paris"}, 0)
+	defer elsewhere.Close()
+
+	a2 := a1
+	a2.ID, a2.VehicleID = "A2", "V2"
+	if _, err := repo.Save(ctx, a2); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// Moving A2 to V1 matches the filter through the after image.
+	a2.VehicleID, a2.Version = "V1", 1
+	a2.StartsAt, a2.EndsAt = now.Add(2*time.Hour), now.Add(3*time.Hour)
+	if _, err := repo.Save(ctx, a2); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	pollOnce(t, b, 2)
+
+	e, ok := receive(t, sub)
+	if !ok || e.AssignmentID != "A2" || e.Action != models.AuditActionUpdated || e.ID != 3 {
+		t.Fatalf("expected the move of A2 to V1, got %+v (open %v)", e, ok)
+	}
+	if len(sub.Events()) != 0 {
+		t.Fatalf("expected the creation on V2 to be filtered out")
+	}
+	if len(elsewhere.Events()) != 0 {
+		t.Fatalf("expected changes of another tenant to be filtered out")
+	}
+
+	resumed := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 2)
+	defer resumed.Close()
+	if !resumed.Resumed() || len(resumed.Replay()) != 1 || resumed.Replay()[0].ID != 3 {
+		t.Fatalf("expected change 3 to be replayed, got resumed=%v %+v", resumed.Resumed(), resumed.Replay())
+	}
+
+	// A third change pushes change 2 out of the replay buffer.
+	a3 := a1
+	a3.ID, a3.VehicleID = "A3", "V3"
+	if _, err := repo.Save(ctx, a3); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	pollOnce(t, b, 1)
+	stale := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 1)
+	defer stale.Close()
+	if stale.Resumed() || len(stale.Replay()) != 0 {
+		t.Fatalf("expected a reset, got resumed=%v %+v", stale.Resumed(), stale.Replay())
+	}
+}
+
+func TestAssignmentBroadcasterEvictsSlowSubscribers(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAssignmentRepository()
+	b := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{BufferSize: 1})
+	pollOnce(t, b, 0)
+	slow := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 0)
+	defer slow.Close()
+
+	now := time.Now().UTC()
+	for _, id := range []string{"A1", "A2"} {
+		a := models.Assignment{ID: id, VehicleID: "V-" + id, RouteID: "R1", StartsAt: now, EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+		if _, err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	pollOnce(t, b, 2)
+
+	if e, ok := receive(t, slow); !ok || e.ID != 1 {
+		t.Fatalf("expected the queued change, got %+v (open %v)", e, ok)
+	}
+	if _, ok := receive(t, slow); ok {
+		t.Fatal("expected the slow subscriber to be evicted")
+	}
+	slow.Close() // no-op after eviction
+}
+
+// gappyRepository serves a fixed audit trail that may lack some IDs.
+type gappyRepository struct {
+	ports.AssignmentRepository
+	entries []models.AuditEntry
+}
+
+func (r *gappyRepository) LatestAuditID(ctx context.Context) (int64, error) { return 0, nil }
+
+func (r *gappyRepository) AuditSince(ctx context.Context, afterID int64, limit int) ([]models.AuditEntry, error) {
+	var out []models.AuditEntry
+	for _, e := range r.entries {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func TestAssignmentBroadcasterWaitsForGaps(t *testing.T) {
+	change := func(id int64) models.AuditEntry {
+		return models.AuditEntry{ID: id, TenantID: requestctx.DefaultTenant}
+	}
+	repo := &gappyRepository{entries: []models.AuditEntry{change(1), change(3)}}
+	b := service.NewAssignmentBroadcaster(repo, service.AssignmentBroadcasterOptions{GapTimeout: 50 * time.Millisecond})
+	pollOnce(t, b, 0)
+	sub := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 0)
+	defer sub.Close()
+
+	// Change 2 may still be committing: 3 is held back.
+	pollOnce(t, b, 1)
+	pollOnce(t, b, 0)
+	repo.entries = []models.AuditEntry{change(1), change(2), change(3), change(5)}
+	pollOnce(t, b, 2)
+	for _, want := range []int64{1, 2, 3} {
+		if e, ok := receive(t, sub); !ok || e.ID != want {
+			t.Fatalf("expected change %d, got %+v (open %v)", want, e, ok)
+		}
+	}
+
+	// Change 4 never shows up and is skipped once the timeout has passed.
+	pollOnce(t, b, 0)
+	time.Sleep(60 * time.Millisecond)
+	pollOnce(t, b, 1)
+	if e, ok := receive(t, sub); !ok || e.ID != 5 {
+		t.Fatalf("expected change 5, got %+v (open %v)", e, ok)
+	}
+}
+
+func TestAssignmentBroadcasterClosesSubscriptionsOnStop(t *testing.T) {
+	b := service.NewAssignmentBroadcaster(repository.NewMemoryAssignmentRepository(), service.AssignmentBroadcasterOptions{})
+	sub := b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.Run(ctx)
+
+	if _, ok := <-sub.Events(); ok {
+		t.Fatal("expected the subscription to be closed")
+	}
+	if _, ok := <-b.Subscribe(models.AssignmentChangeFilter{TenantID: requestctx.DefaultTenant}, 0).Events(); ok {
+		t.Fatal("expected subscriptions after stop to be closed")
+	}
+}
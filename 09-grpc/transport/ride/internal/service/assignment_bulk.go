@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// Import checks every row before writing anything. An atomic import then
+// stores all rows in one transaction, or when any row fails reports every
+// failing row and creates none; a partial import saves them one by one.
+// Like Save, both reserve the vehicles first when sagas are set. Only
+// validation failures and schedule conflicts, including refused
+// reservations, are reported per row: anything else, such as the database
+// being down, fails the import, and a partial import keeps the rows it
+// saved before that.
+func (s *assignmentService) Import(ctx context.Context, rows []models.ImportRow, mode models.ImportMode) (models.ImportReport, error) {
+	switch mode {
+	case "":
+		mode = models.ImportAtomic
+	case models.ImportAtomic, models.ImportPartial:
+	default:
+		return models.ImportReport{}, models.NewValidationError("unknown import mode %q", mode)
+	}
+	if len(rows) == 0 {
+		return models.ImportReport{}, models.NewValidationError("nothing to import")
+	}
+	if len(rows) > models.MaxImportRows {
+		return models.ImportReport{}, models.NewValidationError("at most %d rows can be imported at once", models.MaxImportRows)
+	}
+
+	report := models.ImportReport{Mode: mode, Rows: make([]models.ImportRowResult, len(rows))}
+	fail := func(i int, err error) {
+		report.Rows[i].Status, report.Rows[i].Err = models.ImportRowFailed, err
+		report.Failed++
+	}
+	created := func(i int, id string) {
+		report.Rows[i].Status, report.Rows[i].ID = models.ImportRowCreated, id
+		report.Imported++
+	}
+
+	var (
+		batch   []models.Assignment
+		indexes []int // position in rows of each batch entry
+	)
+	for i, row := range rows {
+		report.Rows[i].Row = row.Row
+		a := row.Assignment
+		a.ID = ""
+		err := row.Err
+		if err == nil {
+			a, err = newPendingAssignment(a)
+		}
+		if err != nil {
+			fail(i, err)
+			continue
+		}
+		batch = append(batch, a)
+		indexes = append(indexes, i)
+	}
+
+	if mode == models.ImportPartial {
+		for j, a := range batch {
+			if err := s.create(ctx, a); err != nil {
+				if !isRowError(err) {
+					return models.ImportReport{}, err
+				}
+				fail(indexes[j], err)
+				continue
+			}
+			created(indexes[j], a.ID)
+		}
+		return report, nil
+	}
+
+	var err error
+	switch {
+	case report.Failed > 0:
+		// Nothing is created, but the valid rows are still checked against
+		// the schedule so that every failing row is reported at once.
+		if len(batch) > 0 {
+			err = s.assignmentRepo.CheckAll(ctx, batch)
+		}
+	case s.sagas != nil:
+		err = s.sagas.CreateAll(ctx, batch)
+	default:
+		err = s.assignmentRepo.SaveAll(ctx, batch)
+	}
+	var batchErr *models.BatchError
+	switch {
+	case err == nil && report.Failed == 0:
+		for j, a := range batch {
+			created(indexes[j], a.ID)
+		}
+		return report, nil
+	case errors.As(err, &batchErr):
+		for _, item := range batchErr.Items {
+			if !isRowError(item.Err) {
+				return models.ImportReport{}, err
+			}
+			fail(indexes[item.Index], item.Err)
+		}
+	case err != nil:
+		return models.ImportReport{}, err
+	}
+	for i := range report.Rows {
+		if report.Rows[i].Status == "" {
+			report.Rows[i].Status = models.ImportRowSkipped
+		}
+	}
+	return report, nil
+}
+
+// isRowError reports whether err is the fault of the row being imported.
+func isRowError(err error) bool {
+	return errors.Is(err, models.ErrValidation) || errors.Is(err, models.ErrConflict)
+}
+
+// Export walks the listing with the same keyset cursor as List, so memory
+// use does not grow with the result. Assignments written while the export
+// runs may or may not be included.
+func (s *assignmentService) Export(ctx context.Context, q models.AssignmentQuery, fn func(models.Assignment) error) error {
+	q, err := checkQuery(q)
+	if err != nil {
+		return err
+	}
+	q.Limit, q.After = MaxPageSize, nil
+	for {
+		page, err := s.assignmentRepo.FindAll(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, a := range page.Items {
+			if err := fn(a); err != nil {
+				return err
+			}
+		}
+		if page.Next == nil {
+			return nil
+		}
+		q.After = page.Next
+	}
+}
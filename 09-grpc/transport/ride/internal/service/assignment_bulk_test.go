@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+func TestAssignmentServiceImport(t *testing.T) {
+	base := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	row := func(n int, vehicleID string, startsAt time.Time) models.ImportRow {
+		return models.ImportRow{Row: n, Assignment: models.Assignment{VehicleID: vehicleID, RouteID: "R1", StartsAt: startsAt}}
+	}
+	// Row 3 overlaps row 1; row 4 overlaps the assignment stored up front.
+	rows := []models.ImportRow{
+		row(1, "V1", base),
+		row(2, "V2", base),
+		row(3, "V1", base.Add(30*time.Minute)),
+		row(4, "V3", base),
+		{Row: 5, Err: models.NewValidationError("startsAt is not an RFC 3339 instant")},
+	}
+
+	testCases := []struct {
+		name       string
+		mode       models.ImportMode
+		rows       []models.ImportRow
+		want       []models.ImportRowStatus
+		wantStored int
+	}{
+		{
+			name: "atomic reports every conflict",
+			mode: models.ImportAtomic,
+			rows: rows[:4],
+			want: []models.ImportRowStatus{models.ImportRowSkipped, models.ImportRowSkipped, models.ImportRowFailed, models.ImportRowFailed},
+		},
+		{
+			name: "atomic reports invalid and conflicting rows without writing",
+			mode: models.ImportAtomic,
+			rows: rows,
+			want: []models.ImportRowStatus{models.ImportRowSkipped, models.ImportRowSkipped, models.ImportRowFailed, models.ImportRowFailed, models.ImportRowFailed},
+		},
+		{
+			name:       "atomic without failures",
+			rows:       rows[:2],
+			want:       []models.ImportRowStatus{models.ImportRowCreated, models.ImportRowCreated},
+			wantStored: 2,
+		},
+		{
+			name:       "partial keeps the valid rows",
+			mode:       models.ImportPartial,
+			rows:       rows,
+			want:       []models.ImportRowStatus{models.ImportRowCreated, models.ImportRowCreated, models.ImportRowFailed, models.ImportRowFailed, models.ImportRowFailed},
+			wantStored: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := repository.NewMemoryAssignmentRepository()
+			svc := service.NewAssignmentService(repo, nil, nil)
+			existing, err := svc.Save(ctx, models.Assignment{VehicleID: "V3", RouteID: "R9", StartsAt: base})
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			report, err := svc.Import(ctx, tc.rows, tc.mode)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if len(report.Rows) != len(tc.want) || report.Imported != tc.wantStored {
+				t.Fatalf("unexpected report %+v", report)
+			}
+			for i, r := range report.Rows {
+				if r.Row != tc.rows[i].Row || r.Status != tc.want[i] {
+					t.Errorf("row %d: expected %s, got %+v", i+1, tc.want[i], r)
+				}
+				if r.Status == models.ImportRowCreated {
+					if _, err := repo.FindByID(ctx, r.ID); err != nil {
+						t.Errorf("row %d: created assignment not found: %v", i+1, err)
+					}
+				}
+			}
+			if len(report.Rows) > 3 && report.Rows[3].Status == models.ImportRowFailed {
+				if msg := models.ErrorMessage(report.Rows[3].Err, ""); !strings.Contains(msg, existing.ID) {
+					t.Errorf("expected the conflict of row 4 to name %s, got %q", existing.ID, msg)
+				}
+			}
+
+			page, err := repo.FindAll(ctx, models.AssignmentQuery{Order: models.SortAscending})
+			if err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+			if got := len(page.Items) - 1; got != tc.wantStored {
+				t.Fatalf("expected %d imported assignments stored, got %d", tc.wantStored, got)
+			}
+		})
+	}
+}
+
+func TestAssignmentServiceImportRejectsRequests(t *testing.T) {
+	svc := service.NewAssignmentService(repository.NewMemoryAssignmentRepository(), nil, nil)
+	one := []models.ImportRow{{Row: 1}}
+
+	testCases := []struct {
+		name string
+		rows []models.ImportRow
+		mode models.ImportMode
+	}{
+		{name: "no rows", mode: models.ImportAtomic},
+		{name: "too many", rows: make([]models.ImportRow, models.MaxImportRows+1), mode: models.ImportAtomic},
+		{name: "unknown mode", rows: one, mode: "best-effort"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := svc.Import(context.Background(), tc.rows, tc.mode); !errors.Is(err, models.ErrValidation) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignmentServiceImportReservesVehicles(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	row := func(n int, vehicleID string) models.ImportRow {
+		return models.ImportRow{Row: n, Assignment: models.Assignment{VehicleID: vehicleID, RouteID: "R1", StartsAt: base}}
+	}
+	// The vehicle service holds V2 for another system, so it refuses row 2
+	// although the schedule of the ride service has room.
+	rows := []models.ImportRow{row(1, "V1"), row(2, "V2"), row(3, "V3")}
+
+	testCases := []struct {
+		name     string
+		mode     models.ImportMode
+		want     []models.ImportRowStatus
+		wantHeld int // reservations held afterwards, the other system's included
+	}{
+		{
+			name:     "atomic releases what it reserved",
+			mode:     models.ImportAtomic,
+			want:     []models.ImportRowStatus{models.ImportRowSkipped, models.ImportRowFailed, models.ImportRowSkipped},
+			wantHeld: 1,
+		},
+		{
+			name:     "partial keeps the reserved rows",
+			mode:     models.ImportPartial,
+			want:     []models.ImportRowStatus{models.ImportRowCreated, models.ImportRowFailed, models.ImportRowCreated},
+			wantHeld: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMemoryAssignmentRepository()
+			reserver := &fakeVehicleReserver{held: map[string]models.Assignment{
+				"other": {VehicleID: "V2", StartsAt: base, EndsAt: base.Add(time.Hour)},
+			}}
+			svc := service.NewAssignmentService(repo, nil, service.NewAssignmentSagaOrchestrator(repo, repository.NewMemoryAssignmentSagaStore(), reserver, service.AssignmentSagaOptions{}))
+
+			report, err := svc.Import(ctx, rows, tc.mode)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			for i, r := range report.Rows {
+				if r.Status != tc.want[i] {
+					t.Errorf("row %d: expected %s, got %+v", i+1, tc.want[i], r)
+				}
+				if _, held := reserver.held[r.ID]; r.Status == models.ImportRowCreated && !held {
+					t.Errorf("row %d: created without a reservation", i+1)
+				}
+			}
+			if !errors.Is(report.Rows[1].Err, models.ErrConflict) {
+				t.Errorf("expected row 2 refused with a conflict, got %v", report.Rows[1].Err)
+			}
+			if len(reserver.held) != tc.wantHeld {
+				t.Fatalf("expected %d reservations held, got %v", tc.wantHeld, reserver.held)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// AssignmentSagaOptions tunes an AssignmentSagaOrchestrator; zero values
+// fall back to defaults.
+type AssignmentSagaOptions struct {
+	ResumeInterval time.Duration // how often interrupted sagas are looked up
+	// StaleAfter is how long a saga must be left untouched before it
+	// counts as interrupted. It must exceed the longest create or update
+	// request.
+	StaleAfter time.Duration
+	BatchSize  int // sagas resumed per run
+}
+
+const (
+	defaultSagaResumeInterval = 10 * time.Second
+	defaultSagaStaleAfter     = time.Minute
+	defaultSagaBatchSize      = 100
+)
+
+var (
+	// errSagaInterrupted is the cause recorded when a resumed saga is
+	// rolled back.
+	errSagaInterrupted = errors.New("assignment saga was interrupted")
+	// errAssignmentStored reports a saga that found its assignment stored
+	// as it meant while rolling back.
+	errAssignmentStored = errors.New("assignment is already stored")
+)
+
+// AssignmentSagaOrchestrator creates assignments that hold their vehicle in
+// the vehicle service: it reserves the vehicle, then stores the assignment,
+// whose AssignmentCreated event the repository writes to the outbox in the
+// same transaction. Either both steps stand or the reservation is released.
+// Updates that move an assignment to another vehicle or window go the same
+// way, except that a move rolled back puts the reservation back to what the
+// stored assignment holds.
+//
+// Every step is recorded in the saga store before the next one starts, so
+// that a saga interrupted by a crash can be resumed: Run finishes it if its
+// assignment was stored as the saga meant and rolls it back otherwise. Each saga write is a
+// compare-and-set on the version, so only one process drives a saga at a
+// time, and the vehicle calls are idempotent by reservation ID, so
+// repeating one after a crash is harmless.
+//
+// A reservation is keyed by the ID of its assignment, so that it can be
+// moved when the assignment is updated and released once the assignment
+// reaches a terminal status; see Move and ReleaseVehicleOnTerminalStatus.
+type AssignmentSagaOrchestrator struct {
+	repo     ports.AssignmentRepository
+	sagas    ports.AssignmentSagaStore
+	vehicles ports.VehicleReserver
+	opts     AssignmentSagaOptions
+	now      func() time.Time
+}
+
+func NewAssignmentSagaOrchestrator(repo ports.AssignmentRepository, sagas ports.AssignmentSagaStore, vehicles ports.VehicleReserver, opts AssignmentSagaOptions) *AssignmentSagaOrchestrator {
+	if opts.ResumeInterval <= 0 {
+		opts.ResumeInterval = defaultSagaResumeInterval
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = defaultSagaStaleAfter
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultSagaBatchSize
+	}
+	return &AssignmentSagaOrchestrator{repo: repo, sagas: sagas, vehicles: vehicles, opts: opts, now: time.Now}
+}
+
+// Create stores a, a validated new assignment with its ID set, once its
+// vehicle is reserved. On failure it returns the error of the step that
+// failed after undoing the earlier ones; an undo that fails is left to Run.
+func (o *AssignmentSagaOrchestrator) Create(ctx context.Context, a models.Assignment) error {
+	saga, err := o.reserve(ctx, models.AssignmentSagaCreate, a)
+	if errors.Is(err, errAssignmentStored) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := o.repo.Save(ctx, a); err != nil {
+		return o.compensate(ctx, saga, err)
+	}
+	// The assignment stands even if this write fails: Run completes the
+	// saga once it finds the assignment stored.
+	o.finish(ctx, saga, models.AssignmentSagaCompleted, nil)
+	return nil
+}
+
+// CreateAll is Create for a batch that SaveAll stores in one transaction:
+// every vehicle is reserved, each in a saga of its own, before the batch is
+// stored, and all of them are released if it is not. Refused reservations
+// fail the batch with a *models.BatchError naming them, together with the
+// assignments CheckAll finds fault with among the others.
+func (o *AssignmentSagaOrchestrator) CreateAll(ctx context.Context, as []models.Assignment) error {
+	sagas := make([]models.AssignmentSaga, 0, len(as))
+	rollback := func(cause error) error {
+		for _, saga := range sagas {
+			_ = o.compensate(ctx, saga, cause)
+		}
+		return cause
+	}
+	var refused []models.ItemError
+	for i, a := range as {
+		saga, err := o.reserve(ctx, models.AssignmentSagaCreate, a)
+		if errors.Is(err, errAssignmentStored) {
+			continue // its reservation is its own now, not the batch's
+		}
+		if isRowError(err) {
+			refused = append(refused, models.ItemError{Index: i, Err: err})
+			continue
+		}
+		if err != nil {
+			return rollback(err)
+		}
+		sagas = append(sagas, saga)
+	}
+	if len(refused) > 0 {
+		return rollback(checkOthers(ctx, o.repo, as, refused))
+	}
+	if err := o.repo.SaveAll(ctx, as); err != nil {
+		return rollback(err)
+	}
+	for _, saga := range sagas {
+		o.finish(ctx, saga, models.AssignmentSagaCompleted, nil)
+	}
+	return nil
+}
+
+// checkOthers returns a *models.BatchError naming the failed items of as
+// together with those of the others that repo.CheckAll finds fault with,
+// so that a batch reports all its failures at once; other errors of
+// CheckAll are returned as they are.
+func checkOthers(ctx context.Context, repo ports.AssignmentRepository, as []models.Assignment, failed []models.ItemError) error {
+	var (
+		others  []models.Assignment
+		indexes []int // position in as of each entry of others
+	)
+	for i, a := range as {
+		if !slices.ContainsFunc(failed, func(item models.ItemError) bool { return item.Index == i }) {
+			others = append(others, a)
+			indexes = append(indexes, i)
+		}
+	}
+	var batchErr *models.BatchError
+	if len(others) > 0 {
+		switch err := repo.CheckAll(ctx, others); {
+		case errors.As(err, &batchErr):
+			for _, item := range batchErr.Items {
+				failed = append(failed, models.ItemError{Index: indexes[item.Index], Err: item.Err})
+			}
+		case err != nil:
+			return err
+		}
+	}
+	slices.SortFunc(failed, func(a, b models.ItemError) int { return a.Index - b.Index })
+	return &models.BatchError{Items: failed}
+}
+
+// reserve starts a saga of kind for a and reserves its vehicle. On failure
+// the saga is already settled or left to Run; errAssignmentStored means a
+// turned out to be stored already and the saga completed.
+func (o *AssignmentSagaOrchestrator) reserve(ctx context.Context, kind models.AssignmentSagaKind, a models.Assignment) (models.AssignmentSaga, error) {
+	saga := models.AssignmentSaga{
+		ID:         uuid.NewString(),
+		TenantID:   requestctx.Tenant(ctx),
+		Kind:       kind,
+		Assignment: a,
+		Actor:      requestctx.Actor(ctx),
+		RequestID:  requestctx.RequestID(ctx),
+		State:      models.AssignmentSagaStarted,
+	}
+	if err := o.sagas.Create(ctx, saga); err != nil {
+		return saga, err
+	}
+	saga.Version = 1
+
+	if err := o.vehicles.Reserve(ctx, a.ID, a); err != nil {
+		if errors.Is(err, models.ErrConflict) || errors.Is(err, models.ErrValidation) {
+			// Refused: nothing is held, so there is nothing to undo.
+			o.finish(ctx, saga, models.AssignmentSagaAborted, err)
+			return saga, err
+		}
+		return saga, o.settle(ctx, saga, err)
+	}
+	next, err := o.moveTo(ctx, saga, models.AssignmentSagaVehicleReserved, nil)
+	if err != nil {
+		return saga, o.settle(ctx, saga, err)
+	}
+	return next, nil
+}
+
+// settle is compensate for a saga whose assignment was not saved by this
+// call, reporting an assignment found stored as errAssignmentStored.
+func (o *AssignmentSagaOrchestrator) settle(ctx context.Context, saga models.AssignmentSaga, cause error) error {
+	if err := o.compensate(ctx, saga, cause); err != nil {
+		return err
+	}
+	return errAssignmentStored
+}
+
+// Move stores a, an update of the stored assignment that moves it to
+// another vehicle or window, once the reservation is moved along, in a saga
+// of its own. If the update is not stored, the reservation is put back to
+// what is stored then: the update may have committed before its answer was
+// lost, or the assignment may have been cancelled meanwhile, which releases
+// it. The error of the save is returned either way.
+func (o *AssignmentSagaOrchestrator) Move(ctx context.Context, a models.Assignment) error {
+	saga, err := o.reserve(ctx, models.AssignmentSagaMove, a)
+	if errors.Is(err, errAssignmentStored) {
+		// The stored assignment holds the vehicle and window of a already,
+		// and so does the reservation; the rest of a is a plain update.
+		_, err = o.repo.Save(ctx, a)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := o.repo.Save(ctx, a); err != nil {
+		// Not compensate: Save fails with ErrPreconditionFailed when the
+		// assignment changed since it was read, which compensate would take
+		// for the saga having been taken over.
+		_ = o.rollBack(ctx, saga, err)
+		return err
+	}
+	o.finish(ctx, saga, models.AssignmentSagaCompleted, nil)
+	return nil
+}
+
+// restore makes the reservation of assignment id match the stored one and
+// returns the stored assignment.
+func (o *AssignmentSagaOrchestrator) restore(ctx context.Context, id string) (models.Assignment, error) {
+	stored, err := o.repo.FindByID(ctx, id)
+	if err != nil {
+		return models.Assignment{}, err
+	}
+	if models.AssignmentStatus(stored.Status).IsTerminal() {
+		return stored, o.vehicles.Release(ctx, id)
+	}
+	return stored, o.vehicles.Reserve(ctx, id, stored)
+}
+
+// compensate rolls saga back after cause and returns cause. A save that
+// failed or went unanswered may still have committed; the assignment then
+// stands and the saga completes instead, returning nil.
+func (o *AssignmentSagaOrchestrator) compensate(ctx context.Context, saga models.AssignmentSaga, cause error) error {
+	if errors.Is(cause, models.ErrPreconditionFailed) {
+		// Run has taken the saga over; it finishes the work.
+		return models.NewUnavailableError(cause, "the assignment change was interrupted and is being rolled back; retry later")
+	}
+	return o.rollBack(ctx, saga, cause)
+}
+
+// rollBack is compensate for a saga that has not been taken over.
+func (o *AssignmentSagaOrchestrator) rollBack(ctx context.Context, saga models.AssignmentSaga, cause error) error {
+	// The caller may have given up already; the undo must run regardless.
+	ctx = context.WithoutCancel(ctx)
+
+	if saga.State != models.AssignmentSagaCompensating {
+		next, err := o.moveTo(ctx, saga, models.AssignmentSagaCompensating, cause)
+		if err != nil {
+			log.Printf("assignment saga %s: record compensation: %v", saga.ID, err)
+			if errors.Is(err, models.ErrPreconditionFailed) {
+				return cause
+			}
+		} else {
+			saga = next
+		}
+	}
+
+	if saga.Kind == models.AssignmentSagaMove {
+		return o.rollBackMove(ctx, saga, cause)
+	}
+	if _, err := o.repo.FindByID(ctx, saga.Assignment.ID); err == nil {
+		o.finish(ctx, saga, models.AssignmentSagaCompleted, nil)
+		return nil
+	} else if !errors.Is(err, models.ErrNotFound) {
+		log.Printf("assignment saga %s: check assignment %s, retried later: %v", saga.ID, saga.Assignment.ID, err)
+		return cause
+	}
+	if err := o.vehicles.Release(ctx, saga.Assignment.ID); err != nil {
+		log.Printf("assignment saga %s: release vehicle %s, retried later: %v", saga.ID, saga.Assignment.VehicleID, err)
+		return cause
+	}
+	o.finish(ctx, saga, models.AssignmentSagaAborted, cause)
+	return cause
+}
+
+// rollBackMove puts the reservation of a move back to what the stored
+// assignment holds. The saga completes if that is what it moved to.
+func (o *AssignmentSagaOrchestrator) rollBackMove(ctx context.Context, saga models.AssignmentSaga, cause error) error {
+	stored, err := o.restore(ctx, saga.Assignment.ID)
+	if err != nil {
+		log.Printf("assignment saga %s: restore the reservation of assignment %s, retried later: %v", saga.ID, saga.Assignment.ID, err)
+		return cause
+	}
+	if !movesVehicleHold(stored, saga.Assignment) {
+		o.finish(ctx, saga, models.AssignmentSagaCompleted, nil)
+		return nil
+	}
+	o.finish(ctx, saga, models.AssignmentSagaAborted, cause)
+	return cause
+}
+
+// moveTo records that saga reached state and returns it at its new version.
+func (o *AssignmentSagaOrchestrator) moveTo(ctx context.Context, saga models.AssignmentSaga, state models.AssignmentSagaState, cause error) (models.AssignmentSaga, error) {
+	next := saga
+	next.State = state
+	if cause != nil {
+		next.LastError = cause.Error()
+	}
+	if err := o.sagas.Update(ctx, next); err != nil {
+		return saga, err
+	}
+	next.Version++
+	return next, nil
+}
+
+// finish records a final state. A failure is only logged: the saga stays
+// unfinished in the store and Run settles it again later.
+func (o *AssignmentSagaOrchestrator) finish(ctx context.Context, saga models.AssignmentSaga, state models.AssignmentSagaState, cause error) {
+	if _, err := o.moveTo(ctx, saga, state, cause); err != nil {
+		log.Printf("assignment saga %s: record %s: %v", saga.ID, state, err)
+		return
+	}
+	log.Printf("request %s: assignment saga %s for assignment %s %s", saga.RequestID, saga.ID, saga.Assignment.ID, state)
+}
+
+// Run resumes interrupted sagas until ctx is cancelled.
+func (o *AssignmentSagaOrchestrator) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.opts.ResumeInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.ResumeOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("assignment saga: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ResumeOnce settles one batch of sagas left untouched for StaleAfter and
+// reports how many it took over. A saga is completed if its assignment was
+// stored as it meant and rolled back otherwise: the request that started it has
+// already answered with an error, or not at all.
+func (o *AssignmentSagaOrchestrator) ResumeOnce(ctx context.Context) (int, error) {
+	sagas, err := o.sagas.ListUnfinished(ctx, o.now().UTC().Add(-o.opts.StaleAfter), o.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list unfinished sagas: %w", err)
+	}
+
+	resumed := 0
+	for _, saga := range sagas {
+		if ctx.Err() != nil {
+			return resumed, ctx.Err()
+		}
+		// Claim the saga before acting on it, so that another replica, or
+		// a request still working on it, stops at its next write.
+		saga.Attempts++
+		claimed, err := o.moveTo(ctx, saga, saga.State, nil)
+		if errors.Is(err, models.ErrPreconditionFailed) {
+			continue
+		}
+		if err != nil {
+			return resumed, fmt.Errorf("claim saga %s: %w", saga.ID, err)
+		}
+		log.Printf("assignment saga %s: resuming from %s, attempt %d", saga.ID, saga.State, saga.Attempts)
+		cause := errSagaInterrupted
+		if claimed.LastError != "" {
+			cause = errors.New(claimed.LastError)
+		}
+		_ = o.compensate(sagaContext(ctx, claimed), claimed, cause)
+		resumed++
+	}
+	return resumed, nil
+}
+
+// sagaContext carries the tenant, actor and request ID of the request that
+// started saga, so that resumed steps are scoped and audited like it.
+func sagaContext(ctx context.Context, saga models.AssignmentSaga) context.Context {
+	ctx = requestctx.WithTenant(ctx, saga.TenantID)
+	ctx = requestctx.WithActor(ctx, saga.Actor)
+	return requestctx.WithRequestID(ctx, saga.RequestID)
+}
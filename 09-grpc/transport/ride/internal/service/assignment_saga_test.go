@@ -0,0 +1,378 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// fakeVehicleReserver holds reservations by ID and refuses one that
+// overlaps another reservation of the vehicle, like the vehicle service. It
+// fails with reserveErr or releaseErr when set.
+type fakeVehicleReserver struct {
+	reserveErr error
+	releaseErr error
+	held       map[string]models.Assignment
+	lastID     string
+	releases   int
+}
+
+func (f *fakeVehicleReserver) Reserve(ctx context.Context, reservationID string, a models.Assignment) error {
+	f.lastID = reservationID
+	if f.reserveErr != nil {
+		return f.reserveErr
+	}
+	for id, other := range f.held {
+		if id != reservationID && other.VehicleID == a.VehicleID && other.StartsAt.Before(a.EndsAt) && a.StartsAt.Before(other.EndsAt) {
+			return models.NewConflictError("vehicle %s is reserved by %s", a.VehicleID, id)
+		}
+	}
+	if f.held == nil {
+		f.held = map[string]models.Assignment{}
+	}
+	f.held[reservationID] = a
+	return nil
+}
+
+func (f *fakeVehicleReserver) Release(ctx context.Context, reservationID string) error {
+	f.releases++
+	if f.releaseErr != nil {
+		return f.releaseErr
+	}
+	delete(f.held, reservationID)
+	return nil
+}
+
+// recordingSagaStore remembers the ID of the last saga created.
+type recordingSagaStore struct {
+	ports.AssignmentSagaStore
+	lastID string
+}
+
+func (s *recordingSagaStore) Create(ctx context.Context, saga models.AssignmentSaga) error {
+	s.lastID = saga.ID
+	return s.AssignmentSagaStore.Create(ctx, saga)
+}
+
+// failingSaveRepository fails Save with err, after storing the assignment
+// when committed is set, like a commit whose answer was lost.
+type failingSaveRepository struct {
+	ports.AssignmentRepository
+	err       error
+	committed bool
+}
+
+func (r *failingSaveRepository) Save(ctx context.Context, a models.Assignment) (bool, error) {
+	if r.committed {
+		if _, err := r.AssignmentRepository.Save(ctx, a); err != nil {
+			return false, err
+		}
+	}
+	return false, r.err
+}
+
+func TestAssignmentSagaCreate(t *testing.T) {
+	ctx := requestctx.WithTenant(context.Background(), "acme")
+	in := models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)}
+	dbDown := models.NewUnavailableError(errors.New("dial tcp"), "save assignment: database unavailable")
+
+	testCases := []struct {
+		name         string
+		reserver     *fakeVehicleReserver
+		repo         *failingSaveRepository
+		wantErr      error
+		wantStored   bool
+		wantHeld     bool
+		wantReleases int
+		wantState    models.AssignmentSagaState
+	}{
+		{name: "success", reserver: &fakeVehicleReserver{}, wantStored: true, wantHeld: true, wantState: models.AssignmentSagaCompleted},
+		{name: "reservation refused", reserver: &fakeVehicleReserver{reserveErr: models.NewConflictError("vehicle V1 is booked")}, wantErr: models.ErrConflict, wantState: models.AssignmentSagaAborted},
+		{name: "reservation unanswered", reserver: &fakeVehicleReserver{reserveErr: models.NewUnavailableError(errors.New("deadline"), "vehicle service did not answer")}, wantErr: models.ErrUnavailable, wantReleases: 1, wantState: models.AssignmentSagaAborted},
+		{name: "save failed", reserver: &fakeVehicleReserver{}, repo: &failingSaveRepository{err: dbDown}, wantErr: models.ErrUnavailable, wantReleases: 1, wantState: models.AssignmentSagaAborted},
+		{name: "save committed unanswered", reserver: &fakeVehicleReserver{}, repo: &failingSaveRepository{err: dbDown, committed: true}, wantStored: true, wantHeld: true, wantState: models.AssignmentSagaCompleted},
+		{name: "release failed", reserver: &fakeVehicleReserver{releaseErr: errors.New("connection reset")}, repo: &failingSaveRepository{err: dbDown}, wantErr: models.ErrUnavailable, wantHeld: true, wantReleases: 1, wantState: models.AssignmentSagaCompensating},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory := repository.NewMemoryAssignmentRepository()
+			var repo ports.AssignmentRepository = memory
+			if tc.repo != nil {
+				tc.repo.AssignmentRepository = memory
+				repo = tc.repo
+			}
+			sagas := &recordingSagaStore{AssignmentSagaStore: repository.NewMemoryAssignmentSagaStore()}
+			svc := service.NewAssignmentService(repo, nil, service.NewAssignmentSagaOrchestrator(repo, sagas, tc.reserver, service.AssignmentSagaOptions{}))
+
+			_, err := svc.Save(ctx, in)
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			saga, err := sagas.FindByID(ctx, sagas.lastID)
+			if err != nil {
+				t.Fatalf("find saga: %v", err)
+			}
+			if saga.State != tc.wantState || saga.TenantID != "acme" {
+				t.Fatalf("expected a %s saga of acme, got %+v", tc.wantState, saga)
+			}
+			_, err = memory.FindByID(ctx, saga.Assignment.ID)
+			if stored := err == nil; stored != tc.wantStored {
+				t.Fatalf("expected stored=%v, got %v", tc.wantStored, err)
+			}
+			if tc.reserver.lastID != saga.Assignment.ID {
+				t.Fatalf("expected the reservation keyed by assignment %s, got %s", saga.Assignment.ID, tc.reserver.lastID)
+			}
+			if _, held := tc.reserver.held[saga.Assignment.ID]; held != tc.wantHeld {
+				t.Fatalf("expected held=%v, got %v", tc.wantHeld, held)
+			}
+			if tc.reserver.releases != tc.wantReleases {
+				t.Fatalf("expected %d releases, got %d", tc.wantReleases, tc.reserver.releases)
+			}
+		})
+	}
+}
+
+func TestAssignmentSagaResumeOnce(t *testing.T) {
+	start := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		state        models.AssignmentSagaState
+		stored       bool
+		releaseErr   error
+		wantState    models.AssignmentSagaState
+		wantHeld     bool
+		wantAttempts int
+	}{
+		{name: "started, not stored", state: models.AssignmentSagaStarted, wantState: models.AssignmentSagaAborted, wantAttempts: 1},
+		{name: "reserved, not stored", state: models.AssignmentSagaVehicleReserved, wantState: models.AssignmentSagaAborted, wantAttempts: 1},
+		{name: "reserved, stored", state: models.AssignmentSagaVehicleReserved, stored: true, wantState: models.AssignmentSagaCompleted, wantHeld: true, wantAttempts: 1},
+		{name: "compensating, release fails", state: models.AssignmentSagaCompensating, releaseErr: errors.New("connection reset"), wantState: models.AssignmentSagaCompensating, wantHeld: true, wantAttempts: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMemoryAssignmentRepository()
+			sagas := repository.NewMemoryAssignmentSagaStore()
+			reserver := &fakeVehicleReserver{releaseErr: tc.releaseErr}
+
+			// The saga was started by a request of tenant acme that never
+			// finished.
+			a := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: start, EndsAt: start.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+			acme := requestctx.WithTenant(context.Background(), "acme")
+			if tc.stored {
+				if _, err := repo.Save(acme, a); err != nil {
+					t.Fatalf("seed assignment: %v", err)
+				}
+			}
+			reserver.held = map[string]models.Assignment{"A1": a}
+			saga := models.AssignmentSaga{ID: "S1", TenantID: "acme", Assignment: a, Actor: "alice", RequestID: "req-1", State: tc.state}
+			if err := sagas.Create(context.Background(), saga); err != nil {
+				t.Fatalf("seed saga: %v", err)
+			}
+
+			fresh := service.NewAssignmentSagaOrchestrator(repo, sagas, reserver, service.AssignmentSagaOptions{StaleAfter: time.Hour})
+			if n, err := fresh.ResumeOnce(context.Background()); err != nil || n != 0 {
+				t.Fatalf("expected a saga in flight to be left alone, got %d %v", n, err)
+			}
+
+			time.Sleep(time.Millisecond)
+			orchestrator := service.NewAssignmentSagaOrchestrator(repo, sagas, reserver, service.AssignmentSagaOptions{StaleAfter: time.Nanosecond})
+			if n, err := orchestrator.ResumeOnce(context.Background()); err != nil || n != 1 {
+				t.Fatalf("expected one saga resumed, got %d %v", n, err)
+			}
+
+			got, err := sagas.FindByID(context.Background(), "S1")
+			if err != nil {
+				t.Fatalf("find saga: %v", err)
+			}
+			if got.State != tc.wantState || got.Attempts != tc.wantAttempts {
+				t.Fatalf("expected %s after %d attempts, got %s after %d", tc.wantState, tc.wantAttempts, got.State, got.Attempts)
+			}
+			if _, held := reserver.held["A1"]; held != tc.wantHeld {
+				t.Fatalf("expected held=%v, got %v", tc.wantHeld, held)
+			}
+			if _, err := repo.FindByID(acme, "A1"); (err == nil) != tc.stored {
+				t.Fatalf("expected stored=%v, got %v", tc.stored, err)
+			}
+		})
+	}
+}
+
+func TestAssignmentSagaFreesVehicleForRebooking(t *testing.T) {
+	ctx := requestctx.WithTenant(context.Background(), "acme")
+	start := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	slot := models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: start, EndsAt: start.Add(time.Hour)}
+
+	transition := func(to ...models.AssignmentStatus) func(ports.AssignmentService, models.Assignment) error {
+		return func(svc ports.AssignmentService, a models.Assignment) error {
+			for _, status := range to {
+				if _, err := svc.Transition(ctx, a.ID, status, "", 0); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	testCases := []struct {
+		name     string
+		free     func(ports.AssignmentService, models.Assignment) error
+		wantHeld bool // whether the first assignment still holds a reservation
+	}{
+		{name: "cancelled", free: transition(models.AssignmentStatusCancelled)},
+		{name: "completed", free: transition(models.AssignmentStatusActive, models.AssignmentStatusCompleted)},
+		{name: "moved to another window", wantHeld: true, free: func(svc ports.AssignmentService, a models.Assignment) error {
+			a.StartsAt, a.EndsAt = a.StartsAt.Add(2*time.Hour), a.EndsAt.Add(2*time.Hour)
+			_, err := svc.Update(ctx, a)
+			return err
+		}},
+		{name: "moved to another vehicle", wantHeld: true, free: func(svc ports.AssignmentService, a models.Assignment) error {
+			a.VehicleID = "V2"
+			_, err := svc.Update(ctx, a)
+			return err
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMemoryAssignmentRepository()
+			reserver := &fakeVehicleReserver{}
+			svc := service.NewAssignmentService(repo, nil, service.NewAssignmentSagaOrchestrator(repo, repository.NewMemoryAssignmentSagaStore(), reserver, service.AssignmentSagaOptions{}))
+			release := service.ReleaseVehicleOnTerminalStatus(reserver)
+
+			first, err := svc.Save(ctx, slot)
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if _, err := svc.Save(ctx, slot); !errors.Is(err, models.ErrConflict) {
+				t.Fatalf("expected the slot to be taken, got %v", err)
+			}
+
+			if err := tc.free(svc, first); err != nil {
+				t.Fatalf("free the slot: %v", err)
+			}
+			// The relay delivers every status change the repository wrote
+			// to the outbox; only one to a terminal status releases.
+			history, err := svc.History(ctx, first.ID)
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			for _, entry := range history {
+				if entry.Before == nil || entry.Before.Status == entry.After.Status {
+					continue
+				}
+				e, err := models.NewAssignmentStatusChangedEvent(models.AssignmentTransition{AssignmentID: first.ID, From: models.AssignmentStatus(entry.Before.Status), To: models.AssignmentStatus(entry.After.Status)})
+				if err != nil {
+					t.Fatalf("build event: %v", err)
+				}
+				if err := release(ctx, e); err != nil {
+					t.Fatalf("release: %v", err)
+				}
+			}
+			if _, held := reserver.held[first.ID]; held != tc.wantHeld {
+				t.Fatalf("expected held=%v for the first assignment, got %v", tc.wantHeld, held)
+			}
+
+			second, err := svc.Save(ctx, slot)
+			if err != nil {
+				t.Fatalf("expected the slot to be bookable again, got %v", err)
+			}
+			if got := reserver.held[second.ID]; got.VehicleID != "V1" || !got.StartsAt.Equal(start) {
+				t.Fatalf("expected V1 reserved from %s for the second assignment, got %+v", start, got)
+			}
+		})
+	}
+}
+
+func TestAssignmentSagaMoveFailed(t *testing.T) {
+	ctx := requestctx.WithTenant(context.Background(), "acme")
+	start := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	dbDown := models.NewUnavailableError(errors.New("dial tcp"), "save assignment: database unavailable")
+
+	testCases := []struct {
+		name    string
+		saveErr error
+		startAt time.Time // where the update moves the first assignment
+		wantErr error
+	}{
+		{name: "window taken", startAt: start.Add(2 * time.Hour), wantErr: models.ErrConflict},
+		{name: "save failed", saveErr: dbDown, startAt: start.Add(4 * time.Hour), wantErr: models.ErrUnavailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory := repository.NewMemoryAssignmentRepository()
+			reserver := &fakeVehicleReserver{}
+			sagas := &recordingSagaStore{AssignmentSagaStore: repository.NewMemoryAssignmentSagaStore()}
+			svc := service.NewAssignmentService(memory, nil, service.NewAssignmentSagaOrchestrator(memory, sagas, reserver, service.AssignmentSagaOptions{}))
+
+			first, err := svc.Save(ctx, models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: start})
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if _, err := svc.Save(ctx, models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: start.Add(2 * time.Hour)}); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			if tc.saveErr != nil {
+				repo := &failingSaveRepository{AssignmentRepository: memory, err: tc.saveErr}
+				svc = service.NewAssignmentService(repo, nil, service.NewAssignmentSagaOrchestrator(repo, sagas, reserver, service.AssignmentSagaOptions{}))
+			}
+			moved := first
+			moved.StartsAt, moved.EndsAt = tc.startAt, tc.startAt.Add(time.Hour)
+			if _, err := svc.Update(ctx, moved); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+
+			// The stored assignment and its reservation stay where they were.
+			stored, err := memory.FindByID(ctx, first.ID)
+			if err != nil || !stored.StartsAt.Equal(start) {
+				t.Fatalf("expected the assignment to stay at %s, got %+v %v", start, stored, err)
+			}
+			if held := reserver.held[first.ID]; !held.StartsAt.Equal(start) {
+				t.Fatalf("expected the reservation to stay at %s, got %+v", start, held)
+			}
+			saga, err := sagas.FindByID(ctx, sagas.lastID)
+			if err != nil || saga.Kind != models.AssignmentSagaMove || saga.State != models.AssignmentSagaAborted {
+				t.Fatalf("expected the move saga aborted, got %+v %v", saga, err)
+			}
+		})
+	}
+}
+
+func TestAssignmentSagaMoveRecordsSaga(t *testing.T) {
+	ctx := requestctx.WithTenant(context.Background(), "acme")
+	start := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	repo := repository.NewMemoryAssignmentRepository()
+	reserver := &fakeVehicleReserver{}
+	sagas := &recordingSagaStore{AssignmentSagaStore: repository.NewMemoryAssignmentSagaStore()}
+	svc := service.NewAssignmentService(repo, nil, service.NewAssignmentSagaOrchestrator(repo, sagas, reserver, service.AssignmentSagaOptions{}))
+
+	a, err := svc.Save(ctx, models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: start})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	a.VehicleID = "V2"
+	if _, err := svc.Update(ctx, a); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	saga, err := sagas.FindByID(ctx, sagas.lastID)
+	if err != nil {
+		t.Fatalf("find saga: %v", err)
+	}
+	if saga.Kind != models.AssignmentSagaMove || saga.State != models.AssignmentSagaCompleted || saga.Assignment.VehicleID != "V2" {
+		t.Fatalf("expected a completed move to V2, got %+v", saga)
+	}
+	if held := reserver.held[a.ID]; held.VehicleID != "V2" {
+		t.Fatalf("expected V2 reserved, got %+v", held)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// CacheInvalidationProcessor evicts assignments named in AssignmentCreated
+// events from a local cache, so that listings pick up assignments created
+// by other instances before the cache TTL runs out. The tenant comes from
+// the message's TenantProperty.
+type CacheInvalidationProcessor struct {
+	Invalidate func(ctx context.Context, assignmentID string)
+}
+
+func (p CacheInvalidationProcessor) Process(ctx context.Context, msg ports.Message[ports.AssignmentCreated]) error {
+	if tenant := msg.Metadata[ports.TenantProperty]; tenant != "" {
+		ctx = requestctx.WithTenant(ctx, tenant)
+	}
+	p.Invalidate(ctx, msg.Value.AssignmentID)
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// OutboxHandler delivers one outbox event. Returning an error leaves the
+// event pending so the relay retries it later.
+type OutboxHandler func(ctx context.Context, e models.OutboxEvent) error
+
+// OutboxRelayOptions tunes an OutboxRelay; zero values fall back to defaults.
+type OutboxRelayOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+}
+
+const (
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	defaultOutboxMinBackoff   = time.Second
+	defaultOutboxMaxBackoff   = 5 * time.Minute
+)
+
+// OutboxRelay publishes events written to the outbox by the repositories.
+// An event is marked sent only after its handler succeeded, so delivery is
+// at-least-once: a crash between publishing and marking resends the event,
+// and consumers must tolerate duplicates.
+type OutboxRelay struct {
+	store    ports.OutboxStore
+	handlers map[string]OutboxHandler
+	opts     OutboxRelayOptions
+	now      func() time.Time
+}
+
+func NewOutboxRelay(store ports.OutboxStore, handlers map[string]OutboxHandler, opts OutboxRelayOptions) *OutboxRelay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultOutboxPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultOutboxBatchSize
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultOutboxMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultOutboxMaxBackoff
+	}
+	if opts.MinBackoff > opts.MaxBackoff {
+		opts.MinBackoff = opts.MaxBackoff
+	}
+	return &OutboxRelay{store: store, handlers: handlers, opts: opts, now: time.Now}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("outbox relay: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due events and reports how many were sent.
+// A failed event does not stop the batch; it is retried after a backoff that
+// doubles with every attempt, up to MaxBackoff.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.store.FetchPending(ctx, r.now().UTC(), r.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("fetch pending events: %w", err)
+	}
+
+	sent := 0
+	for _, e := range events {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		if err := r.deliver(ctx, e); err != nil {
+			retryAt := r.now().UTC().Add(r.backoff(e.Attempts + 1))
+			log.Printf("outbox relay: event %d (%s) attempt %d failed, retry at %s: %v", e.ID, e.Type, e.Attempts+1, retryAt.Format(time.RFC3339), err)
+			if err := r.store.MarkFailed(ctx, e.ID, retryAt, err.Error()); err != nil {
+				return sent, fmt.Errorf("mark event %d failed: %w", e.ID, err)
+			}
+			continue
+		}
+		if err := r.store.MarkSent(ctx, e.ID, r.now().UTC()); err != nil {
+			return sent, fmt.Errorf("mark event %d sent: %w", e.ID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// deliver runs the handler of e on behalf of e's tenant, so the tenant
+// travels with everything the handler sends or stores.
+func (r *OutboxRelay) deliver(ctx context.Context, e models.OutboxEvent) error {
+	handle, ok := r.handlers[e.Type]
+	if !ok {
+		return fmt.Errorf("no handler for event type %q", e.Type)
+	}
+	return handle(requestctx.WithTenant(ctx, e.TenantID), e)
+}
+
+func (r *OutboxRelay) backoff(attempt int) time.Duration {
+	return exponentialBackoff(r.opts.MinBackoff, r.opts.MaxBackoff, attempt)
+}
+
+// exponentialBackoff returns minDelay * 2^(attempt-1), capped at maxDelay.
+func exponentialBackoff(minDelay, maxDelay time.Duration, attempt int) time.Duration {
+	d := minDelay
+	for i := 1; i < attempt && d < maxDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxDelay)
+}
+
+// ChainOutboxHandlers returns a handler that runs handlers in order and
+// stops at the first error. The relay then retries the whole chain, so
+// every handler must tolerate seeing an event again.
+func ChainOutboxHandlers(handlers ...OutboxHandler) OutboxHandler {
+	return func(ctx context.Context, e models.OutboxEvent) error {
+		for _, handle := range handlers {
+			if err := handle(ctx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// PublishAssignmentCreated returns the handler that forwards
+// EventAssignmentCreated to the assignments topic.
+func PublishAssignmentCreated(producer ports.EventProducer[ports.AssignmentCreated]) OutboxHandler {
+	return func(ctx context.Context, e models.OutboxEvent) error {
+		var p models.AssignmentCreatedPayload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		_, err := producer.Send(ctx, ports.AssignmentCreated{
+			AssignmentID: p.AssignmentID,
+			VehicleID:    p.VehicleID,
+			RouteID:      p.RouteID,
+			Timestamp:    p.OccurredAt.UTC().Format(time.RFC3339),
+		})
+		return err
+	}
+}
+
+// PublishAssignmentStatusChanged returns the handler that forwards
+// EventAssignmentStatusChanged to the assignment status topic.
+func PublishAssignmentStatusChanged(producer ports.EventProducer[ports.AssignmentStatusChanged]) OutboxHandler {
+	return func(ctx context.Context, e models.OutboxEvent) error {
+		var p models.AssignmentStatusChangedPayload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		_, err := producer.Send(ctx, ports.AssignmentStatusChanged{
+			AssignmentID: p.AssignmentID,
+			FromStatus:   string(p.From),
+			ToStatus:     string(p.To),
+			Actor:        p.Actor,
+			Reason:       p.Reason,
+			Timestamp:    p.OccurredAt.UTC().Format(time.RFC3339),
+		})
+		return err
+	}
+}
+
+// ReleaseVehicleOnTerminalStatus returns the handler that releases the
+// vehicle reservation of an assignment once EventAssignmentStatusChanged
+// moves it to a terminal status, whether a client, the status scheduler or
+// a retired recurrence rule moved it. Releasing twice is harmless.
+func ReleaseVehicleOnTerminalStatus(vehicles ports.VehicleReserver) OutboxHandler {
+	return func(ctx context.Context, e models.OutboxEvent) error {
+		var p models.AssignmentStatusChangedPayload
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		if !p.To.IsTerminal() {
+			return nil
+		}
+		return vehicles.Release(ctx, p.AssignmentID)
+	}
+}
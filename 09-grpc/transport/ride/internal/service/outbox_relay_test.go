@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+type fakeOutbox struct {
+	pending []models.OutboxEvent
+	sent    []int64
+	retryAt map[int64]time.Time
+}
+
+func (f *fakeOutbox) FetchPending(ctx context.Context, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var due []models.OutboxEvent
+	for _, e := range f.pending {
+		if at, ok := f.retryAt[e.ID]; ok && at.After(now) {
+			continue
+		}
+		due = append(due, e)
+	}
+	return due, nil
+}
+
+func (f *fakeOutbox) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	f.sent = append(f.sent, id)
+	for i, e := range f.pending {
+		if e.ID == id {
+			f.pending = append(f.pending[:i], f.pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeOutbox) MarkFailed(ctx context.Context, id int64, retryAt time.Time, reason string) error {
+	f.retryAt[id] = retryAt
+	for i := range f.pending {
+		if f.pending[i].ID == id {
+			f.pending[i].Attempts++
+		}
+	}
+	return nil
+}
+
+type fakeProducer struct {
+	fail    bool
+	sent    []ports.AssignmentCreated
+	tenants []string // of the context of every sent message
+}
+
+func (p *fakeProducer) Send(ctx context.Context, v ports.AssignmentCreated) (string, error) {
+	if p.fail {
+		return "", errors.New("broker down")
+	}
+	p.sent = append(p.sent, v)
+	p.tenants = append(p.tenants, requestctx.Tenant(ctx))
+	return "msg-1", nil
+}
+
+func TestOutboxRelay(t *testing.T) {
+	created, err := models.NewAssignmentCreatedEvent(models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1"},
+		time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewAssignmentCreatedEvent: %v", err)
+	}
+	created.ID, created.TenantID = 1, "paris"
+	unknown := models.OutboxEvent{ID: 2, Type: "SomethingElse"}
+
+	store := &fakeOutbox{pending: []models.OutboxEvent{created, unknown}, retryAt: map[int64]time.Time{}}
+	producer := &fakeProducer{fail: true}
+	relay := service.NewOutboxRelay(store, map[string]service.OutboxHandler{
+		models.EventAssignmentCreated: service.PublishAssignmentCreated(producer),
+	}, service.OutboxRelayOptions{MinBackoff: time.Minute, MaxBackoff: 3 * time.Minute})
+
+	ctx := context.Background()
+	sent, err := relay.RelayOnce(ctx)
+	if err != nil || sent != 0 {
+		t.Fatalf("expected nothing sent while broker is down, got sent=%d err=%v", sent, err)
+	}
+	if len(store.retryAt) != 2 {
+		t.Fatalf("expected both events to be deferred, got %v", store.retryAt)
+	}
+
+	// Backed-off events are not retried before their time.
+	producer.fail = false
+	if sent, _ := relay.RelayOnce(ctx); sent != 0 {
+		t.Fatalf("expected no retry before backoff elapsed, sent %d", sent)
+	}
+
+	delete(store.retryAt, 1)
+	sent, err = relay.RelayOnce(ctx)
+	if err != nil || sent != 1 {
+		t.Fatalf("expected 1 event sent, got sent=%d err=%v", sent, err)
+	}
+	if len(producer.sent) != 1 || producer.sent[0].AssignmentID != "A1" || producer.sent[0].Timestamp != "2025-01-02T08:00:00Z" {
+		t.Fatalf("unexpected published events: %+v", producer.sent)
+	}
+	if producer.tenants[0] != "paris" {
+		t.Fatalf("expected the event to be published for its tenant, got %q", producer.tenants[0])
+	}
+	if len(store.pending) != 1 || store.pending[0].ID != 2 {
+		t.Fatalf("expected only the unknown event to stay pending, got %+v", store.pending)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teambition/rrule-go"
+	"github.com/yourname/transport/ride/internal/models"
+)
+
+// MaxOccurrenceDuration bounds RecurringAssignment.Duration; longer
+// bookings are not what recurring rules are for.
+const MaxOccurrenceDuration = 24 * time.Hour
+
+// occurrenceNamespace seeds the name-based UUIDs of materialized occurrences.
+var occurrenceNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("urn:ride:recurring-assignment-occurrence"))
+
+// OccurrenceID is the ID of the assignment materialized for the occurrence
+// of r starting at start. It is derived from the rule, its version and the
+// start, so re-running the materializer finds the assignment it created
+// before instead of creating a duplicate, and an edited rule gets fresh IDs.
+func OccurrenceID(r models.RecurringAssignment, start time.Time) string {
+	name := fmt.Sprintf("%s/%d/%s", r.ID, r.Version, start.UTC().Format(time.RFC3339))
+	return uuid.NewSHA1(occurrenceNamespace, []byte(name)).String()
+}
+
+// Occurrences expands r into the pending assignments that start in
+// [from, to). The rule is evaluated in r.Timezone: every occurrence starts
+// at StartsAt's local wall-clock time, so its UTC offset follows DST. A
+// local time skipped by a DST change moves forward by the gap, and an
+// ambiguous one resolves to its first instant. Duration is elapsed time.
+func Occurrences(r models.RecurringAssignment, from, to time.Time) ([]models.Assignment, error) {
+	rule, err := parseRecurrence(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []models.Assignment
+	for _, start := range rule.Between(from, to, true) {
+		if !start.Before(to) {
+			break
+		}
+		day := models.DateOf(start)
+		if r.EndsOn != nil && day.After(*r.EndsOn) {
+			break
+		}
+		if slices.ContainsFunc(r.Exceptions, day.Equal) {
+			continue
+		}
+		out = append(out, models.Assignment{
+			ID:        OccurrenceID(r, start),
+			VehicleID: r.VehicleID,
+			RouteID:   r.RouteID,
+			StartsAt:  start.UTC(),
+			EndsAt:    start.Add(r.Duration).UTC(),
+			Status:    string(models.AssignmentStatusPending),
+		})
+	}
+	return out, nil
+}
+
+// parseRecurrence builds the rule with DTSTART set to r.StartsAt in
+// r.Timezone; rrule-go then generates every occurrence in that location.
+func parseRecurrence(r models.RecurringAssignment) (*rrule.RRule, error) {
+	// "Local" would make occurrences depend on the server's zone.
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil || r.Timezone == "Local" {
+		return nil, models.NewValidationError("unknown timezone %q", r.Timezone)
+	}
+	opt, err := rrule.StrToROptionInLocation(r.RRule, loc)
+	if err != nil {
+		return nil, models.NewValidationError("invalid rrule: %v", err)
+	}
+	if !opt.Dtstart.IsZero() {
+		return nil, models.NewValidationError("invalid rrule: DTSTART is taken from startsAt")
+	}
+	if opt.Freq == rrule.MINUTELY || opt.Freq == rrule.SECONDLY {
+		return nil, models.NewValidationError("invalid rrule: FREQ must be HOURLY or coarser")
+	}
+	opt.Dtstart = r.StartsAt.In(loc)
+	rule, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, models.NewValidationError("invalid rrule: %v", err)
+	}
+	return rule, nil
+}
+
+// validateRecurringAssignment reports every problem at once, like
+// validateAssignment.
+func validateRecurringAssignment(r models.RecurringAssignment) error {
+	var problems []string
+	if strings.TrimSpace(r.VehicleID) == "" {
+		problems = append(problems, "vehicleId is required")
+	}
+	if strings.TrimSpace(r.RouteID) == "" {
+		problems = append(problems, "routeId is required")
+	}
+	if r.StartsAt.IsZero() {
+		problems = append(problems, "startsAt is required")
+	}
+	if r.Duration <= 0 || r.Duration > MaxOccurrenceDuration {
+		problems = append(problems, "duration must be positive and at most 24 hours")
+	}
+	switch {
+	case strings.TrimSpace(r.Timezone) == "":
+		problems = append(problems, "timezone is required")
+	case strings.TrimSpace(r.RRule) == "":
+		problems = append(problems, "rrule is required")
+	default:
+		if _, err := parseRecurrence(r); err != nil {
+			problems = append(problems, models.ErrorMessage(err, err.Error()))
+		}
+	}
+	if r.EndsOn != nil && !r.StartsAt.IsZero() && r.EndsOn.Before(models.DateOf(r.StartsAt.In(locationOrUTC(r.Timezone)))) {
+		problems = append(problems, "endsOn must not be before the first occurrence")
+	}
+	if len(problems) > 0 {
+		return models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func locationOrUTC(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// normalizeRecurringAssignment stores dates as models.DateOf values and
+// drops duplicate exceptions, so equal rules compare equal.
+func normalizeRecurringAssignment(r models.RecurringAssignment) models.RecurringAssignment {
+	if r.EndsOn != nil {
+		d := models.DateOf(*r.EndsOn)
+		r.EndsOn = &d
+	}
+	exceptions := make([]time.Time, 0, len(r.Exceptions))
+	for _, e := range r.Exceptions {
+		exceptions = append(exceptions, models.DateOf(e))
+	}
+	slices.SortFunc(exceptions, time.Time.Compare)
+	r.Exceptions = slices.CompactFunc(exceptions, time.Time.Equal)
+	r.Duration = r.Duration.Truncate(time.Second)
+	return r
+}
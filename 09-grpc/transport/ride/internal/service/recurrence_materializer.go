@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// RecurrenceMaterializerOptions tunes a RecurrenceMaterializer; zero values
+// fall back to defaults.
+type RecurrenceMaterializerOptions struct {
+	Horizon  time.Duration // how far ahead occurrences are materialized
+	Interval time.Duration // how often the horizon is rolled forward
+	Tenants  []string      // tenants whose rules are materialized; none means the default tenant
+}
+
+const (
+	DefaultRecurrenceHorizon  = 14 * 24 * time.Hour
+	defaultRecurrenceInterval = time.Hour
+
+	// MaterializerActor is recorded as the actor of the changes made by
+	// the background job.
+	MaterializerActor = "recurrence-materializer"
+)
+
+// RecurrenceMaterializer turns recurring assignments into concrete
+// assignments for the occurrences that start within the horizon.
+// Occurrence IDs are deterministic (see OccurrenceID), so a run only creates
+// what earlier runs have not; overlapping or repeated runs never duplicate.
+type RecurrenceMaterializer struct {
+	rules       ports.RecurringAssignmentRepository
+	assignments ports.AssignmentRepository
+	sagas       *AssignmentSagaOrchestrator
+	opts        RecurrenceMaterializerOptions
+	now         func() time.Time
+}
+
+// NewRecurrenceMaterializer reserves the vehicle of every occurrence through
+// sagas before storing it, like AssignmentService.Save; with nil it stores
+// occurrences directly.
+func NewRecurrenceMaterializer(rules ports.RecurringAssignmentRepository, assignments ports.AssignmentRepository, sagas *AssignmentSagaOrchestrator, opts RecurrenceMaterializerOptions) *RecurrenceMaterializer {
+	if opts.Horizon <= 0 {
+		opts.Horizon = DefaultRecurrenceHorizon
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultRecurrenceInterval
+	}
+	if len(opts.Tenants) == 0 {
+		opts.Tenants = []string{requestctx.DefaultTenant}
+	}
+	return &RecurrenceMaterializer{rules: rules, assignments: assignments, sagas: sagas, opts: opts, now: time.Now}
+}
+
+// Run materializes every rule once per interval until ctx is cancelled.
+func (m *RecurrenceMaterializer) Run(ctx context.Context) {
+	ctx = requestctx.WithActor(ctx, MaterializerActor)
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if n, err := m.MaterializeAll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("recurrence materializer: %v", err)
+		} else if n > 0 {
+			log.Printf("recurrence materializer: created %d assignments", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MaterializeAll materializes the rules of every tenant and reports how
+// many assignments were created. A failing rule or tenant does not stop the
+// others.
+func (m *RecurrenceMaterializer) MaterializeAll(ctx context.Context) (int, error) {
+	created := 0
+	var errs []error
+	for _, tenant := range m.opts.Tenants {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		n, err := m.materializeTenant(requestctx.WithTenant(ctx, tenant))
+		created += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// materializeTenant is MaterializeAll for the tenant in ctx.
+func (m *RecurrenceMaterializer) materializeTenant(ctx context.Context) (int, error) {
+	rules, err := m.rules.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list recurring assignments: %w", err)
+	}
+
+	created := 0
+	var errs []error
+	for _, r := range rules {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		n, err := m.Materialize(ctx, r)
+		created += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurring assignment %s: %w", r.ID, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// Materialize creates the missing assignments for the occurrences of r that
+// start within the horizon. An occurrence that overlaps another booking of
+// the vehicle, or whose vehicle the vehicle service refuses to reserve, is
+// skipped and retried on the next run, so it is created once the clashing
+// assignment is moved or cancelled.
+func (m *RecurrenceMaterializer) Materialize(ctx context.Context, r models.RecurringAssignment) (int, error) {
+	now := m.now().UTC()
+	occurrences, err := Occurrences(r, now, now.Add(m.opts.Horizon))
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, a := range occurrences {
+		_, err := m.assignments.FindByID(ctx, a.ID)
+		if err == nil {
+			continue // materialized by an earlier run
+		}
+		if !errors.Is(err, models.ErrNotFound) {
+			return created, err
+		}
+		if err := m.create(ctx, a); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				log.Printf("recurrence materializer: skipping %s of %s: %s", a.StartsAt.Format(time.RFC3339), r.ID, models.ErrorMessage(err, err.Error()))
+				continue
+			}
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// create stores the occurrence a, reserving its vehicle first when sagas
+// are set.
+func (m *RecurrenceMaterializer) create(ctx context.Context, a models.Assignment) error {
+	if m.sagas != nil {
+		return m.sagas.Create(ctx, a)
+	}
+	_, err := m.assignments.Save(ctx, a)
+	return err
+}
+
+// Retire cancels the pending assignments materialized for r that have not
+// started yet. Assignments that are already active, finished or changed
+// concurrently are left alone.
+func (m *RecurrenceMaterializer) Retire(ctx context.Context, r models.RecurringAssignment, reason string) (int, error) {
+	now := m.now().UTC()
+	occurrences, err := Occurrences(r, now, now.Add(m.opts.Horizon))
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, o := range occurrences {
+		a, err := m.assignments.FindByID(ctx, o.ID)
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return cancelled, err
+		}
+		if models.AssignmentStatus(a.Status) != models.AssignmentStatusPending {
+			continue
+		}
+		err = m.assignments.UpdateStatus(ctx, models.AssignmentTransition{
+			AssignmentID: a.ID,
+			From:         models.AssignmentStatusPending,
+			To:           models.AssignmentStatusCancelled,
+			Actor:        requestctx.Actor(ctx),
+			Reason:       reason,
+			ChangedAt:    now,
+			Version:      a.Version,
+		})
+		if errors.Is(err, models.ErrConflict) || errors.Is(err, models.ErrPreconditionFailed) {
+			continue
+		}
+		if err != nil {
+			return cancelled, err
+		}
+		cancelled++
+	}
+	return cancelled, nil
+}
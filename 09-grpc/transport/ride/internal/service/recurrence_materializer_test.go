@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+// countByStatus lists every assignment of vehicleID by status.
+func countByStatus(t *testing.T, repo ports.AssignmentRepository, vehicleID string) map[models.AssignmentStatus]int {
+	t.Helper()
+	page, err := repo.FindAll(context.Background(), models.AssignmentQuery{VehicleID: &vehicleID})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	counts := map[models.AssignmentStatus]int{}
+	for _, a := range page.Items {
+		counts[models.AssignmentStatus(a.Status)]++
+	}
+	return counts
+}
+
+func TestRecurrenceMaterializer(t *testing.T) {
+	ctx := context.Background()
+	assignments := repository.NewMemoryAssignmentRepository()
+	rules := repository.NewMemoryRecurringAssignmentRepository()
+	// Occurrences at +1h, +25h and +49h fall within the horizon; +73h does not.
+	materializer := service.NewRecurrenceMaterializer(rules, assignments, nil, service.RecurrenceMaterializerOptions{Horizon: 72 * time.Hour})
+	svc := service.NewRecurringAssignmentService(rules, materializer)
+	start := time.Now().UTC().Truncate(time.Minute).Add(time.Hour)
+
+	rule, err := svc.Save(ctx, models.RecurringAssignment{
+		VehicleID: "V1", RouteID: "R1", RRule: "FREQ=DAILY", Timezone: "UTC", StartsAt: start, Duration: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got := countByStatus(t, assignments, "V1"); got[models.AssignmentStatusPending] != 3 {
+		t.Fatalf("expected 3 pending occurrences after create, got %v", got)
+	}
+
+	t.Run("re-runs create nothing", func(t *testing.T) {
+		n, err := materializer.MaterializeAll(ctx)
+		if err != nil || n != 0 {
+			t.Fatalf("expected 0 created, got %d (err %v)", n, err)
+		}
+		if got := countByStatus(t, assignments, "V1"); got[models.AssignmentStatusPending] != 3 {
+			t.Fatalf("expected still 3 pending occurrences, got %v", got)
+		}
+	})
+
+	t.Run("update replaces pending occurrences", func(t *testing.T) {
+		rule.StartsAt = start.Add(2 * time.Hour)
+		if _, err := svc.Update(ctx, rule); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got := countByStatus(t, assignments, "V1")
+		if got[models.AssignmentStatusPending] != 3 || got[models.AssignmentStatusCancelled] != 3 {
+			t.Fatalf("expected 3 pending and 3 cancelled, got %v", got)
+		}
+	})
+
+	t.Run("delete cancels pending occurrences", func(t *testing.T) {
+		if err := svc.Delete(ctx, rule.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		got := countByStatus(t, assignments, "V1")
+		if got[models.AssignmentStatusPending] != 0 || got[models.AssignmentStatusCancelled] != 6 {
+			t.Fatalf("expected 6 cancelled, got %v", got)
+		}
+	})
+}
+
+func TestRecurrenceMaterializerRetriesClashes(t *testing.T) {
+	ctx := context.Background()
+	assignments := repository.NewMemoryAssignmentRepository()
+	rules := repository.NewMemoryRecurringAssignmentRepository()
+	materializer := service.NewRecurrenceMaterializer(rules, assignments, nil, service.RecurrenceMaterializerOptions{Horizon: 72 * time.Hour})
+	start := time.Now().UTC().Truncate(time.Minute).Add(time.Hour)
+
+	blocker := models.Assignment{ID: "manual", VehicleID: "V2", RouteID: "R9", StartsAt: start.Add(24 * time.Hour), EndsAt: start.Add(26 * time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := assignments.Save(ctx, blocker); err != nil {
+		t.Fatalf("Save blocker: %v", err)
+	}
+	if _, err := rules.Save(ctx, models.RecurringAssignment{
+		ID: "RA2", VehicleID: "V2", RouteID: "R1", RRule: "FREQ=DAILY", Timezone: "UTC", StartsAt: start, Duration: time.Hour,
+	}); err != nil {
+		t.Fatalf("Save rule: %v", err)
+	}
+
+	if n, err := materializer.MaterializeAll(ctx); err != nil || n != 2 {
+		t.Fatalf("expected the clashing occurrence to be skipped, created %d (err %v)", n, err)
+	}
+
+	err := assignments.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: blocker.ID, From: models.AssignmentStatusPending, To: models.AssignmentStatusCancelled, ChangedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if n, err := materializer.MaterializeAll(ctx); err != nil || n != 1 {
+		t.Fatalf("expected the freed occurrence to be created, created %d (err %v)", n, err)
+	}
+}
+
+func TestRecurrenceMaterializerReservesVehicles(t *testing.T) {
+	ctx := context.Background()
+	assignments := repository.NewMemoryAssignmentRepository()
+	rules := repository.NewMemoryRecurringAssignmentRepository()
+	start := time.Now().UTC().Truncate(time.Minute).Add(time.Hour)
+	// The vehicle service holds V1 for another system during the second
+	// occurrence.
+	reserver := &fakeVehicleReserver{held: map[string]models.Assignment{
+		"other": {VehicleID: "V1", StartsAt: start.Add(24 * time.Hour), EndsAt: start.Add(25 * time.Hour)},
+	}}
+	sagas := service.NewAssignmentSagaOrchestrator(assignments, repository.NewMemoryAssignmentSagaStore(), reserver, service.AssignmentSagaOptions{})
+	materializer := service.NewRecurrenceMaterializer(rules, assignments, sagas, service.RecurrenceMaterializerOptions{Horizon: 72 * time.Hour})
+	svc := service.NewRecurringAssignmentService(rules, materializer)
+
+	if _, err := svc.Save(ctx, models.RecurringAssignment{
+		VehicleID: "V1", RouteID: "R1", RRule: "FREQ=DAILY", Timezone: "UTC", StartsAt: start, Duration: time.Hour,
+	}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got := countByStatus(t, assignments, "V1"); got[models.AssignmentStatusPending] != 2 || len(reserver.held) != 3 {
+		t.Fatalf("expected 2 reserved occurrences, got %v and reservations %v", got, reserver.held)
+	}
+
+	// Once the vehicle is free, the next run reserves the skipped one.
+	delete(reserver.held, "other")
+	if n, err := materializer.MaterializeAll(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 created, got %d (err %v)", n, err)
+	}
+	page, err := assignments.FindAll(ctx, models.AssignmentQuery{})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	for _, a := range page.Items {
+		if _, held := reserver.held[a.ID]; !held {
+			t.Fatalf("expected occurrence %s reserved, got %v", a.ID, reserver.held)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	endsOn := date(2025, 4, 2)
+
+	testCases := []struct {
+		name string
+		rule models.RecurringAssignment
+		from time.Time
+		to   time.Time
+		want []time.Time // occurrence starts
+	}{
+		{
+			name: "weekdays keep local time across spring DST change",
+			rule: models.RecurringAssignment{
+				RRule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+				Timezone: "Europe/Berlin",
+				StartsAt: time.Date(2025, 3, 27, 7, 30, 0, 0, berlin),
+			},
+			from: date(2025, 3, 27),
+			to:   date(2025, 4, 1),
+			want: []time.Time{
+				time.Date(2025, 3, 27, 6, 30, 0, 0, time.UTC), // CET, UTC+1
+				time.Date(2025, 3, 28, 6, 30, 0, 0, time.UTC),
+				time.Date(2025, 3, 31, 5, 30, 0, 0, time.UTC), // CEST, UTC+2
+			},
+		},
+		{
+			name: "daily keeps local time across autumn DST change",
+			rule: models.RecurringAssignment{
+				RRule:    "FREQ=DAILY",
+				Timezone: "America/New_York",
+				StartsAt: time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC), // 08:00 EDT
+			},
+			from: date(2025, 11, 1),
+			to:   date(2025, 11, 3),
+			want: []time.Time{
+				time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2025, 11, 2, 13, 0, 0, 0, time.UTC), // 08:00 EST
+			},
+		},
+		{
+			name: "exceptions and end date are local dates",
+			rule: models.RecurringAssignment{
+				RRule:      "FREQ=DAILY",
+				Timezone:   "Europe/Berlin",
+				StartsAt:   time.Date(2025, 3, 30, 23, 30, 0, 0, time.UTC), // 01:30 local on Mar 31
+				Exceptions: []time.Time{date(2025, 4, 1)},
+				EndsOn:     &endsOn,
+			},
+			from: date(2025, 3, 30),
+			to:   date(2025, 4, 10),
+			want: []time.Time{
+				time.Date(2025, 3, 30, 23, 30, 0, 0, time.UTC),
+				time.Date(2025, 4, 1, 23, 30, 0, 0, time.UTC), // Apr 2 local
+			},
+		},
+		{
+			name: "window excludes its end",
+			rule: models.RecurringAssignment{
+				RRule:    "FREQ=DAILY;COUNT=5",
+				Timezone: "UTC",
+				StartsAt: time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC),
+			},
+			from: time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+			to:   time.Date(2025, 1, 4, 8, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 3, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.rule.ID, tc.rule.VehicleID, tc.rule.RouteID, tc.rule.Duration = "RA1", "V1", "R1", time.Hour
+			got, err := service.Occurrences(tc.rule, tc.from, tc.to)
+			if err != nil {
+				t.Fatalf("Occurrences: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d occurrences, got %d: %v", len(tc.want), len(got), got)
+			}
+			for i, a := range got {
+				if !a.StartsAt.Equal(tc.want[i]) {
+					t.Errorf("occurrence %d: expected start %s, got %s", i, tc.want[i], a.StartsAt)
+				}
+				if !a.EndsAt.Equal(a.StartsAt.Add(time.Hour)) {
+					t.Errorf("occurrence %d: expected one hour window, got %s-%s", i, a.StartsAt, a.EndsAt)
+				}
+				if a.ID != service.OccurrenceID(tc.rule, a.StartsAt) {
+					t.Errorf("occurrence %d: unexpected id %s", i, a.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurringAssignmentServiceValidation(t *testing.T) {
+	rules := repository.NewMemoryRecurringAssignmentRepository()
+	svc := service.NewRecurringAssignmentService(rules, service.NewRecurrenceMaterializer(rules, repository.NewMemoryAssignmentRepository(), nil, service.RecurrenceMaterializerOptions{}))
+	valid := models.RecurringAssignment{
+		VehicleID: "V1",
+		RouteID:   "R1",
+		RRule:     "FREQ=DAILY",
+		Timezone:  "Europe/Berlin",
+		StartsAt:  time.Date(2031, 1, 6, 7, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	}
+
+	testCases := []struct {
+		name      string
+		mutate    func(r *models.RecurringAssignment)
+		wantInMsg string
+	}{
+		{name: "unknown timezone", mutate: func(r *models.RecurringAssignment) { r.Timezone = "Mars/Olympus" }, wantInMsg: "unknown timezone"},
+		{name: "malformed rrule", mutate: func(r *models.RecurringAssignment) { r.RRule = "FREQ=SOMETIMES" }, wantInMsg: "invalid rrule"},
+		{name: "dtstart in rrule", mutate: func(r *models.RecurringAssignment) { r.RRule = "DTSTART:20310106T070000Z\nRRULE:FREQ=DAILY" }, wantInMsg: "DTSTART"},
+		{name: "too frequent", mutate: func(r *models.RecurringAssignment) { r.RRule = "FREQ=MINUTELY" }, wantInMsg: "HOURLY or coarser"},
+		{name: "no duration", mutate: func(r *models.RecurringAssignment) { r.Duration = 0 }, wantInMsg: "duration"},
+		{name: "ends before it starts", mutate: func(r *models.RecurringAssignment) { d := date(2031, 1, 5); r.EndsOn = &d }, wantInMsg: "endsOn"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := valid
+			tc.mutate(&r)
+			_, err := svc.Save(context.Background(), r)
+			if !errors.Is(err, models.ErrValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if msg := models.ErrorMessage(err, ""); !strings.Contains(msg, tc.wantInMsg) {
+				t.Fatalf("expected message to contain %q, got %q", tc.wantInMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+type recurringAssignmentService struct {
+	repo         ports.RecurringAssignmentRepository
+	materializer *RecurrenceMaterializer
+}
+
+// NewRecurringAssignmentService manages recurring assignment rules. Writes
+// materialize the rule right away instead of waiting for the next run of
+// the materializer job.
+func NewRecurringAssignmentService(repo ports.RecurringAssignmentRepository, materializer *RecurrenceMaterializer) ports.RecurringAssignmentService {
+	return &recurringAssignmentService{repo: repo, materializer: materializer}
+}
+
+func (s *recurringAssignmentService) Save(ctx context.Context, r models.RecurringAssignment) (models.RecurringAssignment, error) {
+	r = normalizeRecurringAssignment(r)
+	if err := validateRecurringAssignment(r); err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	r.ID = uuid.NewString()
+	r.Version = 0
+	if _, err := s.repo.Save(ctx, r); err != nil {
+		return models.RecurringAssignment{}, err
+	}
+
+	saved, err := s.repo.FindByID(ctx, r.ID)
+	if err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	s.materialize(ctx, saved)
+	return saved, nil
+}
+
+func (s *recurringAssignmentService) GetByID(ctx context.Context, id string) (models.RecurringAssignment, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *recurringAssignmentService) List(ctx context.Context) ([]models.RecurringAssignment, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *recurringAssignmentService) Update(ctx context.Context, r models.RecurringAssignment) (models.RecurringAssignment, error) {
+	r = normalizeRecurringAssignment(r)
+	if err := validateRecurringAssignment(r); err != nil {
+		return models.RecurringAssignment{}, err
+	}
+
+	current, err := s.repo.FindByID(ctx, r.ID)
+	if err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	// Writing at the version just read guarantees that the occurrences
+	// retired below are the ones of the rule this update replaced.
+	r.Version = current.Version
+	if _, err := s.repo.Save(ctx, r); err != nil {
+		if errors.Is(err, models.ErrPreconditionFailed) {
+			return models.RecurringAssignment{}, models.NewConflictError("recurring assignment %s was modified concurrently, retry", r.ID)
+		}
+		return models.RecurringAssignment{}, err
+	}
+
+	updated, err := s.repo.FindByID(ctx, r.ID)
+	if err != nil {
+		return models.RecurringAssignment{}, err
+	}
+	// The new version has new occurrence IDs; retire the old ones first so
+	// they do not clash with their replacements.
+	s.retire(ctx, current, "recurring assignment "+r.ID+" was changed")
+	s.materialize(ctx, updated)
+	return updated, nil
+}
+
+func (s *recurringAssignmentService) Delete(ctx context.Context, id string) error {
+	current, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id, current.Version); err != nil {
+		if errors.Is(err, models.ErrPreconditionFailed) {
+			return models.NewConflictError("recurring assignment %s was modified concurrently, retry", id)
+		}
+		return err
+	}
+	s.retire(ctx, current, "recurring assignment "+id+" was deleted")
+	return nil
+}
+
+// materialize and retire only log failures: the rule itself is saved, and
+// the materializer job catches up with missing occurrences on its next run.
+func (s *recurringAssignmentService) materialize(ctx context.Context, r models.RecurringAssignment) {
+	if _, err := s.materializer.Materialize(ctx, r); err != nil {
+		log.Printf("materialize recurring assignment %s: %v", r.ID, err)
+	}
+}
+
+func (s *recurringAssignmentService) retire(ctx context.Context, r models.RecurringAssignment, reason string) {
+	if _, err := s.materializer.Retire(ctx, r, reason); err != nil {
+		log.Printf("retire occurrences of recurring assignment %s: %v", r.ID, err)
+	}
+}
@@ -3,28 +3,46 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/yourname/transport/ride/internal/models"
 	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
 )
 
 type assignmentService struct {
 	// Could depend on repository ports
 	assignmentRepo ports.AssignmentRepository
+	vehicles       ports.VehicleFinder
+	sagas          *AssignmentSagaOrchestrator
 }
 
-func NewAssignmentService(repo ports.AssignmentRepository) ports.AssignmentService {
-	return &assignmentService{assignmentRepo: repo}
+// NewAssignmentService lets Save pick the vehicle of an assignment created
+// without one through vehicles; with a nil finder vehicleId stays required.
+// With sagas, Save reserves the vehicle of every new assignment in the
+// vehicle service before storing it, and Update moves the reservation along
+// with the vehicle or window; with nil both store directly.
+func NewAssignmentService(repo ports.AssignmentRepository, vehicles ports.VehicleFinder, sagas *AssignmentSagaOrchestrator) ports.AssignmentService {
+	return &assignmentService{assignmentRepo: repo, vehicles: vehicles, sagas: sagas}
 }
 
 func (s *assignmentService) Save(ctx context.Context, a models.Assignment) (models.Assignment, error) {
-	if a.VehicleID == "" {
-		return models.Assignment{}, errors.New("vehicle ID required")
+	a, err := s.withChosenVehicle(ctx, a)
+	if err != nil {
+		return models.Assignment{}, err
 	}
-	_, err := s.assignmentRepo.Save(ctx, a)
+	a, err = newPendingAssignment(a)
 	if err != nil {
 		return models.Assignment{}, err
 	}
+	// The repository rejects a window that overlaps another assignment of
+	// the vehicle; it checks under a per-vehicle lock so concurrent creates
+	// cannot both pass.
+	if err := s.create(ctx, a); err != nil {
+		return models.Assignment{}, err
+	}
 
 	la, err := s.assignmentRepo.FindByID(ctx, a.ID)
 	if err != nil {
@@ -34,10 +52,210 @@ func (s *assignmentService) Save(ctx context.Context, a models.Assignment) (mode
 	return la, nil
 }
 
+// create stores the new assignment a, reserving its vehicle first when
+// sagas are set.
+func (s *assignmentService) create(ctx context.Context, a models.Assignment) error {
+	if s.sagas != nil {
+		return s.sagas.Create(ctx, a)
+	}
+	_, err := s.assignmentRepo.Save(ctx, a)
+	return err
+}
+
+// withChosenVehicle asks the vehicle service for a vehicle on the route,
+// free for the window of a, when a has none. Assignments that name a
+// vehicle, or lack a route or start to choose for, are left to validation.
+// A chosen vehicle is marked VehicleAutoSelected, which is stored with the
+// assignment and its audit entries.
+func (s *assignmentService) withChosenVehicle(ctx context.Context, a models.Assignment) (models.Assignment, error) {
+	a.VehicleAutoSelected = false
+	if s.vehicles == nil || strings.TrimSpace(a.VehicleID) != "" || strings.TrimSpace(a.RouteID) == "" || a.StartsAt.IsZero() {
+		return a, nil
+	}
+	window := withDefaultEndsAt(a)
+	vehicleID, err := s.vehicles.FindAvailableVehicle(ctx, a.RouteID, window.StartsAt, window.EndsAt)
+	if err != nil {
+		return models.Assignment{}, err
+	}
+	a.VehicleID = vehicleID
+	a.VehicleAutoSelected = true
+	return a, nil
+}
+
+func (s *assignmentService) Update(ctx context.Context, a models.Assignment) (models.Assignment, error) {
+	a = withDefaultEndsAt(a)
+	if err := validateAssignment(a); err != nil {
+		return models.Assignment{}, err
+	}
+
+	current, err := s.assignmentRepo.FindByID(ctx, a.ID)
+	if err != nil {
+		return models.Assignment{}, err
+	}
+	if a.Version > 0 && a.Version != current.Version {
+		return models.Assignment{}, models.NewPreconditionFailedError("assignment %s is at version %d, not %d", a.ID, current.Version, a.Version)
+	}
+	if models.AssignmentStatus(current.Status).IsTerminal() {
+		return models.Assignment{}, models.NewConflictError("assignment %s is %s and can no longer be changed", a.ID, current.Status)
+	}
+
+	// Always write against the version just read, so that the terminal-status
+	// check above cannot be raced even when the caller sent no If-Match.
+	conditional := a.Version > 0
+	a.Version = current.Version
+	a.Status = current.Status
+	// The vehicle stays marked as chosen by the vehicle service until the
+	// client picks another one.
+	a.VehicleAutoSelected = current.VehicleAutoSelected && a.VehicleID == current.VehicleID
+	if s.sagas != nil && movesVehicleHold(current, a) {
+		err = s.sagas.Move(ctx, a)
+	} else {
+		_, err = s.assignmentRepo.Save(ctx, a)
+	}
+	if err != nil {
+		if !conditional && errors.Is(err, models.ErrPreconditionFailed) {
+			return models.Assignment{}, models.NewConflictError("assignment %s was modified concurrently, retry", a.ID)
+		}
+		return models.Assignment{}, err
+	}
+
+	return s.assignmentRepo.FindByID(ctx, a.ID)
+}
+
+// movesVehicleHold reports whether updating current to a changes what its
+// vehicle reservation holds.
+func movesVehicleHold(current, a models.Assignment) bool {
+	return a.VehicleID != current.VehicleID || !a.StartsAt.Equal(current.StartsAt) || !a.EndsAt.Equal(current.EndsAt)
+}
+
 func (s *assignmentService) GetByID(ctx context.Context, id string) (models.Assignment, error) {
 	return s.assignmentRepo.FindByID(ctx, id)
 }
 
-func (s *assignmentService) List(ctx context.Context, status *string) ([]models.Assignment, error) {
-	return s.assignmentRepo.FindAll(ctx, status)
+// Page size bounds for List; they keep a single request from scanning the
+// whole table.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+func (s *assignmentService) List(ctx context.Context, q models.AssignmentQuery) (models.AssignmentPage, error) {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return models.AssignmentPage{}, models.NewValidationError("limit must be between 1 and %d", MaxPageSize)
+	}
+	q, err := checkQuery(q)
+	if err != nil {
+		return models.AssignmentPage{}, err
+	}
+	return s.assignmentRepo.FindAll(ctx, q)
+}
+
+// checkQuery validates the filters and sort order of q, defaulting the order.
+func checkQuery(q models.AssignmentQuery) (models.AssignmentQuery, error) {
+	switch q.Order {
+	case "":
+		q.Order = models.SortAscending
+	case models.SortAscending, models.SortDescending:
+	default:
+		return models.AssignmentQuery{}, models.NewValidationError("unknown sort order %q", q.Order)
+	}
+	if q.StartsFrom != nil && q.StartsTo != nil && !q.StartsFrom.Before(*q.StartsTo) {
+		return models.AssignmentQuery{}, models.NewValidationError("startsFrom must be before startsTo")
+	}
+	return q, nil
+}
+
+func (s *assignmentService) Transition(ctx context.Context, id string, to models.AssignmentStatus, reason string, version int64) (models.Assignment, error) {
+	if !to.IsValid() {
+		return models.Assignment{}, models.NewValidationError("unknown status %q", to)
+	}
+
+	current, err := s.assignmentRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Assignment{}, err
+	}
+
+	if version > 0 && version != current.Version {
+		return models.Assignment{}, models.NewPreconditionFailedError("assignment %s is at version %d, not %d", id, current.Version, version)
+	}
+
+	from := models.AssignmentStatus(current.Status)
+	if !from.CanTransitionTo(to) {
+		return models.Assignment{}, models.NewConflictError("assignment %s cannot move from %s to %s", id, from, to)
+	}
+
+	err = s.assignmentRepo.UpdateStatus(ctx, models.AssignmentTransition{
+		AssignmentID: id,
+		From:         from,
+		To:           to,
+		Actor:        requestctx.Actor(ctx),
+		Reason:       reason,
+		ChangedAt:    time.Now().UTC(),
+		Version:      version,
+	})
+	if err != nil {
+		return models.Assignment{}, err
+	}
+
+	return s.assignmentRepo.FindByID(ctx, id)
+}
+
+func (s *assignmentService) History(ctx context.Context, id string) ([]models.AuditEntry, error) {
+	// An unknown assignment has an empty history; report it as missing instead.
+	if _, err := s.assignmentRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.History(ctx, id)
+}
+
+// DefaultAssignmentDuration is the length of an assignment created without
+// an end time.
+const DefaultAssignmentDuration = time.Hour
+
+// newPendingAssignment validates a new assignment and fills in what the
+// server decides. IDs are generated server-side and new assignments always
+// start pending. The repository ignores status on updates, so this cannot
+// rewind an existing assignment; status only changes through Transition.
+func newPendingAssignment(a models.Assignment) (models.Assignment, error) {
+	a = withDefaultEndsAt(a)
+	if err := validateAssignment(a); err != nil {
+		return models.Assignment{}, err
+	}
+	if a.ID == "" {
+		a.ID = uuid.NewString()
+	}
+	a.Status = string(models.AssignmentStatusPending)
+	a.Version = 0
+	return a, nil
+}
+
+func withDefaultEndsAt(a models.Assignment) models.Assignment {
+	if a.EndsAt.IsZero() && !a.StartsAt.IsZero() {
+		a.EndsAt = a.StartsAt.Add(DefaultAssignmentDuration)
+	}
+	return a
+}
+
+// validateAssignment reports every missing field at once so clients can fix
+// a request in a single round trip.
+func validateAssignment(a models.Assignment) error {
+	var problems []string
+	if strings.TrimSpace(a.VehicleID) == "" {
+		problems = append(problems, "vehicleId is required")
+	}
+	if strings.TrimSpace(a.RouteID) == "" {
+		problems = append(problems, "routeId is required")
+	}
+	if a.StartsAt.IsZero() {
+		problems = append(problems, "startsAt is required")
+	} else if !a.EndsAt.After(a.StartsAt) {
+		problems = append(problems, "endsAt must be after startsAt")
+	}
+	if len(problems) > 0 {
+		return models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
+	return nil
 }
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+func TestAssignmentServiceSaveSchedule(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewAssignmentService(repository.NewMemoryAssignmentRepository(), nil, nil)
+	base := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+
+	first, err := svc.Save(ctx, models.Assignment{VehicleID: "V1", RouteID: "R1", StartsAt: base})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if want := base.Add(service.DefaultAssignmentDuration); !first.EndsAt.Equal(want) {
+		t.Fatalf("expected default end %s, got %s", want, first.EndsAt)
+	}
+
+	testCases := []struct {
+		name      string
+		in        models.Assignment
+		wantErr   error
+		wantInMsg string
+	}{
+		{
+			name:      "overlaps the default window",
+			in:        models.Assignment{VehicleID: "V1", RouteID: "R2", StartsAt: base.Add(30 * time.Minute)},
+			wantErr:   models.ErrConflict,
+			wantInMsg: first.ID,
+		},
+		{
+			name: "starts when the other ends",
+			in:   models.Assignment{VehicleID: "V1", RouteID: "R2", StartsAt: base.Add(time.Hour), EndsAt: base.Add(2 * time.Hour)},
+		},
+		{
+			name: "same window, other vehicle",
+			in:   models.Assignment{VehicleID: "V2", RouteID: "R1", StartsAt: base},
+		},
+		{
+			name:      "ends before it starts",
+			in:        models.Assignment{VehicleID: "V3", RouteID: "R1", StartsAt: base, EndsAt: base.Add(-time.Minute)},
+			wantErr:   models.ErrValidation,
+			wantInMsg: "endsAt must be after startsAt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Save(ctx, tc.in)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if msg := models.ErrorMessage(err, ""); !strings.Contains(msg, tc.wantInMsg) {
+				t.Fatalf("expected message to contain %q, got %q", tc.wantInMsg, msg)
+			}
+		})
+	}
+}
+
+// fakeVehicleFinder answers from a fixed map of route to vehicle, or fails
+// with err when it is set. It keeps the window of the last lookup.
+type fakeVehicleFinder struct {
+	vehicles         map[string]string
+	err              error
+	calls            int
+	startsAt, endsAt time.Time
+}
+
+func (f *fakeVehicleFinder) FindAvailableVehicle(ctx context.Context, routeID string, startsAt, endsAt time.Time) (string, error) {
+	f.calls++
+	f.startsAt, f.endsAt = startsAt, endsAt
+	if f.err != nil {
+		return "", f.err
+	}
+	vehicleID, ok := f.vehicles[routeID]
+	if !ok {
+		return "", models.NewConflictError("no vehicle is available on route %s", routeID)
+	}
+	return vehicleID, nil
+}
+
+func TestAssignmentServiceSaveChoosesVehicle(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	breakerOpen := models.NewUnavailableError(errors.New("circuit breaker is open"), "vehicle service is failing")
+
+	testCases := []struct {
+		name        string
+		in          models.Assignment
+		finderErr   error
+		wantVehicle string
+		wantAuto    bool
+		wantErr     error
+		wantCalls   int
+	}{
+		{name: "chosen for the route", in: models.Assignment{RouteID: "R1", StartsAt: base}, wantVehicle: "bus-7", wantAuto: true, wantCalls: 1},
+		{name: "chosen for a long window", in: models.Assignment{RouteID: "R1", StartsAt: base, EndsAt: base.Add(5 * time.Hour)}, wantVehicle: "bus-7", wantAuto: true, wantCalls: 1},
+		{name: "named by the client", in: models.Assignment{VehicleID: "V9", RouteID: "R1", StartsAt: base}, wantVehicle: "V9"},
+		{name: "client cannot claim auto selection", in: models.Assignment{VehicleID: "V9", VehicleAutoSelected: true, RouteID: "R1", StartsAt: base}, wantVehicle: "V9"},
+		{name: "none available", in: models.Assignment{RouteID: "R2", StartsAt: base}, wantErr: models.ErrConflict, wantCalls: 1},
+		{name: "breaker open", in: models.Assignment{RouteID: "R1", StartsAt: base}, finderErr: breakerOpen, wantErr: models.ErrUnavailable, wantCalls: 1},
+		{name: "no route to choose for", in: models.Assignment{StartsAt: base}, wantErr: models.ErrValidation},
+		{name: "no start to choose for", in: models.Assignment{RouteID: "R1"}, wantErr: models.ErrValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			finder := &fakeVehicleFinder{vehicles: map[string]string{"R1": "bus-7"}, err: tc.finderErr}
+			svc := service.NewAssignmentService(repository.NewMemoryAssignmentRepository(), finder, nil)
+
+			saved, err := svc.Save(ctx, tc.in)
+			if finder.calls != tc.wantCalls {
+				t.Fatalf("expected %d lookups, got %d", tc.wantCalls, finder.calls)
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if saved.VehicleID != tc.wantVehicle || saved.VehicleAutoSelected != tc.wantAuto {
+				t.Fatalf("expected vehicle %s (auto-selected %t), got %s (%t)", tc.wantVehicle, tc.wantAuto, saved.VehicleID, saved.VehicleAutoSelected)
+			}
+			if tc.wantAuto && (!finder.startsAt.Equal(saved.StartsAt) || !finder.endsAt.Equal(saved.EndsAt)) {
+				t.Fatalf("expected the vehicle looked up for %v-%v, got %v-%v", saved.StartsAt, saved.EndsAt, finder.startsAt, finder.endsAt)
+			}
+			history, err := svc.History(ctx, saved.ID)
+			if err != nil || len(history) != 1 || history[0].After.VehicleID != tc.wantVehicle || history[0].After.VehicleAutoSelected != tc.wantAuto {
+				t.Fatalf("expected the creation on %s (auto-selected %t) in the audit trail, got %v %v", tc.wantVehicle, tc.wantAuto, history, err)
+			}
+			if !tc.wantAuto {
+				return
+			}
+
+			// The mark survives updates that keep the vehicle and goes once
+			// the client picks another one.
+			saved.EndsAt = saved.EndsAt.Add(time.Hour)
+			kept, err := svc.Update(ctx, saved)
+			if err != nil || !kept.VehicleAutoSelected {
+				t.Fatalf("expected the vehicle to stay auto-selected, got %+v %v", kept, err)
+			}
+			kept.VehicleID = "V9"
+			moved, err := svc.Update(ctx, kept)
+			if err != nil || moved.VehicleAutoSelected {
+				t.Fatalf("expected a client-picked vehicle, got %+v %v", moved, err)
+			}
+		})
+	}
+}
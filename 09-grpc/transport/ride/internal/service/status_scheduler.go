@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// StatusSchedulerOptions tunes a StatusScheduler; zero values fall back to
+// defaults.
+type StatusSchedulerOptions struct {
+	Interval      time.Duration // how often due assignments are looked up
+	CompleteAfter time.Duration // active assignments complete this long after EndsAt
+	ExpireAfter   time.Duration // pending assignments are cancelled this long after EndsAt
+	BatchSize     int           // assignments read per query
+	Tenants       []string      // tenants swept; none means the default tenant
+	// Invalidate, when set, is called for every assignment the scheduler
+	// changed, so a cache in front of the repository stops serving the old
+	// status. It reaches the cache of the replica running the scheduler
+	// only: the others serve the old status until their cache TTL runs out.
+	Invalidate func(ctx context.Context, assignmentID string)
+}
+
+const (
+	defaultSchedulerInterval      = 30 * time.Second
+	defaultSchedulerCompleteAfter = 15 * time.Minute
+	defaultSchedulerExpireAfter   = 15 * time.Minute
+	defaultSchedulerBatchSize     = 200
+
+	// SchedulerActor is recorded as the actor of automatic transitions.
+	SchedulerActor = "scheduler"
+)
+
+// StatusScheduler moves assignments through their lifecycle as time passes:
+// pending assignments become active once StartsAt has passed, active ones
+// complete CompleteAfter past EndsAt, and pending ones that never started
+// are cancelled ExpireAfter past EndsAt. Only the replica holding the
+// leader lock does the work. Each transition is a compare-and-set on the
+// version that was read, so a concurrent client change wins, and the
+// repository records an AssignmentStatusChanged event for it.
+type StatusScheduler struct {
+	repo ports.AssignmentRepository
+	lock ports.LeaderLock
+	opts StatusSchedulerOptions
+	now  func() time.Time
+}
+
+func NewStatusScheduler(repo ports.AssignmentRepository, lock ports.LeaderLock, opts StatusSchedulerOptions) *StatusScheduler {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultSchedulerInterval
+	}
+	if opts.CompleteAfter <= 0 {
+		opts.CompleteAfter = defaultSchedulerCompleteAfter
+	}
+	if opts.ExpireAfter <= 0 {
+		opts.ExpireAfter = defaultSchedulerExpireAfter
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultSchedulerBatchSize
+	}
+	if len(opts.Tenants) == 0 {
+		opts.Tenants = []string{requestctx.DefaultTenant}
+	}
+	return &StatusScheduler{repo: repo, lock: lock, opts: opts, now: time.Now}
+}
+
+// Run applies due transitions once per interval while this replica is the
+// leader. It releases leadership and returns once ctx is cancelled.
+func (s *StatusScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := s.lock.Release(releaseCtx); err != nil {
+			log.Printf("status scheduler: release leader lock: %v", err)
+		}
+	}()
+
+	leader := false
+	for {
+		isLeader, err := s.lock.Acquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("status scheduler: acquire leader lock: %v", err)
+		}
+		if isLeader != leader {
+			leader = isLeader
+			log.Printf("status scheduler: leader=%t", leader)
+		}
+		if leader {
+			if n, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("status scheduler: %v", err)
+			} else if n > 0 {
+				log.Printf("status scheduler: applied %d transitions", n)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies every transition that is due now, tenant by tenant, and
+// reports how many were applied. It does not check leadership.
+func (s *StatusScheduler) RunOnce(ctx context.Context) (int, error) {
+	ctx = requestctx.WithActor(ctx, SchedulerActor)
+	now := s.now().UTC()
+
+	applied := 0
+	for _, tenant := range s.opts.Tenants {
+		n, err := s.runTenant(requestctx.WithTenant(ctx, tenant), now)
+		applied += n
+		if err != nil {
+			return applied, fmt.Errorf("tenant %s: %w", tenant, err)
+		}
+	}
+	return applied, nil
+}
+
+// runTenant is RunOnce for the tenant in ctx.
+func (s *StatusScheduler) runTenant(ctx context.Context, now time.Time) (int, error) {
+	// Completing first keeps an assignment from being activated and
+	// completed in the same run.
+	completed, err := s.sweep(ctx, models.AssignmentStatusActive, now, func(a models.Assignment) (models.AssignmentStatus, string, bool) {
+		if now.Before(a.EndsAt.Add(s.opts.CompleteAfter)) {
+			return "", "", false
+		}
+		return models.AssignmentStatusCompleted, "ended", true
+	})
+	if err != nil {
+		return completed, fmt.Errorf("complete assignments: %w", err)
+	}
+
+	started, err := s.sweep(ctx, models.AssignmentStatusPending, now, func(a models.Assignment) (models.AssignmentStatus, string, bool) {
+		if !now.Before(a.EndsAt.Add(s.opts.ExpireAfter)) {
+			return models.AssignmentStatusCancelled, "expired without starting", true
+		}
+		return models.AssignmentStatusActive, "start time reached", true
+	})
+	if err != nil {
+		return completed + started, fmt.Errorf("start assignments: %w", err)
+	}
+	return completed + started, nil
+}
+
+// sweep pages through the assignments in status from that started before
+// now and applies the transition decide returns for each of them.
+func (s *StatusScheduler) sweep(ctx context.Context, from models.AssignmentStatus, now time.Time, decide func(models.Assignment) (models.AssignmentStatus, string, bool)) (int, error) {
+	status := string(from)
+	q := models.AssignmentQuery{Status: &status, StartsTo: &now, Order: models.SortAscending, Limit: s.opts.BatchSize}
+
+	applied := 0
+	for {
+		page, err := s.repo.FindAll(ctx, q)
+		if err != nil {
+			return applied, err
+		}
+		for _, a := range page.Items {
+			to, reason, ok := decide(a)
+			if !ok {
+				continue
+			}
+			err := s.repo.UpdateStatus(ctx, models.AssignmentTransition{
+				AssignmentID: a.ID,
+				From:         from,
+				To:           to,
+				Actor:        requestctx.Actor(ctx),
+				Reason:       reason,
+				ChangedAt:    now,
+				Version:      a.Version,
+			})
+			if errors.Is(err, models.ErrConflict) || errors.Is(err, models.ErrPreconditionFailed) || errors.Is(err, models.ErrNotFound) {
+				continue // changed concurrently; the next run sees the new state
+			}
+			if err != nil {
+				return applied, err
+			}
+			if s.opts.Invalidate != nil {
+				s.opts.Invalidate(ctx, a.ID)
+			}
+			applied++
+		}
+		if page.Next == nil || ctx.Err() != nil {
+			return applied, ctx.Err()
+		}
+		q.After = page.Next
+	}
+}
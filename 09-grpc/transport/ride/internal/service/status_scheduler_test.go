@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+type fakeLeaderLock struct {
+	leader   bool
+	released bool
+}
+
+func (l *fakeLeaderLock) Acquire(ctx context.Context) (bool, error) { return l.leader, nil }
+
+func (l *fakeLeaderLock) Release(ctx context.Context) error {
+	l.released = true
+	return nil
+}
+
+func TestStatusSchedulerRunOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAssignmentRepository()
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name     string
+		status   models.AssignmentStatus
+		startsAt time.Time
+		endsAt   time.Time
+		want     models.AssignmentStatus
+	}{
+		{name: "pending, started", status: models.AssignmentStatusPending, startsAt: now.Add(-time.Minute), endsAt: now.Add(time.Hour), want: models.AssignmentStatusActive},
+		{name: "pending, not started", status: models.AssignmentStatusPending, startsAt: now.Add(time.Hour), endsAt: now.Add(2 * time.Hour), want: models.AssignmentStatusPending},
+		{name: "pending, ended within expiry window", status: models.AssignmentStatusPending, startsAt: now.Add(-time.Hour), endsAt: now.Add(-5 * time.Minute), want: models.AssignmentStatusActive},
+		{name: "pending, expired", status: models.AssignmentStatusPending, startsAt: now.Add(-2 * time.Hour), endsAt: now.Add(-time.Hour), want: models.AssignmentStatusCancelled},
+		{name: "active, running", status: models.AssignmentStatusActive, startsAt: now.Add(-time.Hour), endsAt: now.Add(time.Hour), want: models.AssignmentStatusActive},
+		{name: "active, within completion window", status: models.AssignmentStatusActive, startsAt: now.Add(-time.Hour), endsAt: now.Add(-5 * time.Minute), want: models.AssignmentStatusActive},
+		{name: "active, ended", status: models.AssignmentStatusActive, startsAt: now.Add(-2 * time.Hour), endsAt: now.Add(-time.Hour), want: models.AssignmentStatusCompleted},
+	}
+	for i, tc := range testCases {
+		a := models.Assignment{ID: tc.name, VehicleID: string(rune('A' + i)), RouteID: "R1", StartsAt: tc.startsAt, EndsAt: tc.endsAt, Status: string(tc.status)}
+		if _, err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save %s: %v", tc.name, err)
+		}
+	}
+
+	scheduler := service.NewStatusScheduler(repo, &fakeLeaderLock{leader: true}, service.StatusSchedulerOptions{BatchSize: 2})
+	if n, err := scheduler.RunOnce(ctx); err != nil || n != 4 {
+		t.Fatalf("expected 4 transitions, got %d (err %v)", n, err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := repo.FindByID(ctx, tc.name)
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if models.AssignmentStatus(a.Status) != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, a.Status)
+			}
+		})
+	}
+
+	if n, err := scheduler.RunOnce(ctx); err != nil || n != 0 {
+		t.Fatalf("expected a second run to change nothing, got %d (err %v)", n, err)
+	}
+}
+
+func TestStatusSchedulerFollowerDoesNothing(t *testing.T) {
+	repo := repository.NewMemoryAssignmentRepository()
+	now := time.Now().UTC()
+	a := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := repo.Save(context.Background(), a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	lock := &fakeLeaderLock{leader: false}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Run makes one pass, then sees the cancelled context and stops
+	service.NewStatusScheduler(repo, lock, service.StatusSchedulerOptions{}).Run(ctx)
+
+	got, err := repo.FindByID(context.Background(), "A1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Status != string(models.AssignmentStatusPending) {
+		t.Fatalf("a follower must not change assignments, got %s", got.Status)
+	}
+	if !lock.released {
+		t.Fatal("expected the leader lock to be released on shutdown")
+	}
+}
+
+func TestStatusSchedulerInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAssignmentRepository()
+	cached, err := repository.NewCachedAssignmentRepository(repo, repository.CacheOptions{TTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewCachedAssignmentRepository: %v", err)
+	}
+	now := time.Now().UTC()
+	a := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := cached.Save(ctx, a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	before, err := cached.FindByID(ctx, "A1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+
+	// Like main, the scheduler writes to the uncached repository.
+	scheduler := service.NewStatusScheduler(repo, &fakeLeaderLock{leader: true}, service.StatusSchedulerOptions{Invalidate: cached.Invalidate})
+	if n, err := scheduler.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 transition, got %d (err %v)", n, err)
+	}
+
+	after, err := cached.FindByID(ctx, "A1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if after.Status != string(models.AssignmentStatusActive) {
+		t.Fatalf("expected the cache to serve the new status, got %s", after.Status)
+	}
+	if after.Version == before.Version {
+		t.Fatalf("expected a new version (and ETag) after the transition, still %d", after.Version)
+	}
+}
+
+// The scheduler evicts its transitions from the cache of its own replica
+// only; another replica serves the old status until its entry expires.
+func TestStatusSchedulerStalenessOnOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryAssignmentRepository()
+	const ttl = 50 * time.Millisecond
+	leader, err := repository.NewCachedAssignmentRepository(repo, repository.CacheOptions{TTL: ttl})
+	if err != nil {
+		t.Fatalf("NewCachedAssignmentRepository: %v", err)
+	}
+	replica, err := repository.NewCachedAssignmentRepository(repo, repository.CacheOptions{TTL: ttl})
+	if err != nil {
+		t.Fatalf("NewCachedAssignmentRepository: %v", err)
+	}
+	now := time.Now().UTC()
+	a := models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Status: string(models.AssignmentStatusPending)}
+	if _, err := repo.Save(ctx, a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	status := func(cache *repository.CachedAssignmentRepository) string {
+		t.Helper()
+		got, err := cache.FindByID(ctx, "A1")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		return got.Status
+	}
+	status(leader)
+	status(replica)
+
+	scheduler := service.NewStatusScheduler(repo, &fakeLeaderLock{leader: true}, service.StatusSchedulerOptions{Invalidate: leader.Invalidate})
+	transitioned := time.Now()
+	if n, err := scheduler.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 transition, got %d (err %v)", n, err)
+	}
+
+	active := string(models.AssignmentStatusActive)
+	if got := status(leader); got != active {
+		t.Fatalf("expected the leader's cache to serve the new status, got %s", got)
+	}
+	if got := status(replica); got != string(models.AssignmentStatusPending) && time.Since(transitioned) < ttl {
+		t.Fatalf("expected the replica to serve its cached status within the TTL, got %s", got)
+	}
+	time.Sleep(ttl)
+	if got := status(replica); got != active {
+		t.Fatalf("expected the replica to serve the new status once the TTL ran out, got %s", got)
+	}
+}
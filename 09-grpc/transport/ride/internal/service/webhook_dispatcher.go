@@ -0,0 +1,308 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// Headers of a webhook request. Receivers should verify the signature,
+// reject timestamps too far from their clock, and use the delivery ID to
+// drop duplicates: delivery is at-least-once.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// WebhookSignature is the WebhookSignatureHeader value for body sent at ts:
+// "sha256=" followed by the hex HMAC-SHA256, keyed with secret, of the
+// WebhookTimestampHeader value, a dot and body. Signing the timestamp keeps
+// a captured request from being replayed later.
+func WebhookSignature(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// EnqueueWebhooks returns the outbox handler that queues an event for every
+// enabled webhook subscribed to its type. The relay runs it for the event's
+// tenant, so only that tenant's webhooks see the event. The store skips
+// deliveries it already has, so the relay may retry the handler.
+func EnqueueWebhooks(webhooks ports.WebhookRepository, deliveries ports.WebhookDeliveryStore) OutboxHandler {
+	return func(ctx context.Context, e models.OutboxEvent) error {
+		all, err := webhooks.FindAll(ctx)
+		if err != nil {
+			return fmt.Errorf("list webhooks: %w", err)
+		}
+		body, err := json.Marshal(models.WebhookEvent{
+			EventID:    e.ID,
+			Type:       e.Type,
+			OccurredAt: e.CreatedAt.UTC(),
+			Data:       json.RawMessage(e.Payload),
+		})
+		if err != nil {
+			return fmt.Errorf("encode webhook event: %w", err)
+		}
+
+		now := time.Now().UTC()
+		var queued []models.WebhookDelivery
+		for _, w := range all {
+			if !w.Enabled || !w.Subscribes(e.Type) {
+				continue
+			}
+			queued = append(queued, models.WebhookDelivery{
+				WebhookID:     w.ID,
+				EventID:       e.ID,
+				EventType:     e.Type,
+				Payload:       body,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+		if len(queued) == 0 {
+			return nil
+		}
+		return deliveries.Enqueue(ctx, queued)
+	}
+}
+
+// WebhookDispatcherOptions tunes a WebhookDispatcher; zero values fall back
+// to defaults.
+type WebhookDispatcherOptions struct {
+	PollInterval time.Duration // how often due deliveries are polled
+	BatchSize    int           // deliveries fetched per poll
+	Concurrency  int           // requests in flight at once
+	Timeout      time.Duration // per request
+	MinBackoff   time.Duration // delay after the first failed attempt; doubles per attempt
+	MaxBackoff   time.Duration // upper bound for the retry delay
+	MaxAttempts  int           // a delivery fails for good after this many attempts
+	DisableAfter int           // consecutive failed attempts that disable a webhook
+}
+
+const (
+	defaultWebhookPollInterval = 2 * time.Second
+	defaultWebhookBatchSize    = 100
+	defaultWebhookConcurrency  = 8
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookMinBackoff   = 10 * time.Second
+	defaultWebhookMaxBackoff   = time.Hour
+	defaultWebhookMaxAttempts  = 10
+	defaultWebhookDisableAfter = 20
+
+	// maxWebhookResponseBytes is how much of a response body is read
+	// before the connection is reused; the body itself is ignored.
+	maxWebhookResponseBytes = 64 << 10
+)
+
+// WebhookDispatcher sends queued deliveries to their webhooks. Failed
+// attempts are retried with exponential backoff until MaxAttempts, and a
+// webhook that fails DisableAfter times in a row is disabled, which fails
+// its pending deliveries. Every replica may run a dispatcher: a delivery
+// is claimed before it is sent, so replicas do not send it at the same
+// time.
+type WebhookDispatcher struct {
+	webhooks   ports.WebhookRepository
+	deliveries ports.WebhookDeliveryStore
+	client     *http.Client
+	opts       WebhookDispatcherOptions
+}
+
+func NewWebhookDispatcher(webhooks ports.WebhookRepository, deliveries ports.WebhookDeliveryStore, opts WebhookDispatcherOptions) *WebhookDispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWebhookPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultWebhookBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultWebhookConcurrency
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultWebhookTimeout
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultWebhookMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	if opts.MinBackoff > opts.MaxBackoff {
+		opts.MinBackoff = opts.MaxBackoff
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if opts.DisableAfter <= 0 {
+		opts.DisableAfter = defaultWebhookDisableAfter
+	}
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		// A redirect is answered like any other non-2xx status; following
+		// it would send signed payloads to a URL nobody registered.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return &WebhookDispatcher{webhooks: webhooks, deliveries: deliveries, client: client, opts: opts}
+}
+
+// Run sends due deliveries once per poll interval until ctx is cancelled.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("webhook dispatcher: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce sends one batch of due deliveries and reports how many
+// succeeded. A failed delivery does not stop the batch.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	due, err := d.deliveries.FetchDue(ctx, time.Now().UTC(), d.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("fetch due deliveries: %w", err)
+	}
+
+	var (
+		g         errgroup.Group
+		succeeded = make(chan struct{}, len(due))
+	)
+	g.SetLimit(d.opts.Concurrency)
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			ok, err := d.dispatch(ctx, delivery)
+			if ok {
+				succeeded <- struct{}{}
+			}
+			return err
+		})
+	}
+	err = g.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return len(succeeded), err
+}
+
+// dispatch claims and sends one delivery and records the outcome, acting
+// for the delivery's tenant. It reports whether the webhook accepted it.
+func (d *WebhookDispatcher) dispatch(ctx context.Context, delivery models.WebhookDelivery) (bool, error) {
+	ctx = requestctx.WithTenant(ctx, delivery.TenantID)
+	// The claim outlasts the request, so nobody else sends the delivery
+	// before this attempt is recorded.
+	claimed, err := d.deliveries.Claim(ctx, delivery, time.Now().UTC().Add(2*d.opts.Timeout))
+	if err != nil {
+		return false, fmt.Errorf("claim delivery %d: %w", delivery.ID, err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	w, err := d.webhooks.FindByID(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		return false, d.abandon(ctx, delivery, "webhook was deleted")
+	case err != nil:
+		return false, fmt.Errorf("find webhook %s: %w", delivery.WebhookID, err)
+	case !w.Enabled:
+		return false, d.abandon(ctx, delivery, "webhook is disabled")
+	}
+
+	status, sendErr := d.send(ctx, w, delivery)
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastStatusCode = status
+	if sendErr == nil {
+		delivery.Status, delivery.LastError, delivery.DeliveredAt = models.WebhookDeliverySucceeded, "", &now
+		if err := d.deliveries.RecordAttempt(ctx, delivery); err != nil {
+			return false, fmt.Errorf("record delivery %d: %w", delivery.ID, err)
+		}
+		if _, err := d.webhooks.RecordOutcome(ctx, w.ID, true, d.opts.DisableAfter, ""); err != nil {
+			return true, fmt.Errorf("record outcome of webhook %s: %w", w.ID, err)
+		}
+		return true, nil
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(exponentialBackoff(d.opts.MinBackoff, d.opts.MaxBackoff, delivery.Attempts))
+	}
+	if err := d.deliveries.RecordAttempt(ctx, delivery); err != nil {
+		return false, fmt.Errorf("record delivery %d: %w", delivery.ID, err)
+	}
+	reason := fmt.Sprintf("disabled after %d consecutive failed deliveries; last error: %s", d.opts.DisableAfter, delivery.LastError)
+	disabled, err := d.webhooks.RecordOutcome(ctx, w.ID, false, d.opts.DisableAfter, reason)
+	if err != nil {
+		return false, fmt.Errorf("record outcome of webhook %s: %w", w.ID, err)
+	}
+	if disabled {
+		log.Printf("webhook dispatcher: webhook %s %s", w.ID, reason)
+	}
+	return false, nil
+}
+
+// send posts the delivery and returns the response status, or 0 when no
+// response arrived. Any status outside 2xx is an error.
+func (d *WebhookDispatcher) send(ctx context.Context, w models.Webhook, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ride-webhooks/1")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.Secret, ts, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// abandon fails a delivery that can no longer be sent.
+func (d *WebhookDispatcher) abandon(ctx context.Context, delivery models.WebhookDelivery, reason string) error {
+	delivery.Status, delivery.LastError = models.WebhookDeliveryFailed, reason
+	if err := d.deliveries.RecordAttempt(ctx, delivery); err != nil {
+		return fmt.Errorf("record delivery %d: %w", delivery.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/ride/internal/adapters/repository"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+	"github.com/yourname/transport/ride/internal/service"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// receiver records the webhook requests it gets and answers with status.
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	status   atomic.Int32
+}
+
+func newReceiver(t *testing.T, status int) (*receiver, *httptest.Server) {
+	r := &receiver{}
+	r.status.Store(int32(status))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+		w.WriteHeader(int(r.status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+	return r, srv
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func (r *receiver) received() ([]*http.Request, [][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests, r.bodies
+}
+
+func saveWebhook(t *testing.T, store ports.WebhookStore, w models.Webhook) {
+	t.Helper()
+	if w.Secret == "" {
+		w.Secret = testWebhookSecret
+	}
+	if _, err := store.Save(context.Background(), w); err != nil {
+		t.Fatalf("save webhook %s: %v", w.ID, err)
+	}
+}
+
+func createdEvent(t *testing.T, id int64) models.OutboxEvent {
+	t.Helper()
+	e, err := models.NewAssignmentCreatedEvent(models.Assignment{ID: "A1", VehicleID: "V1", RouteID: "R1"},
+		time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewAssignmentCreatedEvent: %v", err)
+	}
+	e.ID = id
+	return e
+}
+
+func TestWebhookDispatcherDeliversSignedEvents(t *testing.T) {
+	ctx := context.Background()
+	recv, srv := newReceiver(t, http.StatusNoContent)
+	store := repository.NewMemoryWebhookStore()
+	saveWebhook(t, store, models.Webhook{ID: "all", URL: srv.URL + "/all", Enabled: true})
+	saveWebhook(t, store, models.Webhook{ID: "created", URL: srv.URL + "/created", Enabled: true, EventTypes: []string{models.EventAssignmentCreated}})
+	saveWebhook(t, store, models.Webhook{ID: "status", URL: srv.URL + "/status", Enabled: true, EventTypes: []string{models.EventAssignmentStatusChanged}})
+	saveWebhook(t, store, models.Webhook{ID: "disabled", URL: srv.URL + "/disabled"})
+
+	enqueue := service.EnqueueWebhooks(store, store)
+	event := createdEvent(t, 7)
+	// A relay retry must not queue the event twice.
+	for range 2 {
+		if err := enqueue(ctx, event); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	dispatcher := service.NewWebhookDispatcher(store, store, service.WebhookDispatcherOptions{})
+	sent, err := dispatcher.DispatchOnce(ctx)
+	if err != nil || sent != 2 {
+		t.Fatalf("expected 2 deliveries, got sent=%d err=%v", sent, err)
+	}
+	if sent, _ := dispatcher.DispatchOnce(ctx); sent != 0 {
+		t.Fatalf("expected delivered events not to be sent again, sent %d", sent)
+	}
+
+	paths := map[string]bool{}
+	requests, bodies := recv.received()
+	for i, req := range requests {
+		paths[req.URL.Path] = true
+		body := bodies[i]
+		if got := req.Header.Get(service.WebhookEventHeader); got != models.EventAssignmentCreated {
+			t.Errorf("%s: event header %q", req.URL.Path, got)
+		}
+		if req.Header.Get(service.WebhookDeliveryHeader) == "" {
+			t.Errorf("%s: missing delivery header", req.URL.Path)
+		}
+		mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+		mac.Write([]byte(req.Header.Get(service.WebhookTimestampHeader) + "." + string(body)))
+		if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); req.Header.Get(service.WebhookSignatureHeader) != want {
+			t.Errorf("%s: signature %q, want %q", req.URL.Path, req.Header.Get(service.WebhookSignatureHeader), want)
+		}
+
+		var got models.WebhookEvent
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("decode body %s: %v", body, err)
+		}
+		if got.EventID != 7 || got.Type != models.EventAssignmentCreated || !strings.Contains(string(got.Data), `"A1"`) {
+			t.Errorf("%s: unexpected body %s", req.URL.Path, body)
+		}
+	}
+	if len(paths) != 2 || !paths["/all"] || !paths["/created"] {
+		t.Fatalf("expected deliveries to /all and /created, got %v", paths)
+	}
+
+	log, err := store.List(ctx, models.WebhookDeliveryQuery{WebhookID: "all"})
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(log) != 1 || log[0].Status != models.WebhookDeliverySucceeded || log[0].Attempts != 1 ||
+		log[0].LastStatusCode != http.StatusNoContent || log[0].DeliveredAt == nil {
+		t.Fatalf("unexpected delivery log: %+v", log)
+	}
+}
+
+func TestWebhookDispatcherRetriesAndDisables(t *testing.T) {
+	ctx := context.Background()
+	recv, srv := newReceiver(t, http.StatusInternalServerError)
+	store := repository.NewMemoryWebhookStore()
+	saveWebhook(t, store, models.Webhook{ID: "W1", URL: srv.URL, Enabled: true})
+
+	enqueue := service.EnqueueWebhooks(store, store)
+	for _, id := range []int64{1, 2} {
+		if err := enqueue(ctx, createdEvent(t, id)); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	const backoff = 50 * time.Millisecond
+	dispatcher := service.NewWebhookDispatcher(store, store, service.WebhookDispatcherOptions{
+		Concurrency:  1,
+		MinBackoff:   backoff,
+		MaxBackoff:   backoff,
+		MaxAttempts:  2,
+		DisableAfter: 3,
+	})
+
+	if sent, err := dispatcher.DispatchOnce(ctx); err != nil || sent != 0 {
+		t.Fatalf("expected failed attempts, got sent=%d err=%v", sent, err)
+	}
+	if recv.count() != 2 {
+		t.Fatalf("expected 2 requests, got %d", recv.count())
+	}
+	pending := models.WebhookDeliveryPending
+	log, _ := store.List(ctx, models.WebhookDeliveryQuery{WebhookID: "W1", Status: &pending})
+	if len(log) != 2 || log[0].Attempts != 1 || log[0].LastStatusCode != http.StatusInternalServerError || log[0].LastError == "" {
+		t.Fatalf("expected both deliveries pending after one attempt, got %+v", log)
+	}
+
+	// Nothing is retried before the backoff elapses.
+	if _, err := dispatcher.DispatchOnce(ctx); err != nil || recv.count() != 2 {
+		t.Fatalf("expected no retry before the backoff, got %d requests, err=%v", recv.count(), err)
+	}
+
+	time.Sleep(backoff + 10*time.Millisecond)
+	if _, err := dispatcher.DispatchOnce(ctx); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	// The first retry is the third failure in a row, which disables the
+	// webhook; the second delivery is then failed without a request.
+	if recv.count() != 3 {
+		t.Fatalf("expected 3 requests, got %d", recv.count())
+	}
+	w, err := store.FindByID(ctx, "W1")
+	if err != nil {
+		t.Fatalf("find webhook: %v", err)
+	}
+	if w.Enabled || w.ConsecutiveFailures != 3 || !strings.Contains(w.DisabledReason, "500") {
+		t.Fatalf("expected webhook disabled after 3 failures, got %+v", w)
+	}
+	log, _ = store.List(ctx, models.WebhookDeliveryQuery{WebhookID: "W1"})
+	if len(log) != 2 {
+		t.Fatalf("expected 2 deliveries, got %+v", log)
+	}
+	for _, d := range log {
+		if d.Status != models.WebhookDeliveryFailed {
+			t.Errorf("delivery %d: status %s, want failed", d.ID, d.Status)
+		}
+	}
+	if log[0].LastError != "webhook is disabled" || log[1].Attempts != 2 {
+		t.Fatalf("unexpected delivery log: %+v", log)
+	}
+}
+
+func TestWebhookDispatcherResetsFailuresOnSuccess(t *testing.T) {
+	ctx := context.Background()
+	recv, srv := newReceiver(t, http.StatusBadGateway)
+	store := repository.NewMemoryWebhookStore()
+	saveWebhook(t, store, models.Webhook{ID: "W1", URL: srv.URL, Enabled: true})
+	if err := service.EnqueueWebhooks(store, store)(ctx, createdEvent(t, 1)); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	dispatcher := service.NewWebhookDispatcher(store, store, service.WebhookDispatcherOptions{
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	})
+	if sent, _ := dispatcher.DispatchOnce(ctx); sent != 0 {
+		t.Fatalf("expected the first attempt to fail, sent %d", sent)
+	}
+	if w, _ := store.FindByID(ctx, "W1"); w.ConsecutiveFailures != 1 {
+		t.Fatalf("expected 1 consecutive failure, got %d", w.ConsecutiveFailures)
+	}
+
+	recv.status.Store(http.StatusOK)
+	time.Sleep(5 * time.Millisecond)
+	if sent, err := dispatcher.DispatchOnce(ctx); err != nil || sent != 1 {
+		t.Fatalf("expected the retry to succeed, got sent=%d err=%v", sent, err)
+	}
+	if w, _ := store.FindByID(ctx, "W1"); !w.Enabled || w.ConsecutiveFailures != 0 {
+		t.Fatalf("expected failures reset, got %+v", w)
+	}
+	if log, _ := store.List(ctx, models.WebhookDeliveryQuery{WebhookID: "W1"}); len(log) != 1 || log[0].Attempts != 2 || log[0].Status != models.WebhookDeliverySucceeded {
+		t.Fatalf("unexpected delivery log: %+v", log)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yourname/transport/ride/internal/models"
+	"github.com/yourname/transport/ride/internal/ports"
+)
+
+// Bounds for Webhook.Secret.
+const (
+	MinWebhookSecretLength = 16
+	MaxWebhookSecretLength = 255
+)
+
+type webhookService struct {
+	store ports.WebhookStore
+}
+
+// NewWebhookService manages webhook subscriptions and reads their delivery
+// log. Deliveries themselves are made by the WebhookDispatcher.
+func NewWebhookService(store ports.WebhookStore) ports.WebhookService {
+	return &webhookService{store: store}
+}
+
+func (s *webhookService) Save(ctx context.Context, w models.Webhook) (models.Webhook, error) {
+	w = normalizeWebhook(w)
+	if err := validateWebhook(w); err != nil {
+		return models.Webhook{}, err
+	}
+	w.ID = uuid.NewString()
+	w.Version = 0
+	w.Enabled, w.DisabledReason, w.ConsecutiveFailures = true, "", 0
+	if _, err := s.store.Save(ctx, w); err != nil {
+		return models.Webhook{}, err
+	}
+	return s.store.FindByID(ctx, w.ID)
+}
+
+func (s *webhookService) GetByID(ctx context.Context, id string) (models.Webhook, error) {
+	return s.store.FindByID(ctx, id)
+}
+
+func (s *webhookService) List(ctx context.Context) ([]models.Webhook, error) {
+	return s.store.FindAll(ctx)
+}
+
+func (s *webhookService) Update(ctx context.Context, w models.Webhook) (models.Webhook, error) {
+	w = normalizeWebhook(w)
+	if err := validateWebhook(w); err != nil {
+		return models.Webhook{}, err
+	}
+
+	current, err := s.store.FindByID(ctx, w.ID)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	w.Version = current.Version
+	switch {
+	case !w.Enabled:
+		w.DisabledReason, w.ConsecutiveFailures = current.DisabledReason, current.ConsecutiveFailures
+	case !current.Enabled:
+		// Re-enabling gives the endpoint a fresh start.
+		w.DisabledReason, w.ConsecutiveFailures = "", 0
+	default:
+		w.ConsecutiveFailures = current.ConsecutiveFailures
+	}
+	if _, err := s.store.Save(ctx, w); err != nil {
+		if errors.Is(err, models.ErrPreconditionFailed) {
+			return models.Webhook{}, models.NewConflictError("webhook %s was modified concurrently, retry", w.ID)
+		}
+		return models.Webhook{}, err
+	}
+	return s.store.FindByID(ctx, w.ID)
+}
+
+func (s *webhookService) Delete(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id, 0)
+}
+
+func (s *webhookService) Deliveries(ctx context.Context, q models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error) {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return nil, models.NewValidationError("limit must be between 1 and %d", MaxPageSize)
+	}
+	if _, err := s.store.FindByID(ctx, q.WebhookID); err != nil {
+		return nil, err
+	}
+	return s.store.List(ctx, q)
+}
+
+// normalizeWebhook drops duplicate event types, so equal filters compare equal.
+func normalizeWebhook(w models.Webhook) models.Webhook {
+	w.URL = strings.TrimSpace(w.URL)
+	types := slices.Clone(w.EventTypes)
+	slices.Sort(types)
+	w.EventTypes = slices.Compact(types)
+	return w
+}
+
+// validateWebhook reports every problem at once, like validateAssignment.
+func validateWebhook(w models.Webhook) error {
+	var problems []string
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, "url must be an absolute http or https URL")
+	}
+	for _, t := range w.EventTypes {
+		if !slices.Contains(models.WebhookEventTypes, t) {
+			problems = append(problems, "unknown event type "+t)
+		}
+	}
+	if n := len(w.Secret); n < MinWebhookSecretLength || n > MaxWebhookSecretLength {
+		problems = append(problems, "secret must be between 16 and 255 characters")
+	}
+	if len(problems) > 0 {
+		return models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package tenancy decides which tenant a request acts for. The REST and
+// gRPC APIs both resolve tenants through it, so they serve the same tenants
+// under the same rules.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yourname/transport/ride/internal/requestctx"
+)
+
+// Errors wrapped by those of Resolve and OfClaims.
+var (
+	// ErrForbidden means the credentials of the request rule the tenant out.
+	ErrForbidden = errors.New("tenant forbidden")
+	// ErrNotServed means the request named a tenant that is not served.
+	ErrNotServed = errors.New("tenant not served")
+	// ErrMissing means the request named no tenant where it has to.
+	ErrMissing = errors.New("tenant missing")
+)
+
+type tenantError struct {
+	kind    error
+	message string
+}
+
+func (e *tenantError) Error() string { return e.message }
+func (e *tenantError) Unwrap() error { return e.kind }
+
+// OfClaims returns the tenant the claims of a token pin requests to. A token
+// without a tenant claim is refused when requireClaim is set and otherwise
+// acts for requestctx.DefaultTenant alone; a tenant named by the request
+// never stands in for the claim.
+func OfClaims(claims requestctx.Claims, requireClaim bool) (string, error) {
+	switch {
+	case claims.Tenant != "":
+		return claims.Tenant, nil
+	case requireClaim:
+		return "", &tenantError{kind: ErrForbidden, message: "token has no tenant claim"}
+	default:
+		return requestctx.DefaultTenant, nil
+	}
+}
+
+// Resolver picks the tenant of each request among those served.
+type Resolver struct {
+	served map[string]bool
+	field  string
+}
+
+// NewResolver serves the listed tenants; an empty list serves the default
+// tenant alone. field names the header or metadata key requests name their
+// tenant in, for error messages.
+func NewResolver(tenants []string, field string) *Resolver {
+	if len(tenants) == 0 {
+		tenants = []string{requestctx.DefaultTenant}
+	}
+	served := make(map[string]bool, len(tenants))
+	for _, t := range tenants {
+		served[t] = true
+	}
+	return &Resolver{served: served, field: field}
+}
+
+// Resolve returns the tenant a request acts for, given the tenant it named
+// in its header or metadata, which may be "". A tenant already in ctx, put
+// there from the credentials of the caller, wins, and a request naming a
+// different one is refused. Otherwise the named tenant is used, or
+// requestctx.DefaultTenant when none is named.
+func (r *Resolver) Resolve(ctx context.Context, named string) (string, error) {
+	tenant, fromCredentials := requestctx.LookupTenant(ctx)
+	switch {
+	case fromCredentials:
+		if named != "" && named != tenant {
+			return "", &tenantError{kind: ErrForbidden, message: r.field + " does not match the tenant of the credentials"}
+		}
+		if !r.served[tenant] {
+			return "", &tenantError{kind: ErrForbidden, message: fmt.Sprintf("tenant %q is not served here", tenant)}
+		}
+		return tenant, nil
+	case named != "":
+		if !r.served[named] {
+			return "", &tenantError{kind: ErrNotServed, message: fmt.Sprintf("tenant %q is not served here", named)}
+		}
+		return named, nil
+	default:
+		if !r.served[requestctx.DefaultTenant] {
+			return "", &tenantError{kind: ErrMissing, message: "this deployment serves several tenants; name one in " + r.field}
+		}
+		return requestctx.DefaultTenant, nil
+	}
+}
@@ -0,0 +1,27 @@
+// Package migrations embeds the versioned SQL migrations of the ride
+// database. Files are named NNNN_description.up.sql / .down.sql and are
+// applied in version order by repository.Migrator.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed mysql/*.sql postgres/*.sql
+var files embed.FS
+
+// MySQL and Postgres hold the migrations for each supported database. Both
+// sets must describe the same schema and keep the same version numbers.
+var (
+	MySQL    = mustSub(files, "mysql")
+	Postgres = mustSub(files, "postgres")
+)
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS assignments (
+    id VARCHAR(50) PRIMARY KEY,
+    vehicle_id VARCHAR(50) NOT NULL,
+    route_id VARCHAR(50) NOT NULL,
+    starts_at DATETIME NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    INDEX idx_assignments_starts_at (starts_at, id),
+    INDEX idx_assignments_status_starts_at (status, starts_at, id),
+    INDEX idx_assignments_vehicle_starts_at (vehicle_id, starts_at, id),
+    INDEX idx_assignments_route_starts_at (route_id, starts_at, id)
+);
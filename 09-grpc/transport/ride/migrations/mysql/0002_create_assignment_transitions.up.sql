@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS assignment_transitions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    assignment_id VARCHAR(50) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(1024) NOT NULL DEFAULT '',
+    changed_at DATETIME(6) NOT NULL,
+    INDEX idx_assignment_transitions_assignment (assignment_id, changed_at)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS vehicle_schedule_locks;
+ALTER TABLE assignments DROP COLUMN ends_at;
@@ -0,0 +1,10 @@
+-- Existing assignments get the default one-hour window.
+ALTER TABLE assignments ADD COLUMN ends_at DATETIME NULL AFTER starts_at;
+UPDATE assignments SET ends_at = DATE_ADD(starts_at, INTERVAL 1 HOUR) WHERE ends_at IS NULL;
+ALTER TABLE assignments MODIFY ends_at DATETIME NOT NULL;
+
+-- One row per vehicle; writers lock it to check and book the vehicle's
+-- schedule atomically.
+CREATE TABLE IF NOT EXISTS vehicle_schedule_locks (
+    vehicle_id VARCHAR(50) PRIMARY KEY
+);
@@ -0,0 +1,13 @@
+-- Append-only: rows are inserted with the change they describe and never
+-- updated or deleted.
+CREATE TABLE IF NOT EXISTS assignment_audit (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    assignment_id VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    before_state JSON NULL,
+    after_state JSON NOT NULL,
+    recorded_at DATETIME(6) NOT NULL,
+    INDEX idx_assignment_audit_assignment (assignment_id, id)
+);
@@ -0,0 +1,41 @@
+-- Fails where two tenants share a key: merging tenants needs a decision
+-- about whose rows win.
+ALTER TABLE webhook_deliveries
+    DROP INDEX idx_webhook_deliveries_log,
+    ADD INDEX idx_webhook_deliveries_log (webhook_id, id),
+    DROP INDEX uq_webhook_deliveries_event,
+    ADD UNIQUE KEY uq_webhook_deliveries_event (webhook_id, event_id),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE webhooks DROP PRIMARY KEY, ADD PRIMARY KEY (id), DROP COLUMN tenant_id;
+
+ALTER TABLE recurring_assignments DROP PRIMARY KEY, ADD PRIMARY KEY (id), DROP COLUMN tenant_id;
+
+ALTER TABLE idempotency_keys DROP PRIMARY KEY, ADD PRIMARY KEY (idempotency_key), DROP COLUMN tenant_id;
+
+ALTER TABLE outbox DROP COLUMN tenant_id;
+
+ALTER TABLE vehicle_schedule_locks DROP PRIMARY KEY, ADD PRIMARY KEY (vehicle_id), DROP COLUMN tenant_id;
+
+ALTER TABLE assignment_audit
+    DROP INDEX idx_assignment_audit_assignment,
+    ADD INDEX idx_assignment_audit_assignment (assignment_id, id),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE assignment_transitions
+    DROP INDEX idx_assignment_transitions_assignment,
+    ADD INDEX idx_assignment_transitions_assignment (assignment_id, changed_at),
+    DROP COLUMN tenant_id;
+
+ALTER TABLE assignments
+    DROP INDEX idx_assignments_route_starts_at,
+    ADD INDEX idx_assignments_route_starts_at (route_id, starts_at, id),
+    DROP INDEX idx_assignments_vehicle_starts_at,
+    ADD INDEX idx_assignments_vehicle_starts_at (vehicle_id, starts_at, id),
+    DROP INDEX idx_assignments_status_starts_at,
+    ADD INDEX idx_assignments_status_starts_at (status, starts_at, id),
+    DROP INDEX idx_assignments_starts_at,
+    ADD INDEX idx_assignments_starts_at (starts_at, id),
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (id),
+    DROP COLUMN tenant_id;
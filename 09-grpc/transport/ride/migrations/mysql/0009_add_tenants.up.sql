@@ -0,0 +1,64 @@
+-- Rows written before tenants existed belong to the default tenant. The
+-- default only backfills them: every write names its tenant. Keys start
+-- with tenant_id, so tenants may reuse each other's IDs, vehicles and
+-- idempotency keys without colliding.
+ALTER TABLE assignments
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, id),
+    DROP INDEX idx_assignments_starts_at,
+    ADD INDEX idx_assignments_starts_at (tenant_id, starts_at, id),
+    DROP INDEX idx_assignments_status_starts_at,
+    ADD INDEX idx_assignments_status_starts_at (tenant_id, status, starts_at, id),
+    DROP INDEX idx_assignments_vehicle_starts_at,
+    ADD INDEX idx_assignments_vehicle_starts_at (tenant_id, vehicle_id, starts_at, id),
+    DROP INDEX idx_assignments_route_starts_at,
+    ADD INDEX idx_assignments_route_starts_at (tenant_id, route_id, starts_at, id);
+ALTER TABLE assignments ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE assignment_transitions
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_assignment_transitions_assignment,
+    ADD INDEX idx_assignment_transitions_assignment (tenant_id, assignment_id, changed_at);
+ALTER TABLE assignment_transitions ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE assignment_audit
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX idx_assignment_audit_assignment,
+    ADD INDEX idx_assignment_audit_assignment (tenant_id, assignment_id, id);
+ALTER TABLE assignment_audit ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE vehicle_schedule_locks
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, vehicle_id);
+ALTER TABLE vehicle_schedule_locks ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE outbox ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' AFTER id;
+ALTER TABLE outbox ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, idempotency_key);
+ALTER TABLE idempotency_keys ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE recurring_assignments
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, id);
+ALTER TABLE recurring_assignments ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE webhooks
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, id);
+ALTER TABLE webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' AFTER id,
+    DROP INDEX uq_webhook_deliveries_event,
+    ADD UNIQUE KEY uq_webhook_deliveries_event (tenant_id, webhook_id, event_id),
+    DROP INDEX idx_webhook_deliveries_log,
+    ADD INDEX idx_webhook_deliveries_log (tenant_id, webhook_id, id);
+ALTER TABLE webhook_deliveries ALTER COLUMN tenant_id DROP DEFAULT;
@@ -0,0 +1,23 @@
+-- One row per assignment creation, or update moving an assignment, that
+-- reserves a vehicle. The assignment is copied so that an interrupted saga
+-- can be finished or rolled back without the request that started it.
+CREATE TABLE IF NOT EXISTS assignment_sagas (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'create',
+    assignment_id VARCHAR(50) NOT NULL,
+    vehicle_id VARCHAR(50) NOT NULL,
+    vehicle_auto_selected BOOLEAN NOT NULL DEFAULT FALSE,
+    route_id VARCHAR(50) NOT NULL,
+    starts_at DATETIME(6) NOT NULL,
+    ends_at DATETIME(6) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    state VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1024) NOT NULL DEFAULT '',
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at DATETIME(6) NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    INDEX idx_assignment_sagas_state_updated_at (state, updated_at, id)
+);
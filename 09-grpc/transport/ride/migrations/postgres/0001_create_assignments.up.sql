@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS assignments (
+    id VARCHAR(50) PRIMARY KEY,
+    vehicle_id VARCHAR(50) NOT NULL,
+    route_id VARCHAR(50) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_assignments_starts_at ON assignments (starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_status_starts_at ON assignments (status, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_vehicle_starts_at ON assignments (vehicle_id, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_route_starts_at ON assignments (route_id, starts_at, id);
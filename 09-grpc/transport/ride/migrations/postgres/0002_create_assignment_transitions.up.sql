@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS assignment_transitions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    assignment_id VARCHAR(50) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(1024) NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_assignment_transitions_assignment ON assignment_transitions (assignment_id, changed_at);
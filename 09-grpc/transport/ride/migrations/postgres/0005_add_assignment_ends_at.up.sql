@@ -0,0 +1,5 @@
+-- Existing assignments get the default one-hour window. Writers serialise
+-- per vehicle with advisory locks, so no lock table is needed here.
+ALTER TABLE assignments ADD COLUMN IF NOT EXISTS ends_at TIMESTAMPTZ;
+UPDATE assignments SET ends_at = starts_at + INTERVAL '1 hour' WHERE ends_at IS NULL;
+ALTER TABLE assignments ALTER COLUMN ends_at SET NOT NULL;
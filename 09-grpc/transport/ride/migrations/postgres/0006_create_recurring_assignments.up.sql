@@ -0,0 +1,14 @@
+-- Exceptions are stored as comma-separated YYYY-MM-DD dates.
+CREATE TABLE IF NOT EXISTS recurring_assignments (
+    id VARCHAR(50) PRIMARY KEY,
+    vehicle_id VARCHAR(50) NOT NULL,
+    route_id VARCHAR(50) NOT NULL,
+    rrule VARCHAR(500) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    duration_seconds INT NOT NULL,
+    ends_on DATE,
+    exceptions TEXT NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
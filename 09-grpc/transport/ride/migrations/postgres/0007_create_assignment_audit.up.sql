@@ -0,0 +1,13 @@
+-- Append-only: rows are inserted with the change they describe and never
+-- updated or deleted.
+CREATE TABLE IF NOT EXISTS assignment_audit (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    assignment_id VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    before_state JSONB,
+    after_state JSONB NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_assignment_audit_assignment ON assignment_audit (assignment_id, id);
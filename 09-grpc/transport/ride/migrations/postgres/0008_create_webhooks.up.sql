@@ -0,0 +1,31 @@
+-- Event types are stored comma-separated; empty means all events.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id VARCHAR(50) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    event_types VARCHAR(1024) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    disabled_reason VARCHAR(1024) NOT NULL DEFAULT '',
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+-- The payload is kept as sent so that every attempt has the same body.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    webhook_id VARCHAR(50) NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1024) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT uq_webhook_deliveries_event UNIQUE (webhook_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log ON webhook_deliveries (webhook_id, id);
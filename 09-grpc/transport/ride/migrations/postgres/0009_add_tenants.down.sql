@@ -0,0 +1,41 @@
+-- Fails where two tenants share a key: merging tenants needs a decision
+-- about whose rows win.
+DROP INDEX IF EXISTS idx_webhook_deliveries_log;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log ON webhook_deliveries (webhook_id, id);
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS uq_webhook_deliveries_event;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT uq_webhook_deliveries_event UNIQUE (webhook_id, event_id);
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_pkey;
+ALTER TABLE webhooks ADD PRIMARY KEY (id);
+ALTER TABLE webhooks DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE recurring_assignments DROP CONSTRAINT IF EXISTS recurring_assignments_pkey;
+ALTER TABLE recurring_assignments ADD PRIMARY KEY (id);
+ALTER TABLE recurring_assignments DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS idx_assignment_audit_assignment;
+CREATE INDEX IF NOT EXISTS idx_assignment_audit_assignment ON assignment_audit (assignment_id, id);
+ALTER TABLE assignment_audit DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS idx_assignment_transitions_assignment;
+CREATE INDEX IF NOT EXISTS idx_assignment_transitions_assignment ON assignment_transitions (assignment_id, changed_at);
+ALTER TABLE assignment_transitions DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS idx_assignments_route_starts_at;
+DROP INDEX IF EXISTS idx_assignments_vehicle_starts_at;
+DROP INDEX IF EXISTS idx_assignments_status_starts_at;
+DROP INDEX IF EXISTS idx_assignments_starts_at;
+CREATE INDEX IF NOT EXISTS idx_assignments_starts_at ON assignments (starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_status_starts_at ON assignments (status, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_vehicle_starts_at ON assignments (vehicle_id, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_route_starts_at ON assignments (route_id, starts_at, id);
+ALTER TABLE assignments DROP CONSTRAINT IF EXISTS assignments_pkey;
+ALTER TABLE assignments ADD PRIMARY KEY (id);
+ALTER TABLE assignments DROP COLUMN IF EXISTS tenant_id;
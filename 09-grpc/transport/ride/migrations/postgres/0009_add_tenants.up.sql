@@ -0,0 +1,52 @@
+-- Rows written before tenants existed belong to the default tenant. The
+-- default only backfills them: every write names its tenant. Keys start
+-- with tenant_id, so tenants may reuse each other's IDs and idempotency
+-- keys without colliding. Vehicle locks are advisory and keyed by tenant
+-- in the application.
+ALTER TABLE assignments ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE assignments ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE assignments DROP CONSTRAINT IF EXISTS assignments_pkey;
+ALTER TABLE assignments ADD PRIMARY KEY (tenant_id, id);
+DROP INDEX IF EXISTS idx_assignments_starts_at;
+DROP INDEX IF EXISTS idx_assignments_status_starts_at;
+DROP INDEX IF EXISTS idx_assignments_vehicle_starts_at;
+DROP INDEX IF EXISTS idx_assignments_route_starts_at;
+CREATE INDEX IF NOT EXISTS idx_assignments_starts_at ON assignments (tenant_id, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_status_starts_at ON assignments (tenant_id, status, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_vehicle_starts_at ON assignments (tenant_id, vehicle_id, starts_at, id);
+CREATE INDEX IF NOT EXISTS idx_assignments_route_starts_at ON assignments (tenant_id, route_id, starts_at, id);
+
+ALTER TABLE assignment_transitions ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE assignment_transitions ALTER COLUMN tenant_id DROP DEFAULT;
+DROP INDEX IF EXISTS idx_assignment_transitions_assignment;
+CREATE INDEX IF NOT EXISTS idx_assignment_transitions_assignment ON assignment_transitions (tenant_id, assignment_id, changed_at);
+
+ALTER TABLE assignment_audit ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE assignment_audit ALTER COLUMN tenant_id DROP DEFAULT;
+DROP INDEX IF EXISTS idx_assignment_audit_assignment;
+CREATE INDEX IF NOT EXISTS idx_assignment_audit_assignment ON assignment_audit (tenant_id, assignment_id, id);
+
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE outbox ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE idempotency_keys ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, idempotency_key);
+
+ALTER TABLE recurring_assignments ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE recurring_assignments ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE recurring_assignments DROP CONSTRAINT IF EXISTS recurring_assignments_pkey;
+ALTER TABLE recurring_assignments ADD PRIMARY KEY (tenant_id, id);
+
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_pkey;
+ALTER TABLE webhooks ADD PRIMARY KEY (tenant_id, id);
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS uq_webhook_deliveries_event;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT uq_webhook_deliveries_event UNIQUE (tenant_id, webhook_id, event_id);
+DROP INDEX IF EXISTS idx_webhook_deliveries_log;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_log ON webhook_deliveries (tenant_id, webhook_id, id);
@@ -0,0 +1,3 @@
+-- Records whether the vehicle service chose the vehicle of an assignment
+-- created without one.
+ALTER TABLE assignments ADD COLUMN IF NOT EXISTS vehicle_auto_selected BOOLEAN NOT NULL DEFAULT FALSE;
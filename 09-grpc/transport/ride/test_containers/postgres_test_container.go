@@ -0,0 +1,65 @@
+//go:build integration_test
+
+package test_containers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	postgresOnce sync.Once
+	postgresHost string
+	postgresPort string
+)
+
+func GetPostgresContainer(ctx context.Context, db, user, pass string, port *int) (string, string) {
+	postgresOnce.Do(func() {
+		c, err := testContainerRunner{
+			servicePort:  5432,
+			name:         "postgres",
+			image:        "postgres:17.6-alpine", // pin to a stable version
+			exposedPorts: []string{"5432/tcp"},
+			env: map[string]string{
+				"POSTGRES_DB":       db,   // database name
+				"POSTGRES_USER":     user, // superuser
+				"POSTGRES_PASSWORD": pass, // superuser password
+			},
+			hostConfigModifier: postgresHostConfigModifier(port),
+		}.Run(ctx)
+
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to run Test Container")
+		}
+		mp, err := c.MappedPort(ctx, "5432")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to get port")
+		}
+		h, err := c.Host(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to get host")
+		}
+		postgresHost = h
+		postgresPort = mp.Port()
+	})
+	return postgresHost, postgresPort
+}
+
+func postgresHostConfigModifier(port *int) func(hostConfig *container.HostConfig) {
+	return func(hostConfig *container.HostConfig) {
+		hostConfig.AutoRemove = true
+		if port != nil {
+			hostConfig.PortBindings = nat.PortMap{"5432/tcp": []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: fmt.Sprintf("%d", *port),
+				},
+			}}
+		}
+	}
+}
@@ -1,10 +1,19 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"log/slog"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/yourname/transport/vehicle/configs"
 	"github.com/yourname/transport/vehicle/internal/grpcserver"
+	"github.com/yourname/transport/vehicle/internal/ports"
+	"github.com/yourname/transport/vehicle/internal/repository"
 	"github.com/yourname/transport/vehicle/internal/service"
 )
 
@@ -14,7 +23,43 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	var vs service.VehicleService
-	grpcServer := grpcserver.NewGRPCServer(cfg.Server, &vs)
+	// Reservations live in MySQL so that they survive restarts and every
+	// replica sees them. Without a database host they are kept in memory,
+	// which only suits local runs.
+	var reservations ports.ReservationStore
+	if cfg.Database.Host != "" {
+		db, err := openDB(cfg.Database)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err = repository.CreateSchema(ctx, db)
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to create schema: %v", err)
+		}
+		reservations = repository.NewSQLReservationStore(db)
+	} else {
+		log.Printf("no database configured; vehicle reservations are lost on restart")
+		reservations = repository.NewMemoryReservationStore()
+	}
+
+	vs := service.NewVehicleService(nil, reservations, *slog.Default())
+	grpcServer := grpcserver.NewGRPCServer(cfg.Server, vs)
 	grpcserver.Run(grpcServer, cfg.Server.Port)
 }
+
+func openDB(cfg configs.DatabaseConfig) (*sql.DB, error) {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name)
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+	return db, nil
+}
@@ -3,6 +3,7 @@ module github.com/yourname/transport/vehicle
 go 1.25.1
 
 require (
+	github.com/go-sql-driver/mysql v1.9.3
 	github.com/sony/gobreaker v1.0.0
 	google.golang.org/grpc v1.75.1
 	google.golang.org/protobuf v1.36.10
@@ -10,6 +11,7 @@ require (
 )
 
 require (
+	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/stretchr/testify v1.11.1 // indirect
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.10
 // 	protoc        v3.21.12
 // source: vehicle.proto
 
@@ -9,6 +9,7 @@ package vehiclepb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,8 +23,12 @@ const (
 )
 
 type FindRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RouteId       string                 `protobuf:"bytes,1,opt,name=routeId,proto3" json:"routeId,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	RouteId string                 `protobuf:"bytes,1,opt,name=routeId,proto3" json:"routeId,omitempty"`
+	// The window the vehicle is needed for; a vehicle busy during any of it
+	// is not available. Both are optional, but one needs the other.
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=endsAt,proto3" json:"endsAt,omitempty"` // exclusive
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +70,20 @@ func (x *FindRequest) GetRouteId() string {
 	return ""
 }
 
+func (x *FindRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *FindRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
 type FindResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicleId,proto3" json:"vehicleId,omitempty"`
@@ -333,13 +352,223 @@ func (x *AssignmentAck) GetAccepted() bool {
 	return false
 }
 
+type ReserveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservationId,proto3" json:"reservationId,omitempty"`
+	VehicleId     string                 `protobuf:"bytes,2,opt,name=vehicleId,proto3" json:"vehicleId,omitempty"`
+	RouteId       string                 `protobuf:"bytes,3,opt,name=routeId,proto3" json:"routeId,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=endsAt,proto3" json:"endsAt,omitempty"` // exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveRequest) Reset() {
+	*x = ReserveRequest{}
+	mi := &file_vehicle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveRequest) ProtoMessage() {}
+
+func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveRequest.ProtoReflect.Descriptor instead.
+func (*ReserveRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetRouteId() string {
+	if x != nil {
+		return x.RouteId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *ReserveRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type ReserveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservationId,proto3" json:"reservationId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveResponse) Reset() {
+	*x = ReserveResponse{}
+	mi := &file_vehicle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveResponse) ProtoMessage() {}
+
+func (x *ReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveResponse.ProtoReflect.Descriptor instead.
+func (*ReserveResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReserveResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservationId,proto3" json:"reservationId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRequest) Reset() {
+	*x = ReleaseRequest{}
+	mi := &file_vehicle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRequest) ProtoMessage() {}
+
+func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReleaseRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Released      bool                   `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"` // false when there was nothing to release
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseResponse) Reset() {
+	*x = ReleaseResponse{}
+	mi := &file_vehicle_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseResponse) ProtoMessage() {}
+
+func (x *ReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReleaseResponse) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
 var File_vehicle_proto protoreflect.FileDescriptor
 
 const file_vehicle_proto_rawDesc = "" +
 	"\n" +
-	"\rvehicle.proto\x12\tvehiclepb\"'\n" +
+	"\rvehicle.proto\x12\tvehiclepb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x01\n" +
 	"\vFindRequest\x12\x18\n" +
-	"\arouteId\x18\x01 \x01(\tR\arouteId\"D\n" +
+	"\arouteId\x18\x01 \x01(\tR\arouteId\x126\n" +
+	"\bstartsAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x122\n" +
+	"\x06endsAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"D\n" +
 	"\fFindResponse\x12\x1c\n" +
 	"\tvehicleId\x18\x01 \x01(\tR\tvehicleId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\"+\n" +
@@ -355,11 +584,25 @@ const file_vehicle_proto_rawDesc = "" +
 	"\arouteId\x18\x03 \x01(\tR\arouteId\"O\n" +
 	"\rAssignmentAck\x12\"\n" +
 	"\fassignmentId\x18\x01 \x01(\tR\fassignmentId\x12\x1a\n" +
-	"\baccepted\x18\x02 \x01(\bR\baccepted2\xed\x01\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\"\xda\x01\n" +
+	"\x0eReserveRequest\x12$\n" +
+	"\rreservationId\x18\x01 \x01(\tR\rreservationId\x12\x1c\n" +
+	"\tvehicleId\x18\x02 \x01(\tR\tvehicleId\x12\x18\n" +
+	"\arouteId\x18\x03 \x01(\tR\arouteId\x126\n" +
+	"\bstartsAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x122\n" +
+	"\x06endsAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"7\n" +
+	"\x0fReserveResponse\x12$\n" +
+	"\rreservationId\x18\x01 \x01(\tR\rreservationId\"6\n" +
+	"\x0eReleaseRequest\x12$\n" +
+	"\rreservationId\x18\x01 \x01(\tR\rreservationId\"-\n" +
+	"\x0fReleaseResponse\x12\x1a\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased2\xff\x02\n" +
 	"\x0eVehicleService\x12G\n" +
 	"\x14FindAvailableVehicle\x12\x16.vehiclepb.FindRequest\x1a\x17.vehiclepb.FindResponse\x12A\n" +
 	"\x0eGetVehicleInfo\x12\x16.vehiclepb.InfoRequest\x1a\x17.vehiclepb.InfoResponse\x12O\n" +
-	"\x11StreamAssignments\x12\x1c.vehiclepb.AssignmentRequest\x1a\x18.vehiclepb.AssignmentAck(\x010\x01BDZBgithub.com/yourname/transport/vehicle/internal/vehiclepb;vehiclepbb\x06proto3"
+	"\x11StreamAssignments\x12\x1c.vehiclepb.AssignmentRequest\x1a\x18.vehiclepb.AssignmentAck(\x010\x01\x12G\n" +
+	"\x0eReserveVehicle\x12\x19.vehiclepb.ReserveRequest\x1a\x1a.vehiclepb.ReserveResponse\x12G\n" +
+	"\x0eReleaseVehicle\x12\x19.vehiclepb.ReleaseRequest\x1a\x1a.vehiclepb.ReleaseResponseBDZBgithub.com/yourname/transport/vehicle/internal/vehiclepb;vehiclepbb\x06proto3"
 
 var (
 	file_vehicle_proto_rawDescOnce sync.Once
@@ -373,27 +616,40 @@ func file_vehicle_proto_rawDescGZIP() []byte {
 	return file_vehicle_proto_rawDescData
 }
 
-var file_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_vehicle_proto_goTypes = []any{
-	(*FindRequest)(nil),       // 0: vehiclepb.FindRequest
-	(*FindResponse)(nil),      // 1: vehiclepb.FindResponse
-	(*InfoRequest)(nil),       // 2: vehiclepb.InfoRequest
-	(*InfoResponse)(nil),      // 3: vehiclepb.InfoResponse
-	(*AssignmentRequest)(nil), // 4: vehiclepb.AssignmentRequest
-	(*AssignmentAck)(nil),     // 5: vehiclepb.AssignmentAck
+	(*FindRequest)(nil),           // 0: vehiclepb.FindRequest
+	(*FindResponse)(nil),          // 1: vehiclepb.FindResponse
+	(*InfoRequest)(nil),           // 2: vehiclepb.InfoRequest
+	(*InfoResponse)(nil),          // 3: vehiclepb.InfoResponse
+	(*AssignmentRequest)(nil),     // 4: vehiclepb.AssignmentRequest
+	(*AssignmentAck)(nil),         // 5: vehiclepb.AssignmentAck
+	(*ReserveRequest)(nil),        // 6: vehiclepb.ReserveRequest
+	(*ReserveResponse)(nil),       // 7: vehiclepb.ReserveResponse
+	(*ReleaseRequest)(nil),        // 8: vehiclepb.ReleaseRequest
+	(*ReleaseResponse)(nil),       // 9: vehiclepb.ReleaseResponse
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 }
 var file_vehicle_proto_depIdxs = []int32{
-	0, // 0: vehiclepb.VehicleService.FindAvailableVehicle:input_type -> vehiclepb.FindRequest
-	2, // 1: vehiclepb.VehicleService.GetVehicleInfo:input_type -> vehiclepb.InfoRequest
-	4, // 2: vehiclepb.VehicleService.StreamAssignments:input_type -> vehiclepb.AssignmentRequest
-	1, // 3: vehiclepb.VehicleService.FindAvailableVehicle:output_type -> vehiclepb.FindResponse
-	3, // 4: vehiclepb.VehicleService.GetVehicleInfo:output_type -> vehiclepb.InfoResponse
-	5, // 5: vehiclepb.VehicleService.StreamAssignments:output_type -> vehiclepb.AssignmentAck
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: vehiclepb.FindRequest.startsAt:type_name -> google.protobuf.Timestamp
+	10, // 1: vehiclepb.FindRequest.endsAt:type_name -> google.protobuf.Timestamp
+	10, // 2: vehiclepb.ReserveRequest.startsAt:type_name -> google.protobuf.Timestamp
+	10, // 3: vehiclepb.ReserveRequest.endsAt:type_name -> google.protobuf.Timestamp
+	0,  // 4: vehiclepb.VehicleService.FindAvailableVehicle:input_type -> vehiclepb.FindRequest
+	2,  // 5: vehiclepb.VehicleService.GetVehicleInfo:input_type -> vehiclepb.InfoRequest
+	4,  // 6: vehiclepb.VehicleService.StreamAssignments:input_type -> vehiclepb.AssignmentRequest
+	6,  // 7: vehiclepb.VehicleService.ReserveVehicle:input_type -> vehiclepb.ReserveRequest
+	8,  // 8: vehiclepb.VehicleService.ReleaseVehicle:input_type -> vehiclepb.ReleaseRequest
+	1,  // 9: vehiclepb.VehicleService.FindAvailableVehicle:output_type -> vehiclepb.FindResponse
+	3,  // 10: vehiclepb.VehicleService.GetVehicleInfo:output_type -> vehiclepb.InfoResponse
+	5,  // 11: vehiclepb.VehicleService.StreamAssignments:output_type -> vehiclepb.AssignmentAck
+	7,  // 12: vehiclepb.VehicleService.ReserveVehicle:output_type -> vehiclepb.ReserveResponse
+	9,  // 13: vehiclepb.VehicleService.ReleaseVehicle:output_type -> vehiclepb.ReleaseResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_vehicle_proto_init() }
@@ -407,7 +663,7 @@ func file_vehicle_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vehicle_proto_rawDesc), len(file_vehicle_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,15 +22,29 @@ const (
 	VehicleService_FindAvailableVehicle_FullMethodName = "/vehiclepb.VehicleService/FindAvailableVehicle"
 	VehicleService_GetVehicleInfo_FullMethodName       = "/vehiclepb.VehicleService/GetVehicleInfo"
 	VehicleService_StreamAssignments_FullMethodName    = "/vehiclepb.VehicleService/StreamAssignments"
+	VehicleService_ReserveVehicle_FullMethodName       = "/vehiclepb.VehicleService/ReserveVehicle"
+	VehicleService_ReleaseVehicle_FullMethodName       = "/vehiclepb.VehicleService/ReleaseVehicle"
 )
 
 // VehicleServiceClient is the client API for VehicleService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VehicleServiceClient interface {
+	// FindAvailableVehicle picks a vehicle serving the route that is free for
+	// the requested window. It fails with NOT_FOUND when there is none.
 	FindAvailableVehicle(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindResponse, error)
 	GetVehicleInfo(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
 	StreamAssignments(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AssignmentRequest, AssignmentAck], error)
+	// ReserveVehicle holds a vehicle for a time window. Reservations belong
+	// to the tenant named by the x-tenant-id metadata, "default" without it.
+	// Calls are idempotent by reservationId; repeating one with another
+	// vehicle or window moves the reservation. A window overlapping another
+	// reservation of the vehicle in the same tenant fails with ABORTED.
+	ReserveVehicle(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
+	// ReleaseVehicle drops a reservation of the x-tenant-id tenant. Releasing
+	// an unknown or already released reservation succeeds, so callers may
+	// retry it.
+	ReleaseVehicle(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
 }
 
 type vehicleServiceClient struct {
@@ -74,13 +88,45 @@ func (c *vehicleServiceClient) StreamAssignments(ctx context.Context, opts ...gr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VehicleService_StreamAssignmentsClient = grpc.BidiStreamingClient[AssignmentRequest, AssignmentAck]
 
+func (c *vehicleServiceClient) ReserveVehicle(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ReserveVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) ReleaseVehicle(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ReleaseVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VehicleServiceServer is the server API for VehicleService service.
 // All implementations must embed UnimplementedVehicleServiceServer
 // for forward compatibility.
 type VehicleServiceServer interface {
+	// FindAvailableVehicle picks a vehicle serving the route that is free for
+	// the requested window. It fails with NOT_FOUND when there is none.
 	FindAvailableVehicle(context.Context, *FindRequest) (*FindResponse, error)
 	GetVehicleInfo(context.Context, *InfoRequest) (*InfoResponse, error)
 	StreamAssignments(grpc.BidiStreamingServer[AssignmentRequest, AssignmentAck]) error
+	// ReserveVehicle holds a vehicle for a time window. Reservations belong
+	// to the tenant named by the x-tenant-id metadata, "default" without it.
+	// Calls are idempotent by reservationId; repeating one with another
+	// vehicle or window moves the reservation. A window overlapping another
+	// reservation of the vehicle in the same tenant fails with ABORTED.
+	ReserveVehicle(context.Context, *ReserveRequest) (*ReserveResponse, error)
+	// ReleaseVehicle drops a reservation of the x-tenant-id tenant. Releasing
+	// an unknown or already released reservation succeeds, so callers may
+	// retry it.
+	ReleaseVehicle(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
 	mustEmbedUnimplementedVehicleServiceServer()
 }
 
@@ -100,6 +146,12 @@ func (UnimplementedVehicleServiceServer) GetVehicleInfo(context.Context, *InfoRe
 func (UnimplementedVehicleServiceServer) StreamAssignments(grpc.BidiStreamingServer[AssignmentRequest, AssignmentAck]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamAssignments not implemented")
 }
+func (UnimplementedVehicleServiceServer) ReserveVehicle(context.Context, *ReserveRequest) (*ReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) ReleaseVehicle(context.Context, *ReleaseRequest) (*ReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseVehicle not implemented")
+}
 func (UnimplementedVehicleServiceServer) mustEmbedUnimplementedVehicleServiceServer() {}
 func (UnimplementedVehicleServiceServer) testEmbeddedByValue()                        {}
 
@@ -164,6 +216,42 @@ func _VehicleService_StreamAssignments_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VehicleService_StreamAssignmentsServer = grpc.BidiStreamingServer[AssignmentRequest, AssignmentAck]
 
+func _VehicleService_ReserveVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ReserveVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ReserveVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ReserveVehicle(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_ReleaseVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ReleaseVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ReleaseVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ReleaseVehicle(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VehicleService_ServiceDesc is the grpc.ServiceDesc for VehicleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -179,6 +267,14 @@ var VehicleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVehicleInfo",
 			Handler:    _VehicleService_GetVehicleInfo_Handler,
 		},
+		{
+			MethodName: "ReserveVehicle",
+			Handler:    _VehicleService_ReserveVehicle_Handler,
+		},
+		{
+			MethodName: "ReleaseVehicle",
+			Handler:    _VehicleService_ReleaseVehicle_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
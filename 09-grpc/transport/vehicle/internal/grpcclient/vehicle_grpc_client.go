@@ -73,6 +73,32 @@ func (c *VehicleGrpcClient) Close() error {
 }
 
 func (c *VehicleGrpcClient) FindAvailableVehicle(ctx context.Context, req *vehiclepb.FindRequest) (*vehiclepb.FindResponse, error) {
+	client, err := c.typed()
+	if err != nil {
+		return nil, err
+	}
+	return callWithBreaker(ctx, c.cb, client.FindAvailableVehicle, req)
+}
+
+// ReserveVehicle holds a vehicle; retrying with the same reservation ID is safe.
+func (c *VehicleGrpcClient) ReserveVehicle(ctx context.Context, req *vehiclepb.ReserveRequest) (*vehiclepb.ReserveResponse, error) {
+	client, err := c.typed()
+	if err != nil {
+		return nil, err
+	}
+	return callWithBreaker(ctx, c.cb, client.ReserveVehicle, req)
+}
+
+// ReleaseVehicle drops a reservation; releasing it twice is safe.
+func (c *VehicleGrpcClient) ReleaseVehicle(ctx context.Context, req *vehiclepb.ReleaseRequest) (*vehiclepb.ReleaseResponse, error) {
+	client, err := c.typed()
+	if err != nil {
+		return nil, err
+	}
+	return callWithBreaker(ctx, c.cb, client.ReleaseVehicle, req)
+}
+
+func (c *VehicleGrpcClient) typed() (vehiclepb.VehicleServiceClient, error) {
 	c.mu.RLock()
 	client := c.client
 	c.mu.RUnlock()
@@ -80,13 +106,13 @@ func (c *VehicleGrpcClient) FindAvailableVehicle(ctx context.Context, req *vehic
 	if client == nil {
 		return nil, ErrNotDialed
 	}
-	return c.callWithBreaker(ctx, client, req)
+	return client, nil
 }
 
-func (c *VehicleGrpcClient) callWithBreaker(ctx context.Context, client vehiclepb.VehicleServiceClient, req *vehiclepb.FindRequest) (*vehiclepb.FindResponse, error) {
-	var out *vehiclepb.FindResponse
-	_, err := c.cb.Execute(func() (any, error) {
-		resp, err := client.FindAvailableVehicle(ctx, req)
+func callWithBreaker[Req, Resp any](ctx context.Context, cb *gobreaker.CircuitBreaker, call func(context.Context, Req, ...grpc.CallOption) (Resp, error), req Req) (Resp, error) {
+	var out Resp
+	_, err := cb.Execute(func() (any, error) {
+		resp, err := call(ctx, req)
 		if err != nil {
 			// map retryable codes to errors that count toward the breaker
 			if st, ok := status.FromError(err); ok {
@@ -102,7 +128,8 @@ func (c *VehicleGrpcClient) callWithBreaker(ctx context.Context, client vehiclep
 		return nil, nil
 	})
 	if err != nil {
-		return nil, err
+		var zero Resp
+		return zero, err
 	}
 	return out, nil
 }
@@ -126,8 +153,8 @@ func newCircuitBreaker() *gobreaker.CircuitBreaker {
 			}
 			if st, ok := status.FromError(err); ok {
 				switch st.Code() {
-				// caller bugs or permanent errors: don't trip the breaker
-				case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+				// caller bugs, permanent errors and refused reservations: don't trip the breaker
+				case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied, codes.Aborted:
 					return true
 				}
 			}
@@ -0,0 +1,66 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorUnaryInterceptor converts domain errors returned by handlers into gRPC
+// status errors, so clients can branch on codes instead of parsing messages.
+func ErrorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatusError(info.FullMethod, err)
+	}
+	return res, nil
+}
+
+// ErrorStreamInterceptor is the streaming counterpart of ErrorUnaryInterceptor.
+func ErrorStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return toStatusError(info.FullMethod, err)
+	}
+	return nil
+}
+
+func toStatusError(method string, err error) error {
+	// Errors that already carry a status (e.g. from a downstream call) pass through.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codeFor(err)
+	if code == codes.Internal {
+		// Never leak internal details to clients; keep them in the server log.
+		log.Printf("method=%s internal error: %v", method, err)
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, models.ErrorMessage(err, err.Error()))
+}
+
+func codeFor(err error) codes.Code {
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, models.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, models.ErrConflict):
+		return codes.Aborted
+	case errors.Is(err, models.ErrPreconditionFailed):
+		return codes.FailedPrecondition
+	case errors.Is(err, models.ErrUnavailable):
+		return codes.Unavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
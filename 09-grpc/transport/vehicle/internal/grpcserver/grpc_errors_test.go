@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorUnaryInterceptorMapsDomainErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		want    codes.Code
+		wantMsg string
+	}{
+		{name: "validation", err: models.NewValidationError("routeId is required"), want: codes.InvalidArgument, wantMsg: "routeId is required"},
+		{name: "not found", err: models.NewNotFoundError("vehicle %s not found", "v1"), want: codes.NotFound, wantMsg: "vehicle v1 not found"},
+		{name: "conflict", err: models.NewConflictError("already reserved"), want: codes.Aborted},
+		{name: "precondition", err: models.NewPreconditionFailedError("version mismatch"), want: codes.FailedPrecondition},
+		{name: "unavailable", err: models.NewUnavailableError(errors.New("dial tcp"), "database unavailable"), want: codes.Unavailable, wantMsg: "database unavailable"},
+		{name: "wrapped", err: fmt.Errorf("lookup: %w", models.NewNotFoundError("gone")), want: codes.NotFound},
+		{name: "deadline", err: context.DeadlineExceeded, want: codes.DeadlineExceeded},
+		{name: "unclassified", err: errors.New("boom"), want: codes.Internal, wantMsg: "internal error"},
+		{name: "status passthrough", err: status.Error(codes.ResourceExhausted, "slow down"), want: codes.ResourceExhausted, wantMsg: "slow down"},
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/vehiclepb.VehicleService/FindAvailableVehicle"}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ErrorUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+				return nil, tc.err
+			})
+
+			st, ok := status.FromError(err)
+			if !ok {
+				t.Fatalf("expected gRPC status error, got: %v", err)
+			}
+			if st.Code() != tc.want {
+				t.Fatalf("expected code %v, got %v", tc.want, st.Code())
+			}
+			if tc.wantMsg != "" && st.Message() != tc.wantMsg {
+				t.Fatalf("expected message %q, got %q", tc.wantMsg, st.Message())
+			}
+		})
+	}
+}
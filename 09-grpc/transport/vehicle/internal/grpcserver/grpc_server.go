@@ -18,8 +18,9 @@ import (
 func NewGRPCServer(cfg configs.ServerConfig, service vehiclepb.VehicleServiceServer) *grpc.Server {
 	// Prepare TLS credentials if enabled (see TLS section)
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(LoggingUnaryInterceptor),
-		grpc.StreamInterceptor(LoggingStreamInterceptor),
+		// Logging runs outermost so it records the mapped status, not the raw error.
+		grpc.ChainUnaryInterceptor(LoggingUnaryInterceptor, ErrorUnaryInterceptor),
+		grpc.ChainStreamInterceptor(LoggingStreamInterceptor, ErrorStreamInterceptor),
 		grpc.ConnectionTimeout(time.Duration(cfg.ConnectionTimeoutSec) * time.Second),
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"sync"
@@ -12,11 +13,16 @@ import (
 
 	"github.com/yourname/transport/vehicle/configs"
 	vehiclepb "github.com/yourname/transport/vehicle/internal/grpc"
+	"github.com/yourname/transport/vehicle/internal/repository"
 	"github.com/yourname/transport/vehicle/internal/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // bufSize keeps the in-memory listener large enough for typical test payloads.
@@ -75,6 +81,16 @@ func TestNewGRPCServerServesRequests(t *testing.T) {
 	if resp.GetVehicleId() != "bus-123" || resp.GetStatus() != "available" {
 		t.Fatalf("unexpected response: %+v", resp)
 	}
+
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	_, err = client.FindAvailableVehicle(ctx, &vehiclepb.FindRequest{
+		RouteId:  "route-42",
+		StartsAt: timestamppb.New(start),
+		EndsAt:   timestamppb.New(start.Add(-time.Hour)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a reversed window, got %v", err)
+	}
 }
 
 func TestNewGRPCServerHandlesConcurrentRequests(t *testing.T) {
@@ -107,6 +123,98 @@ func TestNewGRPCServerHandlesConcurrentRequests(t *testing.T) {
 	}
 }
 
+func TestNewGRPCServerReservesVehicles(t *testing.T) {
+	vhs := service.NewVehicleService(nil, repository.NewMemoryReservationStore(), *slog.Default())
+	_, lis := startBufconnServer(t, vhs)
+	client := vehiclepb.NewVehicleServiceClient(dialBufconn(t, lis))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	beta := metadata.AppendToOutgoingContext(ctx, service.TenantKey, "beta")
+
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	reserve := func(ctx context.Context, id, vehicle string, from time.Time) error {
+		_, err := client.ReserveVehicle(ctx, &vehiclepb.ReserveRequest{
+			ReservationId: id,
+			VehicleId:     vehicle,
+			RouteId:       "route-42",
+			StartsAt:      timestamppb.New(from),
+			EndsAt:        timestamppb.New(from.Add(time.Hour)),
+		})
+		return err
+	}
+
+	if err := reserve(ctx, "r1", "bus-123", start); err != nil {
+		t.Fatalf("ReserveVehicle failed: %v", err)
+	}
+	if err := reserve(ctx, "r1", "bus-123", start); err != nil {
+		t.Fatalf("retrying ReserveVehicle failed: %v", err)
+	}
+	// The cases run in order, each on the reservations the earlier left.
+	testCases := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "overlapping window", err: reserve(ctx, "r2", "bus-123", start.Add(30*time.Minute)), want: codes.Aborted},
+		{name: "moved to another vehicle", err: reserve(ctx, "r1", "bus-456", start), want: codes.OK},
+		{name: "window freed by the move", err: reserve(ctx, "r2", "bus-123", start.Add(30*time.Minute)), want: codes.OK},
+		{name: "moved into another reservation", err: reserve(ctx, "r2", "bus-456", start), want: codes.Aborted},
+		{name: "same vehicle and id in another tenant", err: reserve(beta, "r1", "bus-456", start), want: codes.OK},
+		{name: "missing vehicle", err: reserve(ctx, "r3", "", start), want: codes.InvalidArgument},
+		{name: "adjacent window", err: reserve(ctx, "r4", "bus-123", start.Add(90*time.Minute)), want: codes.OK},
+	}
+	for _, tc := range testCases {
+		if got := status.Code(tc.err); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, tc.err)
+		}
+	}
+
+	find := func(ctx context.Context, from time.Time) error {
+		_, err := client.FindAvailableVehicle(ctx, &vehiclepb.FindRequest{
+			RouteId:  "route-42",
+			StartsAt: timestamppb.New(from),
+			EndsAt:   timestamppb.New(from.Add(time.Hour)),
+		})
+		return err
+	}
+	if err := find(ctx, start); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound while bus-123 is reserved, got %v", err)
+	}
+	if err := find(ctx, start.Add(3*time.Hour)); err != nil {
+		t.Fatalf("expected bus-123 free after its reservations, got %v", err)
+	}
+	if err := find(beta, start); err != nil {
+		t.Fatalf("expected bus-123 free in another tenant, got %v", err)
+	}
+
+	for _, want := range []bool{true, false} {
+		resp, err := client.ReleaseVehicle(ctx, &vehiclepb.ReleaseRequest{ReservationId: "r1"})
+		if err != nil || resp.GetReleased() != want {
+			t.Fatalf("expected released=%v, got %+v %v", want, resp, err)
+		}
+	}
+	if err := reserve(ctx, "r5", "bus-456", start); err != nil {
+		t.Fatalf("expected the released window to be free, got %v", err)
+	}
+	if err := reserve(beta, "r5", "bus-456", start); status.Code(err) != codes.Aborted {
+		t.Fatalf("expected the reservation of the other tenant to stay, got %v", err)
+	}
+}
+
+func TestNewGRPCServerWithoutReservationStore(t *testing.T) {
+	var vhs service.VehicleService
+	_, lis := startBufconnServer(t, &vhs)
+	client := vehiclepb.NewVehicleServiceClient(dialBufconn(t, lis))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := client.ReleaseVehicle(ctx, &vehiclepb.ReleaseRequest{ReservationId: "r1"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+}
+
 func TestRunServesAndStopsOnSignal(t *testing.T) {
 	port := freePort(t)
 	var vhs service.VehicleService
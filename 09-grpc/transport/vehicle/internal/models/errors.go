@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds shared by repositories and services. Adapters test for them
+// with errors.Is and translate them into transport codes (HTTP status,
+// gRPC status); nothing below the adapters should know about transports.
+// Service-local copy of ride/internal/models/errors.go, kept separate because
+// the services are independent modules; keep the two in sync.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrValidation         = errors.New("validation failed")
+	ErrConflict           = errors.New("conflict")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrUnavailable        = errors.New("unavailable")
+)
+
+// DomainError pairs an error kind with a message that is safe to return to
+// API clients. The optional cause is kept for logs and errors.Is/As only.
+type DomainError struct {
+	Kind    error
+	Message string
+	Cause   error
+}
+
+func (e *DomainError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is / errors.As.
+func (e *DomainError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Kind, e.Cause}
+	}
+	return []error{e.Kind}
+}
+
+func NewNotFoundError(format string, args ...any) error {
+	return &DomainError{Kind: ErrNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewValidationError(format string, args ...any) error {
+	return &DomainError{Kind: ErrValidation, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewConflictError(format string, args ...any) error {
+	return &DomainError{Kind: ErrConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewPreconditionFailedError(format string, args ...any) error {
+	return &DomainError{Kind: ErrPreconditionFailed, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewUnavailableError marks a dependency failure (database, broker, remote
+// service) that a client may retry later.
+func NewUnavailableError(cause error, format string, args ...any) error {
+	return &DomainError{Kind: ErrUnavailable, Message: fmt.Sprintf(format, args...), Cause: cause}
+}
+
+// ErrorMessage returns the client-safe message of a DomainError, or the
+// fallback when err carries none.
+func ErrorMessage(err error, fallback string) string {
+	var de *DomainError
+	if errors.As(err, &de) && de.Message != "" {
+		return de.Message
+	}
+	return fallback
+}
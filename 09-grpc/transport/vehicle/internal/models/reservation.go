@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Reservation holds a vehicle of a tenant for the half-open window
+// [StartsAt, EndsAt). IDs are chosen by the caller and are unique within a
+// tenant; tenants may reuse each other's reservation and vehicle IDs.
+type Reservation struct {
+	TenantID  string
+	ID        string
+	VehicleID string
+	RouteID   string
+	StartsAt  time.Time
+	EndsAt    time.Time
+}
+
+// Overlaps reports whether r and o hold the same vehicle of the same tenant
+// at the same time.
+func (r Reservation) Overlaps(o Reservation) bool {
+	return r.TenantID == o.TenantID && r.VehicleID == o.VehicleID && r.StartsAt.Before(o.EndsAt) && o.StartsAt.Before(r.EndsAt)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+)
+
+// ReservationStore keeps vehicle reservations per tenant.
+type ReservationStore interface {
+	// Save stores r, replacing the reservation of the same tenant and ID.
+	// It fails with models.ErrConflict, storing nothing, when r overlaps
+	// another reservation of the tenant's vehicle; the check and the write
+	// are atomic.
+	Save(ctx context.Context, r models.Reservation) error
+	// Reserved reports whether a reservation of the tenant holds vehicleID
+	// at some time in [startsAt, endsAt).
+	Reserved(ctx context.Context, tenantID, vehicleID string, startsAt, endsAt time.Time) (bool, error)
+	// Delete removes a reservation and reports whether there was one.
+	Delete(ctx context.Context, tenantID, id string) (bool, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+)
+
+// mapSQLError translates driver errors into domain errors so that the
+// service never has to inspect MySQL errors itself.
+func mapSQLError(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+
+	// A cancelled query means the caller went away, not the database; it
+	// passes through so that callers can tell the two apart.
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr) {
+		return models.NewUnavailableError(err, "%s: database unavailable", op)
+	}
+
+	return err
+}
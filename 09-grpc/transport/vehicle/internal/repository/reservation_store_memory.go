@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+	"github.com/yourname/transport/vehicle/internal/ports"
+)
+
+type reservationKey struct{ tenantID, id string }
+
+// memoryReservationStore keeps reservations in process memory; they are
+// lost on restart, so it only suits tests and local runs.
+type memoryReservationStore struct {
+	mu           sync.Mutex
+	reservations map[reservationKey]models.Reservation
+}
+
+func NewMemoryReservationStore() ports.ReservationStore {
+	return &memoryReservationStore{reservations: map[reservationKey]models.Reservation{}}
+}
+
+func (st *memoryReservationStore) Save(ctx context.Context, r models.Reservation) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	own := reservationKey{r.TenantID, r.ID}
+	for key, other := range st.reservations {
+		if key != own && r.Overlaps(other) {
+			return models.NewConflictError("vehicle %s is already reserved by %s for an overlapping window", r.VehicleID, key.id)
+		}
+	}
+	st.reservations[own] = r
+	return nil
+}
+
+func (st *memoryReservationStore) Reserved(ctx context.Context, tenantID, vehicleID string, startsAt, endsAt time.Time) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	window := models.Reservation{TenantID: tenantID, VehicleID: vehicleID, StartsAt: startsAt, EndsAt: endsAt}
+	for _, r := range st.reservations {
+		if window.Overlaps(r) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (st *memoryReservationStore) Delete(ctx context.Context, tenantID, id string) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	key := reservationKey{tenantID, id}
+	_, ok := st.reservations[key]
+	delete(st.reservations, key)
+	return ok, nil
+}
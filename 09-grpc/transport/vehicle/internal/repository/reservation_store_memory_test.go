@@ -0,0 +1,63 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+	"github.com/yourname/transport/vehicle/internal/repository"
+)
+
+func TestMemoryReservationStore(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	at := func(tenant, id, vehicle string, from time.Time) models.Reservation {
+		return models.Reservation{TenantID: tenant, ID: id, VehicleID: vehicle, StartsAt: from, EndsAt: from.Add(time.Hour)}
+	}
+
+	store := repository.NewMemoryReservationStore()
+	if err := store.Save(ctx, at("acme", "r1", "bus-1", start)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// The cases run in order, each on the reservations the earlier left.
+	testCases := []struct {
+		name    string
+		r       models.Reservation
+		wantErr error
+	}{
+		{name: "repeated", r: at("acme", "r1", "bus-1", start)},
+		{name: "overlapping", r: at("acme", "r2", "bus-1", start.Add(30*time.Minute)), wantErr: models.ErrConflict},
+		{name: "same vehicle in another tenant", r: at("beta", "r2", "bus-1", start)},
+		{name: "moved", r: at("acme", "r1", "bus-1", start.Add(2*time.Hour))},
+		{name: "window freed by the move", r: at("acme", "r2", "bus-1", start)},
+	}
+	for _, tc := range testCases {
+		if err := store.Save(ctx, tc.r); !errors.Is(err, tc.wantErr) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+
+	for _, want := range []bool{true, false} {
+		if deleted, err := store.Delete(ctx, "acme", "r2"); err != nil || deleted != want {
+			t.Fatalf("expected deleted=%v, got %v %v", want, deleted, err)
+		}
+	}
+	if err := store.Save(ctx, at("beta", "r3", "bus-1", start.Add(30*time.Minute))); !errors.Is(err, models.ErrConflict) {
+		t.Fatalf("expected the reservation of beta to stay, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		from time.Time
+		want bool
+	}{
+		{from: start, want: false}, // held by beta only
+		{from: start.Add(150 * time.Minute), want: true},
+	} {
+		if reserved, err := store.Reserved(ctx, "acme", "bus-1", tc.from, tc.from.Add(time.Hour)); err != nil || reserved != tc.want {
+			t.Fatalf("from %s: expected reserved=%v, got %v %v", tc.from, tc.want, reserved, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourname/transport/vehicle/internal/models"
+	"github.com/yourname/transport/vehicle/internal/ports"
+)
+
+//go:embed schema.sql
+var schema string
+
+// CreateSchema creates the tables of the SQL stores that do not exist yet.
+// It is safe to run on every start.
+func CreateSchema(ctx context.Context, db *sql.DB) error {
+	for _, stmt := range strings.Split(schema, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return mapSQLError(err, "create schema")
+		}
+	}
+	return nil
+}
+
+type sqlReservationStore struct {
+	db *sql.DB
+}
+
+// NewSQLReservationStore keeps reservations in MySQL, so they survive
+// restarts and are shared by every replica.
+func NewSQLReservationStore(db *sql.DB) ports.ReservationStore {
+	return &sqlReservationStore{db: db}
+}
+
+func (st *sqlReservationStore) Save(ctx context.Context, r models.Reservation) error {
+	tx, err := st.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapSQLError(err, "begin transaction")
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	// The upsert takes an exclusive lock on the vehicle's row whether or
+	// not it existed, which SELECT ... FOR UPDATE cannot do for a vehicle's
+	// first reservation. The overlap check below is this transaction's
+	// first consistent read, so it sees every reservation committed by the
+	// previous lock holder.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO vehicle_reservation_locks (tenant_id, vehicle_id) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE vehicle_id = vehicle_id`, r.TenantID, r.VehicleID,
+	); err != nil {
+		return mapSQLError(err, "lock vehicle reservations")
+	}
+
+	var clash string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM vehicle_reservations
+		WHERE tenant_id = ? AND vehicle_id = ? AND id <> ? AND starts_at < ? AND ends_at > ?
+		LIMIT 1`, r.TenantID, r.VehicleID, r.ID, r.EndsAt, r.StartsAt,
+	).Scan(&clash)
+	switch {
+	case err == nil:
+		return models.NewConflictError("vehicle %s is already reserved by %s for an overlapping window", r.VehicleID, clash)
+	case !errors.Is(err, sql.ErrNoRows):
+		return mapSQLError(err, "check vehicle reservations")
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO vehicle_reservations (tenant_id, id, vehicle_id, route_id, starts_at, ends_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		    vehicle_id = VALUES(vehicle_id),
+		    route_id   = VALUES(route_id),
+		    starts_at  = VALUES(starts_at),
+		    ends_at    = VALUES(ends_at)`,
+		r.TenantID, r.ID, r.VehicleID, r.RouteID, r.StartsAt.UTC(), r.EndsAt.UTC(),
+	); err != nil {
+		return mapSQLError(err, "save reservation")
+	}
+	return mapSQLError(tx.Commit(), "save reservation")
+}
+
+func (st *sqlReservationStore) Reserved(ctx context.Context, tenantID, vehicleID string, startsAt, endsAt time.Time) (bool, error) {
+	var one int
+	err := st.db.QueryRowContext(ctx, `
+		SELECT 1 FROM vehicle_reservations
+		WHERE tenant_id = ? AND vehicle_id = ? AND starts_at < ? AND ends_at > ?
+		LIMIT 1`, tenantID, vehicleID, endsAt.UTC(), startsAt.UTC(),
+	).Scan(&one)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	default:
+		return false, mapSQLError(err, "check vehicle reservations")
+	}
+}
+
+func (st *sqlReservationStore) Delete(ctx context.Context, tenantID, id string) (bool, error) {
+	res, err := st.db.ExecContext(ctx, `DELETE FROM vehicle_reservations WHERE tenant_id = ? AND id = ?`, tenantID, id)
+	if err != nil {
+		return false, mapSQLError(err, "delete reservation")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("delete reservation: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,20 @@
+-- Reservations are keyed by tenant, so tenants may reuse each other's
+-- reservation and vehicle IDs without colliding.
+CREATE TABLE IF NOT EXISTS vehicle_reservations (
+    tenant_id VARCHAR(50) NOT NULL,
+    id VARCHAR(50) NOT NULL,
+    vehicle_id VARCHAR(50) NOT NULL,
+    route_id VARCHAR(50) NOT NULL,
+    starts_at DATETIME(6) NOT NULL,
+    ends_at DATETIME(6) NOT NULL,
+    PRIMARY KEY (tenant_id, id),
+    INDEX idx_vehicle_reservations_vehicle_starts_at (tenant_id, vehicle_id, starts_at)
+);
+
+-- One row per vehicle that has ever been reserved; Save locks it to
+-- serialise writes to the vehicle's reservations.
+CREATE TABLE IF NOT EXISTS vehicle_reservation_locks (
+    tenant_id VARCHAR(50) NOT NULL,
+    vehicle_id VARCHAR(50) NOT NULL,
+    PRIMARY KEY (tenant_id, vehicle_id)
+);
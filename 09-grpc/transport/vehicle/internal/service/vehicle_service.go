@@ -4,9 +4,12 @@ import (
 	"context"
 	"log"
 	"log/slog"
+	"strings"
 
 	vehiclepb "github.com/yourname/transport/vehicle/internal/grpc"
+	"github.com/yourname/transport/vehicle/internal/models"
 	"github.com/yourname/transport/vehicle/internal/ports"
+	"google.golang.org/grpc/metadata"
 )
 
 // VehicleService is our concrete implementation of the gRPC VehicleServiceServer interface.
@@ -22,12 +25,17 @@ type VehicleService struct {
 	// Keeping dependencies as fields makes testing easier (use mocks or fakes).
 	repo   ports.VehicleRepository
 	logger slog.Logger
+
+	// reservations holds vehicles for the ride service's assignments. The
+	// zero VehicleService has none and refuses to reserve.
+	reservations ports.ReservationStore
 }
 
-func NewVehicleService(repo ports.VehicleRepository, logger slog.Logger) *VehicleService {
+func NewVehicleService(repo ports.VehicleRepository, reservations ports.ReservationStore, logger slog.Logger) *VehicleService {
 	return &VehicleService{
-		repo:   repo,
-		logger: logger,
+		repo:         repo,
+		reservations: reservations,
+		logger:       logger,
 	}
 }
 
@@ -35,13 +43,37 @@ func NewVehicleService(repo ports.VehicleRepository, logger slog.Logger) *Vehicl
 // Notice how the parameter is a *FindRequest generated from the proto file.
 // This keeps the wire format (protobuf) separate from the core domain.
 // Inside, you would call your domain service (business logic) instead of hardcoding.
-// For now, we return a dummy bus-123 to keep the example runnable.
+// For now, we return a dummy bus-123 to keep the example runnable, unless a
+// reservation of the tenant holds it during the requested window.
 func (s *VehicleService) FindAvailableVehicle(ctx context.Context, req *vehiclepb.FindRequest) (*vehiclepb.FindResponse, error) {
 	log.Printf("FindAvailableVehicle called with routeId=%s", req.GetRouteId())
+	var problems []string
+	if req.GetRouteId() == "" {
+		problems = append(problems, "routeId is required")
+	}
+	switch {
+	case (req.GetStartsAt() == nil) != (req.GetEndsAt() == nil):
+		problems = append(problems, "startsAt and endsAt go together")
+	case req.GetStartsAt() != nil && !req.GetEndsAt().AsTime().After(req.GetStartsAt().AsTime()):
+		problems = append(problems, "endsAt must be after startsAt")
+	}
+	if len(problems) > 0 {
+		return nil, models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
 
 	// TODO: connect this to your domain or repository instead of hardcoding
+	const candidate = "bus-123"
+	if req.GetStartsAt() != nil && s.reservations != nil {
+		reserved, err := s.reservations.Reserved(ctx, tenantOf(ctx), candidate, req.GetStartsAt().AsTime(), req.GetEndsAt().AsTime())
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, models.NewNotFoundError("no vehicle on route %s is free for the requested window", req.GetRouteId())
+		}
+	}
 	return &vehiclepb.FindResponse{
-		VehicleId: "bus-123",
+		VehicleId: candidate,
 		Status:    "available",
 	}, nil
 }
@@ -51,6 +83,9 @@ func (s *VehicleService) FindAvailableVehicle(ctx context.Context, req *vehiclep
 // Always prefer explicit fields over maps or "any" types — protobuf enforces this contract.
 func (s *VehicleService) GetVehicleInfo(ctx context.Context, req *vehiclepb.InfoRequest) (*vehiclepb.InfoResponse, error) {
 	log.Printf("GetVehicleInfo called with vehicleId=%s", req.GetVehicleId())
+	if req.GetVehicleId() == "" {
+		return nil, models.NewValidationError("vehicleId is required")
+	}
 
 	// TODO: call your database or cache to fetch vehicle details.
 	return &vehiclepb.InfoResponse{
@@ -88,3 +123,87 @@ func (s *VehicleService) StreamAssignments(stream vehiclepb.VehicleService_Strea
 		}
 	}
 }
+
+// TenantKey is the metadata key naming the tenant a reservation belongs
+// to; calls without it act for DefaultTenant.
+const (
+	TenantKey     = "x-tenant-id"
+	DefaultTenant = "default"
+)
+
+// ReserveVehicle holds a vehicle for a time window. A retry with the same
+// reservation ID stores the same reservation again, so callers may repeat a
+// call whose answer they did not get; a call naming another vehicle or
+// window moves the reservation. A window overlapping another reservation of
+// the vehicle in the same tenant is a conflict.
+func (s *VehicleService) ReserveVehicle(ctx context.Context, req *vehiclepb.ReserveRequest) (*vehiclepb.ReserveResponse, error) {
+	log.Printf("ReserveVehicle called with reservationId=%s vehicleId=%s", req.GetReservationId(), req.GetVehicleId())
+	r, err := reservationOf(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if s.reservations == nil {
+		return nil, errNoReservations
+	}
+	if err := s.reservations.Save(ctx, r); err != nil {
+		return nil, err
+	}
+	return &vehiclepb.ReserveResponse{ReservationId: r.ID}, nil
+}
+
+// ReleaseVehicle drops a reservation. Releasing an unknown reservation is
+// not an error: compensation may run more than once, or after a
+// reservation that never arrived.
+func (s *VehicleService) ReleaseVehicle(ctx context.Context, req *vehiclepb.ReleaseRequest) (*vehiclepb.ReleaseResponse, error) {
+	log.Printf("ReleaseVehicle called with reservationId=%s", req.GetReservationId())
+	if req.GetReservationId() == "" {
+		return nil, models.NewValidationError("reservationId is required")
+	}
+	if s.reservations == nil {
+		return nil, errNoReservations
+	}
+
+	released, err := s.reservations.Delete(ctx, tenantOf(ctx), req.GetReservationId())
+	if err != nil {
+		return nil, err
+	}
+	return &vehiclepb.ReleaseResponse{Released: released}, nil
+}
+
+var errNoReservations = models.NewUnavailableError(nil, "reservations are not configured")
+
+// tenantOf returns the tenant named by the call's metadata.
+func tenantOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(TenantKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return DefaultTenant
+}
+
+// reservationOf validates req, reporting every missing field at once.
+func reservationOf(ctx context.Context, req *vehiclepb.ReserveRequest) (models.Reservation, error) {
+	var problems []string
+	if req.GetReservationId() == "" {
+		problems = append(problems, "reservationId is required")
+	}
+	if req.GetVehicleId() == "" {
+		problems = append(problems, "vehicleId is required")
+	}
+	if req.GetStartsAt() == nil || req.GetEndsAt() == nil {
+		problems = append(problems, "startsAt and endsAt are required")
+	} else if !req.GetEndsAt().AsTime().After(req.GetStartsAt().AsTime()) {
+		problems = append(problems, "endsAt must be after startsAt")
+	}
+	if len(problems) > 0 {
+		return models.Reservation{}, models.NewValidationError("%s", strings.Join(problems, "; "))
+	}
+	return models.Reservation{
+		TenantID:  tenantOf(ctx),
+		ID:        req.GetReservationId(),
+		VehicleID: req.GetVehicleId(),
+		RouteID:   req.GetRouteId(),
+		StartsAt:  req.GetStartsAt().AsTime(),
+		EndsAt:    req.GetEndsAt().AsTime(),
+	}, nil
+}